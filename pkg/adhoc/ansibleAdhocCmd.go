@@ -3,6 +3,7 @@ package adhoc
 import (
 	"fmt"
 
+	"github.com/apenella/go-ansible/v2/pkg/redact"
 	errors "github.com/apenella/go-common-utils/error"
 )
 
@@ -85,7 +86,7 @@ func (a *AnsibleAdhocCmd) Command() ([]string, error) {
 	return cmd, nil
 }
 
-// String returns AnsibleAdhocCmd as string
+// String returns AnsibleAdhocCmd as string. The secrets registered on the redact package and the secrets of the options are masked
 func (a *AnsibleAdhocCmd) String() string {
 
 	// Use default binary when it is not already defined
@@ -101,5 +102,14 @@ func (a *AnsibleAdhocCmd) String() string {
 		str = fmt.Sprintf("%s %s", str, a.AdhocOptions.String())
 	}
 
-	return str
+	return redact.RedactWith(str, a.Secrets()...)
+}
+
+// Secrets returns the secrets of the options, which DefaultExecute masks on the outputs of the execution
+func (a *AnsibleAdhocCmd) Secrets() []string {
+	if a.AdhocOptions == nil {
+		return []string{}
+	}
+
+	return a.AdhocOptions.Secrets()
 }
@@ -0,0 +1,13 @@
+package adhoc
+
+import (
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+)
+
+// AnsibleAdhocErrorEnrich is the error enricher for ansible errors. It is an execute.ExitCodeErrorEnrich that converts the errors into the typed errors defined in the execute package, such as execute.BadOptionsError, when the exit code is known
+type AnsibleAdhocErrorEnrich = execute.ExitCodeErrorEnrich
+
+// NewAnsibleAdhocErrorEnrich creates a new AnsibleAdhocErrorEnrich instance
+func NewAnsibleAdhocErrorEnrich() *AnsibleAdhocErrorEnrich {
+	return execute.NewExitCodeErrorEnrich(DefaultAnsibleAdhocBinary)
+}
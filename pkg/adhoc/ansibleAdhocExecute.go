@@ -44,6 +44,7 @@ func (e *AnsibleAdhocExecute) Execute(ctx context.Context) error {
 
 	exec := execute.NewDefaultExecute(
 		execute.WithCmd(e.cmd),
+		execute.WithErrorEnrich(NewAnsibleAdhocErrorEnrich()),
 	)
 
 	err := exec.Execute(ctx)
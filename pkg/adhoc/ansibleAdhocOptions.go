@@ -2,9 +2,13 @@ package adhoc
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/apenella/go-ansible/v2/pkg/redact"
+	"github.com/apenella/go-ansible/v2/pkg/vault"
 	common "github.com/apenella/go-common-utils/data"
 	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/afero"
 )
 
 const (
@@ -188,6 +192,9 @@ type AnsibleAdhocOptions struct {
 	// VaultID the vault identity to use
 	VaultID string
 
+	// VaultKeyring the vault identities to use, passed as a --vault-id flag per vault identity
+	VaultKeyring *vault.VaultKeyring
+
 	// VaultPasswordFile path to the file holding vault decryption key
 	VaultPasswordFile string
 
@@ -263,6 +270,9 @@ type AnsibleAdhocOptions struct {
 
 	// BecomeUser is ansble-playbook's become user
 	BecomeUser string
+
+	// secrets are the plain values of the vaulted extra-vars, which are masked on the outputs of the executions
+	secrets []string
 }
 
 // AddExtraVar registers a new extra variable
@@ -297,6 +307,79 @@ func (o *AnsibleAdhocOptions) AddExtraVarsFile(file string) error {
 	return nil
 }
 
+// AddVaultedExtraVar registers a new extra variable vaulting its value. The plain value is kept as a secret of the options to be masked on the outputs
+func (o *AnsibleAdhocOptions) AddVaultedExtraVar(vaulter Vaulter, name string, value string) error {
+
+	if vaulter == nil {
+		return errors.New("(adhoc::AddVaultedExtraVar)", "To define a vaulted extra-var you need to initialize a vaulter")
+	}
+
+	if o.ExtraVars == nil {
+		o.ExtraVars = map[string]interface{}{}
+	}
+
+	_, exists := o.ExtraVars[name]
+	if exists {
+		return errors.New("(adhoc::AddVaultedExtraVar)", fmt.Sprintf("ExtraVar '%s' already exist", name))
+	}
+
+	vaultedValue, err := vaulter.Vault(value)
+	if err != nil {
+		return errors.New("(adhoc::AddVaultedExtraVar)", fmt.Sprintf("Variable '%s' can not be vaulted", name), err)
+	}
+	o.secrets = append(o.secrets, value)
+
+	o.ExtraVars[name] = vaultedValue
+
+	return nil
+}
+
+// AddVaultedExtraVars registers the extra variables on ansible adhoc options item vaulting every string value, including the ones of the nested maps and lists. The plain values are kept as secrets of the options to be masked on the outputs
+func (o *AnsibleAdhocOptions) AddVaultedExtraVars(vaulter Vaulter, vars map[string]interface{}) error {
+
+	if vaulter == nil {
+		return errors.New("(adhoc::AddVaultedExtraVars)", "To define vaulted extra-vars you need to initialize a vaulter")
+	}
+
+	if o.ExtraVars == nil {
+		o.ExtraVars = map[string]interface{}{}
+	}
+
+	for name := range vars {
+		_, exists := o.ExtraVars[name]
+		if exists {
+			return errors.New("(adhoc::AddVaultedExtraVars)", fmt.Sprintf("ExtraVar '%s' already exist", name))
+		}
+	}
+
+	vaultedVars, err := vault.VaultVariables(vaulter, vars)
+	if err != nil {
+		return errors.New("(adhoc::AddVaultedExtraVars)", "Extra-vars can not be vaulted", err)
+	}
+
+	for name, value := range vaultedVars {
+		o.ExtraVars[name] = value
+	}
+	o.secrets = append(o.secrets, vault.VariablesStringValues(vars)...)
+
+	return nil
+}
+
+// AddVaultedExtraVarsFile encrypts the whole extra-vars file, writing it to the vaulted file, and adds the vaulted file as an extra-vars file on ansible adhoc options item. Both files are accessed through the filesystem, which is the OS filesystem when it is nil. The vaulted file requires the vault password to be provided to the command
+func (o *AnsibleAdhocOptions) AddVaultedExtraVarsFile(fs afero.Fs, encrypter vault.Encrypter, file, vaultedFile string) error {
+
+	if encrypter == nil {
+		return errors.New("(adhoc::AddVaultedExtraVarsFile)", "To define a vaulted extra-vars file you need to initialize an encrypter")
+	}
+
+	err := vault.EncryptVariablesFile(fs, encrypter, strings.TrimPrefix(file, "@"), strings.TrimPrefix(vaultedFile, "@"))
+	if err != nil {
+		return errors.New("(adhoc::AddVaultedExtraVarsFile)", fmt.Sprintf("Extra-vars file '%s' can not be vaulted", file), err)
+	}
+
+	return o.AddExtraVarsFile(vaultedFile)
+}
+
 // GenerateAnsibleAdhocOptions return a list of command options flags to be used on ansible execution
 func (o *AnsibleAdhocOptions) GenerateAnsibleAdhocOptions() ([]string, error) {
 	cmd := []string{}
@@ -398,6 +481,17 @@ func (o *AnsibleAdhocOptions) GenerateAnsibleAdhocOptions() ([]string, error) {
 		cmd = append(cmd, o.VaultID)
 	}
 
+	if o.VaultKeyring != nil {
+		vaultIDs, err := o.VaultKeyring.VaultIDs()
+		if err != nil {
+			return nil, errors.New("(adhoc::GenerateAnsibleAdhocOptions)", "Error generating the vault identities", err)
+		}
+
+		for _, vaultID := range vaultIDs {
+			cmd = append(cmd, VaultIDFlag, vaultID)
+		}
+	}
+
 	if o.VaultPasswordFile != "" {
 		cmd = append(cmd, VaultPasswordFileFlag)
 		cmd = append(cmd, o.VaultPasswordFile)
@@ -515,6 +609,14 @@ func (o *AnsibleAdhocOptions) generateVerbosityFlag() (string, error) {
 	return "", nil
 }
 
+// Secrets returns the plain values of the vaulted extra-vars and the values of the extra-vars whose name holds a secret, such as ansible_become_password. DefaultExecute masks them on the outputs of the execution
+func (o *AnsibleAdhocOptions) Secrets() []string {
+	secrets := append([]string{}, o.secrets...)
+	secrets = append(secrets, redact.SensitiveValues(o.ExtraVars)...)
+
+	return secrets
+}
+
 // GenerateCommandCommonOptions return a list of command options flags to be used on ansible execution
 func (o *AnsibleAdhocOptions) String() string {
 	str := ""
@@ -596,6 +698,13 @@ func (o *AnsibleAdhocOptions) String() string {
 		str = fmt.Sprintf("%s %s %s", str, VaultIDFlag, o.VaultID)
 	}
 
+	if o.VaultKeyring != nil {
+		vaultIDs, _ := o.VaultKeyring.VaultIDs()
+		for _, vaultID := range vaultIDs {
+			str = fmt.Sprintf("%s %s %s", str, VaultIDFlag, vaultID)
+		}
+	}
+
 	if o.VaultPasswordFile != "" {
 		str = fmt.Sprintf("%s %s %s", str, VaultPasswordFileFlag, o.VaultPasswordFile)
 	}
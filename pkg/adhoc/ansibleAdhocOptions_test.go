@@ -3,7 +3,10 @@ package adhoc
 import (
 	"testing"
 
+	"github.com/apenella/go-ansible/v2/pkg/vault"
+	"github.com/apenella/go-ansible/v2/pkg/vault/encrypt"
 	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -274,6 +277,16 @@ func TestAnsibleAdhocOptionsString(t *testing.T) {
 			},
 			res: " -vvvv",
 		},
+		{
+			desc: "Testing AnsibleAdhocOptions with a vault keyring",
+			options: &AnsibleAdhocOptions{
+				VaultKeyring: vault.NewVaultKeyring(
+					vault.WithVaultIdentitySource("dev", "dev-password-file"),
+					vault.WithVaultIdentitySource("prod", vault.VaultIDPromptSource),
+				),
+			},
+			res: " --vault-id dev@dev-password-file --vault-id prod@prompt",
+		},
 	}
 
 	for _, test := range tests {
@@ -475,3 +488,154 @@ func TestGenerateVerbosityFlag(t *testing.T) {
 		})
 	}
 }
+
+// AddVaultedExtraVars(vaulter Vaulter, vars map[string]interface{})
+func TestAddVaultedExtraVars(t *testing.T) {
+	vaulter := vault.NewMockVariableVaulter()
+	vaulter.On("Vault", "admin").Return(vault.NewVaultVariableValue("encrypted_admin"), nil)
+	vaulter.On("Vault", "secret").Return(vault.NewVaultVariableValue("encrypted_secret"), nil)
+
+	tests := []struct {
+		desc    string
+		options *AnsibleAdhocOptions
+		vaulter Vaulter
+		vars    map[string]interface{}
+		res     map[string]interface{}
+		err     error
+	}{
+		{
+			desc:    "Testing add vaulted extra-vars",
+			options: &AnsibleAdhocOptions{},
+			vaulter: vaulter,
+			vars: map[string]interface{}{
+				"user": "admin",
+				"db": map[string]interface{}{
+					"password": "secret",
+					"port":     5432,
+				},
+			},
+			res: map[string]interface{}{
+				"user": vault.NewVaultVariableValue("encrypted_admin"),
+				"db": map[string]interface{}{
+					"password": vault.NewVaultVariableValue("encrypted_secret"),
+					"port":     5432,
+				},
+			},
+		},
+		{
+			desc:    "Testing error adding vaulted extra-vars when vaulter is nil",
+			options: &AnsibleAdhocOptions{},
+			vaulter: nil,
+			err:     errors.New("(adhoc::AddVaultedExtraVars)", "To define vaulted extra-vars you need to initialize a vaulter"),
+		},
+		{
+			desc: "Testing error adding vaulted extra-vars when a variable already exist",
+			options: &AnsibleAdhocOptions{
+				ExtraVars: map[string]interface{}{
+					"user": "admin",
+				},
+			},
+			vaulter: vaulter,
+			vars: map[string]interface{}{
+				"user": "admin",
+			},
+			err: errors.New("(adhoc::AddVaultedExtraVars)", "ExtraVar 'user' already exist"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.AddVaultedExtraVars(test.vaulter, test.vars)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.res, test.options.ExtraVars, "Unexpected options value")
+				// every vaulted value is masked on the outputs
+				assert.ElementsMatch(t, []string{"admin", "secret"}, test.options.Secrets())
+			}
+		})
+	}
+}
+
+// AddVaultedExtraVarsFile(fs afero.Fs, encrypter vault.Encrypter, file, vaultedFile string)
+func TestAddVaultedExtraVarsFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_ = afero.WriteFile(fs, "/vars.yml", []byte("password: secret\n"), 0600)
+
+	encrypter := encrypt.NewMockEncryptString()
+	encrypter.On("Encrypt", "password: secret\n").Return("$ANSIBLE_VAULT;1.1;AES256\n3238", nil)
+
+	options := &AnsibleAdhocOptions{}
+	err := options.AddVaultedExtraVarsFile(fs, encrypter, "@/vars.yml", "/vars.vault.yml")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"@/vars.vault.yml"}, options.ExtraVarsFile)
+
+	content, _ := afero.ReadFile(fs, "/vars.vault.yml")
+	assert.Equal(t, "$ANSIBLE_VAULT;1.1;AES256\n3238\n", string(content))
+
+	err = options.AddVaultedExtraVarsFile(fs, nil, "/vars.yml", "/vars.vault.yml")
+	assert.Equal(t, errors.New("(adhoc::AddVaultedExtraVarsFile)", "To define a vaulted extra-vars file you need to initialize an encrypter"), err)
+}
+
+// AddVaultedExtraVar(vaulter Vaulter, name string, value string)
+func TestAddVaultedExtraVar(t *testing.T) {
+	vaulter := vault.NewMockVariableVaulter()
+	vaulter.On("Vault", "plain_text_value").Return(vault.NewVaultVariableValue("encrypted_value"), nil)
+
+	tests := []struct {
+		desc    string
+		options *AnsibleAdhocOptions
+		vaulter Vaulter
+		name    string
+		value   string
+		res     map[string]interface{}
+		err     error
+	}{
+		{
+			desc:    "Testing add a vaulted extra-var",
+			options: &AnsibleAdhocOptions{},
+			vaulter: vaulter,
+			name:    "variable_name",
+			value:   "plain_text_value",
+			res: map[string]interface{}{
+				"variable_name": vault.NewVaultVariableValue("encrypted_value"),
+			},
+		},
+		{
+			desc:    "Testing error adding a vaulted extra-var when vaulter is nil",
+			options: &AnsibleAdhocOptions{},
+			vaulter: nil,
+			err:     errors.New("(adhoc::AddVaultedExtraVar)", "To define a vaulted extra-var you need to initialize a vaulter"),
+		},
+		{
+			desc: "Testing error adding a vaulted extra-var when variable already exist",
+			options: &AnsibleAdhocOptions{
+				ExtraVars: map[string]interface{}{
+					"variable_name": "{\"__ansible_vault\":\"encrypted_value\"}",
+				},
+			},
+			vaulter: vaulter,
+			name:    "variable_name",
+			value:   "plain_text_value",
+			err:     errors.New("(adhoc::AddVaultedExtraVar)", "ExtraVar 'variable_name' already exist"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.AddVaultedExtraVar(test.vaulter, test.name, test.value)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.res, test.options.ExtraVars, "Unexpected options value")
+				assert.Equal(t, []string{test.value}, test.options.Secrets())
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package adhoc
+
+import "github.com/apenella/go-ansible/v2/pkg/vault"
+
+// Vaulter is the interface to vault the extra variables values
+type Vaulter interface {
+	Vault(value string) (*vault.VaultVariableValue, error)
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/apenella/go-ansible/v2/pkg/redact"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// DefaultAnsibleConfigBinary is the ansible-config binary file default value
+	DefaultAnsibleConfigBinary = "ansible-config"
+
+	// AnsibleConfigDumpSubCommand is the ansible-config subcommand to show the current settings
+	AnsibleConfigDumpSubCommand = "dump"
+
+	// AnsibleConfigInitSubCommand is the ansible-config subcommand to create an initial configuration
+	AnsibleConfigInitSubCommand = "init"
+
+	// AnsibleConfigListSubCommand is the ansible-config subcommand to list all the available settings
+	AnsibleConfigListSubCommand = "list"
+
+	// AnsibleConfigViewSubCommand is the ansible-config subcommand to show the content of the configuration file
+	AnsibleConfigViewSubCommand = "view"
+)
+
+// AnsibleConfigOptionsFunc is a function to set executor options
+type AnsibleConfigOptionsFunc func(*AnsibleConfigCmd)
+
+// AnsibleConfigCmd object is the main object which defines the `ansible-config` command to view, dump and list the ansible configuration.
+type AnsibleConfigCmd struct {
+	// Binary is the ansible-config binary file
+	Binary string
+
+	// SubCommand is the ansible-config subcommand, such as dump or list
+	SubCommand string
+
+	// Args are the plugins whose settings are listed, dumped or initialized
+	Args []string
+
+	// ConfigOptions are the ansible-config options
+	ConfigOptions *AnsibleConfigOptions
+}
+
+// NewAnsibleConfigCmd creates a new AnsibleConfigCmd instance
+func NewAnsibleConfigCmd(options ...AnsibleConfigOptionsFunc) *AnsibleConfigCmd {
+	cmd := &AnsibleConfigCmd{}
+
+	for _, option := range options {
+		option(cmd)
+	}
+
+	return cmd
+}
+
+// WithBinary set the ansible-config binary file
+func WithBinary(binary string) AnsibleConfigOptionsFunc {
+	return func(p *AnsibleConfigCmd) {
+		p.Binary = binary
+	}
+}
+
+// WithSubCommand set the ansible-config subcommand
+func WithSubCommand(subCommand string) AnsibleConfigOptionsFunc {
+	return func(p *AnsibleConfigCmd) {
+		p.SubCommand = subCommand
+	}
+}
+
+// WithArgs set the plugins whose settings are listed, dumped or initialized
+func WithArgs(args ...string) AnsibleConfigOptionsFunc {
+	return func(p *AnsibleConfigCmd) {
+		p.Args = append([]string{}, args...)
+	}
+}
+
+// WithConfigOptions set the ansible-config options
+func WithConfigOptions(options *AnsibleConfigOptions) AnsibleConfigOptionsFunc {
+	return func(p *AnsibleConfigCmd) {
+		p.ConfigOptions = options
+	}
+}
+
+// validate checks that the subcommand is supported and that it accepts the options that are set
+func (p *AnsibleConfigCmd) validate() error {
+	errContext := "(config::AnsibleConfigCmd::validate)"
+
+	switch p.SubCommand {
+	case "":
+		return errors.New(errContext, "Ansible config subcommand is not defined")
+	case AnsibleConfigDumpSubCommand, AnsibleConfigInitSubCommand, AnsibleConfigListSubCommand, AnsibleConfigViewSubCommand:
+	default:
+		return errors.New(errContext, fmt.Sprintf("Ansible config subcommand '%s' is not supported", p.SubCommand))
+	}
+
+	if p.ConfigOptions == nil {
+		return nil
+	}
+
+	if p.ConfigOptions.OnlyChanged && p.SubCommand != AnsibleConfigDumpSubCommand {
+		return errors.New(errContext, fmt.Sprintf("Ansible config '%s' option is only supported by the '%s' subcommand", OnlyChangedFlag, AnsibleConfigDumpSubCommand))
+	}
+
+	if p.ConfigOptions.Disabled && p.SubCommand != AnsibleConfigInitSubCommand {
+		return errors.New(errContext, fmt.Sprintf("Ansible config '%s' option is only supported by the '%s' subcommand", DisabledFlag, AnsibleConfigInitSubCommand))
+	}
+
+	if p.ConfigOptions.Format != "" && p.SubCommand == AnsibleConfigViewSubCommand {
+		return errors.New(errContext, fmt.Sprintf("Ansible config '%s' option is not supported by the '%s' subcommand", FormatFlag, AnsibleConfigViewSubCommand))
+	}
+
+	return nil
+}
+
+// Command generate the ansible-config command which will be executed
+func (p *AnsibleConfigCmd) Command() ([]string, error) {
+	cmd := []string{}
+
+	errContext := "(config::AnsibleConfigCmd::Command)"
+
+	err := p.validate()
+	if err != nil {
+		return nil, errors.New(errContext, "Error validating ansible-config command", err)
+	}
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = DefaultAnsibleConfigBinary
+	}
+
+	cmd = append(cmd, p.Binary, p.SubCommand)
+
+	// Add the options
+	if p.ConfigOptions != nil {
+		options, err := p.ConfigOptions.GenerateCommandOptions()
+		if err != nil {
+			return nil, errors.New(errContext, "Error generating ansible-config options", err)
+		}
+		cmd = append(cmd, options...)
+	}
+
+	// Add the plugins
+	cmd = append(cmd, p.Args...)
+
+	return cmd, nil
+}
+
+// String returns the ansible-config command as a string. The secrets registered on the redact package are masked
+func (p *AnsibleConfigCmd) String() string {
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = DefaultAnsibleConfigBinary
+	}
+
+	str := fmt.Sprintf("%s %s", p.Binary, p.SubCommand)
+
+	if p.ConfigOptions != nil {
+		str = fmt.Sprintf("%s %s", str, p.ConfigOptions.String())
+	}
+
+	// Include the plugins
+	for _, arg := range p.Args {
+		str = fmt.Sprintf("%s %s", str, arg)
+	}
+
+	return redact.Redact(str)
+}
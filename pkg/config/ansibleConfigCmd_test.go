@@ -0,0 +1,173 @@
+package config
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleConfigCmd(t *testing.T) {
+	cmd := NewAnsibleConfigCmd(
+		WithBinary("ansible-config-binary"),
+		WithSubCommand(AnsibleConfigListSubCommand),
+		WithArgs("ssh"),
+		WithConfigOptions(&AnsibleConfigOptions{
+			Type: "connection",
+		}),
+	)
+
+	expect := &AnsibleConfigCmd{
+		Binary:     "ansible-config-binary",
+		SubCommand: AnsibleConfigListSubCommand,
+		Args:       []string{"ssh"},
+		ConfigOptions: &AnsibleConfigOptions{
+			Type: "connection",
+		},
+	}
+
+	assert.Equal(t, expect, cmd)
+}
+
+func TestAnsibleConfigCmdCommand(t *testing.T) {
+
+	errContext := "(config::AnsibleConfigCmd::Command)"
+	validateErrContext := "(config::AnsibleConfigCmd::validate)"
+
+	tests := []struct {
+		desc    string
+		cmd     *AnsibleConfigCmd
+		command []string
+		err     error
+	}{
+		{
+			desc: "Testing generate a dump command using default binary",
+			cmd: NewAnsibleConfigCmd(
+				WithSubCommand(AnsibleConfigDumpSubCommand),
+				WithConfigOptions(&AnsibleConfigOptions{
+					Format:      FormatJSON,
+					OnlyChanged: true,
+				}),
+			),
+			command: []string{
+				DefaultAnsibleConfigBinary,
+				AnsibleConfigDumpSubCommand,
+				FormatFlag, FormatJSON,
+				OnlyChangedFlag,
+			},
+		},
+		{
+			desc: "Testing generate a list command of a plugin",
+			cmd: NewAnsibleConfigCmd(
+				WithBinary("ansible-config-binary"),
+				WithSubCommand(AnsibleConfigListSubCommand),
+				WithArgs("ssh"),
+				WithConfigOptions(&AnsibleConfigOptions{
+					Format: FormatYAML,
+					Type:   "connection",
+				}),
+			),
+			command: []string{
+				"ansible-config-binary",
+				AnsibleConfigListSubCommand,
+				FormatFlag, FormatYAML,
+				TypeFlag, "connection",
+				"ssh",
+			},
+		},
+		{
+			desc: "Testing generate an init command",
+			cmd: NewAnsibleConfigCmd(
+				WithSubCommand(AnsibleConfigInitSubCommand),
+				WithConfigOptions(&AnsibleConfigOptions{
+					Disabled: true,
+					Format:   FormatINI,
+				}),
+			),
+			command: []string{
+				DefaultAnsibleConfigBinary,
+				AnsibleConfigInitSubCommand,
+				DisabledFlag,
+				FormatFlag, FormatINI,
+			},
+		},
+		{
+			desc: "Testing generate a view command",
+			cmd: NewAnsibleConfigCmd(
+				WithSubCommand(AnsibleConfigViewSubCommand),
+				WithConfigOptions(&AnsibleConfigOptions{
+					Config: "ansible.cfg",
+				}),
+			),
+			command: []string{
+				DefaultAnsibleConfigBinary,
+				AnsibleConfigViewSubCommand,
+				ConfigFlag, "ansible.cfg",
+			},
+		},
+		{
+			desc: "Testing generate a command without subcommand",
+			cmd:  NewAnsibleConfigCmd(),
+			err:  errors.New(errContext, "Error validating ansible-config command", errors.New(validateErrContext, "Ansible config subcommand is not defined")),
+		},
+		{
+			desc: "Testing generate a command with an unsupported subcommand",
+			cmd:  NewAnsibleConfigCmd(WithSubCommand("unknown")),
+			err:  errors.New(errContext, "Error validating ansible-config command", errors.New(validateErrContext, "Ansible config subcommand 'unknown' is not supported")),
+		},
+		{
+			desc: "Testing generate a list command with the only changed option",
+			cmd: NewAnsibleConfigCmd(
+				WithSubCommand(AnsibleConfigListSubCommand),
+				WithConfigOptions(&AnsibleConfigOptions{OnlyChanged: true}),
+			),
+			err: errors.New(errContext, "Error validating ansible-config command", errors.New(validateErrContext, "Ansible config '--only-changed' option is only supported by the 'dump' subcommand")),
+		},
+		{
+			desc: "Testing generate a dump command with the disabled option",
+			cmd: NewAnsibleConfigCmd(
+				WithSubCommand(AnsibleConfigDumpSubCommand),
+				WithConfigOptions(&AnsibleConfigOptions{Disabled: true}),
+			),
+			err: errors.New(errContext, "Error validating ansible-config command", errors.New(validateErrContext, "Ansible config '--disabled' option is only supported by the 'init' subcommand")),
+		},
+		{
+			desc: "Testing generate a view command with the format option",
+			cmd: NewAnsibleConfigCmd(
+				WithSubCommand(AnsibleConfigViewSubCommand),
+				WithConfigOptions(&AnsibleConfigOptions{Format: FormatJSON}),
+			),
+			err: errors.New(errContext, "Error validating ansible-config command", errors.New(validateErrContext, "Ansible config '--format' option is not supported by the 'view' subcommand")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			command, err := test.cmd.Command()
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.command, command)
+		})
+	}
+}
+
+func TestAnsibleConfigCmdString(t *testing.T) {
+	t.Run("Testing ansible-config command string", func(t *testing.T) {
+		cmd := NewAnsibleConfigCmd(
+			WithSubCommand(AnsibleConfigDumpSubCommand),
+			WithArgs("ssh"),
+			WithConfigOptions(&AnsibleConfigOptions{
+				OnlyChanged: true,
+				Type:        "connection",
+			}),
+		)
+
+		assert.Equal(t, "ansible-config dump  --only-changed --type connection ssh", cmd.String())
+	})
+}
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// OriginDefault is the origin of the settings that keep their default value
+	OriginDefault = "default"
+
+	// OriginRequired is the origin of the required settings that are not defined
+	OriginRequired = "REQUIRED"
+
+	// OriginEnv is the origin of the settings defined by an environment variable
+	OriginEnv = "env"
+
+	// OriginIni is the origin of the settings defined in an ansible.cfg file
+	OriginIni = "ini"
+
+	// OriginVar is the origin of the settings defined by an ansible variable
+	OriginVar = "var"
+
+	// originSeparator is the separator between the origin kind and its source, such as `env: ANSIBLE_FORKS`
+	originSeparator = ": "
+
+	// pluginsKeySuffix is the suffix of the plugin types keys of the ansible-config dump output, such as CALLBACK_PLUGINS
+	pluginsKeySuffix = "_PLUGINS"
+)
+
+// AnsibleConfigSetting is a setting of the `ansible-config dump --format json` output
+type AnsibleConfigSetting struct {
+	// Name is the setting name, such as DEFAULT_FORKS
+	Name string `json:"name"`
+
+	// Value is the effective value of the setting, decoded from JSON
+	Value interface{} `json:"value"`
+
+	// Origin is the origin of the value as it is reported by ansible-config, such as `default`, `env: ANSIBLE_FORKS` or the path of an ansible.cfg file
+	Origin string `json:"origin"`
+
+	// OriginKind is the kind of origin of the value: OriginDefault, OriginRequired, OriginEnv, OriginIni, OriginVar or any other kind reported by ansible-config
+	OriginKind string `json:"-"`
+
+	// OriginSource is the source of the value, such as the environment variable name or the ansible.cfg file path. It is empty for the default values
+	OriginSource string `json:"-"`
+
+	// PluginType is the plugin type of the plugin settings, such as callback. It is empty for the ansible core settings
+	PluginType string `json:"-"`
+
+	// Plugin is the plugin name of the plugin settings. It is empty for the ansible core settings
+	Plugin string `json:"-"`
+}
+
+// AnsibleConfigDump is the effective configuration reported by the `ansible-config dump --format json` command
+type AnsibleConfigDump struct {
+	// Settings are the settings, in the order they are reported by ansible-config
+	Settings []*AnsibleConfigSetting
+}
+
+// ParseAnsibleConfigDump parses the `ansible-config dump --format json` output. It supports the output of the ansible core settings, of a plugin type, and of all the plugin types
+func ParseAnsibleConfigDump(data []byte) (*AnsibleConfigDump, error) {
+	var items []map[string]json.RawMessage
+
+	errContext := "(config::ParseAnsibleConfigDump)"
+
+	err := json.Unmarshal(data, &items)
+	if err != nil {
+		return nil, errors.New(errContext, "Error decoding the ansible-config dump output", err)
+	}
+
+	dump := &AnsibleConfigDump{
+		Settings: []*AnsibleConfigSetting{},
+	}
+
+	for _, item := range items {
+		// the ansible core settings are objects with the name, value and origin keys
+		if _, isSetting := item["name"]; isSetting {
+			setting, err := parseAnsibleConfigSetting(item, "", "")
+			if err != nil {
+				return nil, errors.New(errContext, "Error parsing the ansible-config dump output", err)
+			}
+			dump.Settings = append(dump.Settings, setting)
+			continue
+		}
+
+		// the rest of items group the plugin settings by plugin type or by plugin
+		for _, key := range sortedRawKeys(item) {
+			var settings []*AnsibleConfigSetting
+
+			if strings.HasSuffix(key, pluginsKeySuffix) {
+				pluginType := strings.ToLower(strings.TrimSuffix(key, pluginsKeySuffix))
+				settings, err = parsePluginTypeSettings(item[key], pluginType)
+			} else {
+				settings, err = parsePluginSettings(item[key], "", key)
+			}
+			if err != nil {
+				return nil, errors.New(errContext, fmt.Sprintf("Error parsing the '%s' settings of the ansible-config dump output", key), err)
+			}
+
+			dump.Settings = append(dump.Settings, settings...)
+		}
+	}
+
+	return dump, nil
+}
+
+// Setting returns the ansible core setting with the name
+func (d *AnsibleConfigDump) Setting(name string) (*AnsibleConfigSetting, bool) {
+	for _, setting := range d.Settings {
+		if setting.Plugin == "" && setting.Name == name {
+			return setting, true
+		}
+	}
+
+	return nil, false
+}
+
+// PluginSetting returns the setting with the name of the plugin
+func (d *AnsibleConfigDump) PluginSetting(plugin, name string) (*AnsibleConfigSetting, bool) {
+	for _, setting := range d.Settings {
+		if setting.Plugin == plugin && setting.Name == name {
+			return setting, true
+		}
+	}
+
+	return nil, false
+}
+
+// Changed returns the settings whose value is not the default one
+func (d *AnsibleConfigDump) Changed() []*AnsibleConfigSetting {
+	changed := []*AnsibleConfigSetting{}
+
+	for _, setting := range d.Settings {
+		if setting.IsChanged() {
+			changed = append(changed, setting)
+		}
+	}
+
+	return changed
+}
+
+// ByOriginKind returns the settings whose value comes from the kind of origin, such as OriginEnv or OriginIni
+func (d *AnsibleConfigDump) ByOriginKind(kind string) []*AnsibleConfigSetting {
+	settings := []*AnsibleConfigSetting{}
+
+	for _, setting := range d.Settings {
+		if setting.OriginKind == kind {
+			settings = append(settings, setting)
+		}
+	}
+
+	return settings
+}
+
+// IsChanged returns whether the setting value is not the default one
+func (s *AnsibleConfigSetting) IsChanged() bool {
+	return s.OriginKind != OriginDefault && s.OriginKind != OriginRequired
+}
+
+// parsePluginTypeSettings parses the settings of a plugin type, which is a list of objects that hold the settings of each plugin
+func parsePluginTypeSettings(data json.RawMessage, pluginType string) ([]*AnsibleConfigSetting, error) {
+	var plugins []map[string]json.RawMessage
+
+	err := json.Unmarshal(data, &plugins)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := []*AnsibleConfigSetting{}
+	for _, plugin := range plugins {
+		for _, name := range sortedRawKeys(plugin) {
+			pluginSettings, err := parsePluginSettings(plugin[name], pluginType, name)
+			if err != nil {
+				return nil, fmt.Errorf("plugin '%s': %w", name, err)
+			}
+			settings = append(settings, pluginSettings...)
+		}
+	}
+
+	return settings, nil
+}
+
+// parsePluginSettings parses the settings of a plugin, which is a list of settings
+func parsePluginSettings(data json.RawMessage, pluginType, plugin string) ([]*AnsibleConfigSetting, error) {
+	var items []map[string]json.RawMessage
+
+	err := json.Unmarshal(data, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make([]*AnsibleConfigSetting, 0, len(items))
+	for _, item := range items {
+		setting, err := parseAnsibleConfigSetting(item, pluginType, plugin)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, setting)
+	}
+
+	return settings, nil
+}
+
+// parseAnsibleConfigSetting parses a setting and its origin
+func parseAnsibleConfigSetting(item map[string]json.RawMessage, pluginType, plugin string) (*AnsibleConfigSetting, error) {
+	setting := &AnsibleConfigSetting{}
+
+	fields := map[string]interface{}{
+		"name":   &setting.Name,
+		"value":  &setting.Value,
+		"origin": &setting.Origin,
+	}
+	for key, target := range fields {
+		raw, exists := item[key]
+		if !exists {
+			continue
+		}
+
+		err := json.Unmarshal(raw, target)
+		if err != nil {
+			return nil, fmt.Errorf("invalid setting %s: %w", key, err)
+		}
+	}
+
+	if setting.Name == "" {
+		return nil, fmt.Errorf("setting without name")
+	}
+
+	setting.OriginKind, setting.OriginSource = parseOrigin(setting.Origin)
+	setting.PluginType = pluginType
+	setting.Plugin = plugin
+
+	return setting, nil
+}
+
+// parseOrigin returns the kind and source of the origin reported by ansible-config. The origins that are neither a known keyword nor prefixed by their kind are the path of the ansible.cfg file that defines the setting
+func parseOrigin(origin string) (string, string) {
+	switch origin {
+	case OriginDefault, OriginRequired:
+		return origin, ""
+	}
+
+	kind, source, found := strings.Cut(origin, originSeparator)
+	if found && !strings.ContainsAny(kind, "/\\") {
+		return kind, source
+	}
+
+	return OriginIni, origin
+}
+
+// sortedRawKeys returns the keys of the JSON object sorted alphabetically
+func sortedRawKeys(item map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(item))
+	for key := range item {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
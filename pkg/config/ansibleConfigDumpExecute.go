@@ -0,0 +1,140 @@
+package config
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// AnsibleConfigDumpExecute is an executor for ansible-config command that runs the dump subcommand using the JSON format and parses its output into an AnsibleConfigDump
+type AnsibleConfigDumpExecute struct {
+	cmd        *AnsibleConfigCmd
+	executable execute.Executabler
+	envVars    map[string]string
+	dump       *AnsibleConfigDump
+}
+
+// NewAnsibleConfigDumpExecute returns a new AnsibleConfigDumpExecute
+func NewAnsibleConfigDumpExecute() *AnsibleConfigDumpExecute {
+
+	exec := &AnsibleConfigDumpExecute{
+		cmd:     &AnsibleConfigCmd{},
+		envVars: map[string]string{},
+	}
+
+	return exec
+}
+
+// WithBinary returns an AnsibleConfigDumpExecute with the binary file set
+func (e *AnsibleConfigDumpExecute) WithBinary(binary string) *AnsibleConfigDumpExecute {
+	e.cmd.Binary = binary
+
+	return e
+}
+
+// WithConfigOptions returns an AnsibleConfigDumpExecute with the ansible-config options set. The Format option is ignored, and the options not supported by the dump subcommand are unset
+func (e *AnsibleConfigDumpExecute) WithConfigOptions(options *AnsibleConfigOptions) *AnsibleConfigDumpExecute {
+	e.cmd.ConfigOptions = options
+
+	return e
+}
+
+// WithPlugins returns an AnsibleConfigDumpExecute with the plugins whose settings are dumped set
+func (e *AnsibleConfigDumpExecute) WithPlugins(plugins ...string) *AnsibleConfigDumpExecute {
+	e.cmd.Args = append([]string{}, plugins...)
+
+	return e
+}
+
+// WithExecutable returns an AnsibleConfigDumpExecute with the executabler used to run the command set
+func (e *AnsibleConfigDumpExecute) WithExecutable(executable execute.Executabler) *AnsibleConfigDumpExecute {
+	e.executable = executable
+
+	return e
+}
+
+// AddEnvVar adds an environment variable to the ansible-config execution. It allows the executor to be decorated by the configuration package, so the settings it defines can be audited
+func (e *AnsibleConfigDumpExecute) AddEnvVar(key, value string) {
+	if e.envVars == nil {
+		e.envVars = map[string]string{}
+	}
+
+	e.envVars[key] = value
+}
+
+// Execute method runs the ansible-config dump subcommand and parses its output, which is available through the Dump method
+func (e *AnsibleConfigDumpExecute) Execute(ctx context.Context) error {
+	_, err := e.ExecuteWithDump(ctx)
+
+	return err
+}
+
+// ExecuteWithDump method runs the ansible-config dump subcommand and returns its output parsed into an AnsibleConfigDump
+func (e *AnsibleConfigDumpExecute) ExecuteWithDump(ctx context.Context) (*AnsibleConfigDump, error) {
+	var dump *AnsibleConfigDump
+
+	errContext := "(config::AnsibleConfigDumpExecute::ExecuteWithDump)"
+
+	e.dump = nil
+
+	cmd := e.dumpCmd()
+
+	err := execute.CaptureExecute(ctx,
+		func(stdout []byte) error {
+			var err error
+
+			dump, err = ParseAnsibleConfigDump(stdout)
+			if err != nil {
+				return errors.New(errContext, "Error parsing the ansible-config output", err)
+			}
+
+			return nil
+		},
+		execute.WithCmd(cmd),
+		execute.WithErrorEnrich(NewAnsibleConfigErrorEnrich()),
+		execute.WithExecutable(e.executable),
+		execute.WithEnvVars(e.envVars),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// the plugin type is not reported when the settings of a single plugin type are dumped
+	pluginType := cmd.ConfigOptions.Type
+	if pluginType != "" && pluginType != TypeBase && pluginType != TypeAll {
+		for _, setting := range dump.Settings {
+			if setting.Plugin != "" && setting.PluginType == "" {
+				setting.PluginType = pluginType
+			}
+		}
+	}
+
+	e.dump = dump
+
+	return dump, nil
+}
+
+// Dump returns the configuration parsed on the last execution. It is nil when the command has not been executed or the execution failed
+func (e *AnsibleConfigDumpExecute) Dump() *AnsibleConfigDump {
+	return e.dump
+}
+
+// dumpCmd returns a copy of the ansible-config command with the dump subcommand and the JSON format set, and the options not supported by the dump subcommand unset
+func (e *AnsibleConfigDumpExecute) dumpCmd() *AnsibleConfigCmd {
+	options := &AnsibleConfigOptions{}
+	if e.cmd.ConfigOptions != nil {
+		*options = *e.cmd.ConfigOptions
+	}
+
+	options.Disabled = false
+	options.Format = FormatJSON
+	options.Version = false
+
+	return &AnsibleConfigCmd{
+		Binary:        e.cmd.Binary,
+		SubCommand:    AnsibleConfigDumpSubCommand,
+		Args:          e.cmd.Args,
+		ConfigOptions: options,
+	}
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleConfigDumpExecute(t *testing.T) {
+	tests := []struct {
+		desc       string
+		options    *AnsibleConfigOptions
+		args       []string
+		stdout     string
+		waitErr    error
+		assertFunc func(t *testing.T, e *AnsibleConfigDumpExecute, dump *AnsibleConfigDump, err error)
+	}{
+		{
+			desc:    "Testing execute ansible-config dump returning the parsed configuration",
+			options: &AnsibleConfigOptions{OnlyChanged: true, Format: FormatYAML},
+			args:    []string{"dump", "--format", "json", "--only-changed"},
+			stdout:  `[{"name": "DEFAULT_FORKS", "value": 10, "origin": "env: ANSIBLE_FORKS"}]`,
+			assertFunc: func(t *testing.T, e *AnsibleConfigDumpExecute, dump *AnsibleConfigDump, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []*AnsibleConfigSetting{
+					{Name: "DEFAULT_FORKS", Value: float64(10), Origin: "env: ANSIBLE_FORKS", OriginKind: OriginEnv, OriginSource: "ANSIBLE_FORKS"},
+				}, dump.Settings)
+				assert.Equal(t, dump, e.Dump())
+			},
+		},
+		{
+			desc:    "Testing execute ansible-config dump of a plugin type setting the plugin type to the settings",
+			options: &AnsibleConfigOptions{Type: "connection"},
+			args:    []string{"dump", "--format", "json", "--type", "connection"},
+			stdout:  `[{"ssh": [{"name": "pipelining", "value": true, "origin": "default"}]}]`,
+			assertFunc: func(t *testing.T, e *AnsibleConfigDumpExecute, dump *AnsibleConfigDump, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "connection", dump.Settings[0].PluginType)
+				assert.Equal(t, "ssh", dump.Settings[0].Plugin)
+			},
+		},
+		{
+			desc:   "Testing execute ansible-config dump returning an error when the output is not valid",
+			args:   []string{"dump", "--format", "json"},
+			stdout: `not a json`,
+			assertFunc: func(t *testing.T, e *AnsibleConfigDumpExecute, dump *AnsibleConfigDump, err error) {
+				assert.ErrorContains(t, err, "Error parsing the ansible-config output")
+				assert.Nil(t, dump)
+				assert.Nil(t, e.Dump())
+			},
+		},
+		{
+			desc:    "Testing execute ansible-config dump returning an error when the command fails",
+			args:    []string{"dump", "--format", "json"},
+			waitErr: fmt.Errorf("command failed"),
+			assertFunc: func(t *testing.T, e *AnsibleConfigDumpExecute, dump *AnsibleConfigDump, err error) {
+				assert.ErrorContains(t, err, "command failed")
+				assert.Nil(t, dump)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			cmd := exec.NewMockCmd()
+			cmd.On("StdoutPipe").Return(io.NopCloser(bytes.NewBufferString(test.stdout)), nil)
+			cmd.On("StderrPipe").Return(io.NopCloser(bytes.NewBufferString("")), nil)
+			cmd.On("Start").Return(nil)
+			cmd.On("Wait").Return(test.waitErr)
+
+			executable := exec.NewMockExec()
+			executable.On("CommandContext", context.TODO(), "ansible-config", test.args).Return(cmd)
+
+			e := NewAnsibleConfigDumpExecute().
+				WithConfigOptions(test.options).
+				WithExecutable(executable)
+
+			dump, err := e.ExecuteWithDump(context.TODO())
+			test.assertFunc(t, e, dump, err)
+		})
+	}
+}
+
+func TestAnsibleConfigDumpExecuteAddEnvVar(t *testing.T) {
+	t.Run("Testing add an environment variable to AnsibleConfigDumpExecute", func(t *testing.T) {
+		e := NewAnsibleConfigDumpExecute()
+		e.AddEnvVar("ANSIBLE_FORKS", "10")
+
+		assert.Equal(t, map[string]string{"ANSIBLE_FORKS": "10"}, e.envVars)
+	})
+}
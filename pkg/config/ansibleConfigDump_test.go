@@ -0,0 +1,120 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAnsibleConfigDump(t *testing.T) {
+	tests := []struct {
+		desc       string
+		data       string
+		res        *AnsibleConfigDump
+		err        bool
+		errMessage string
+	}{
+		{
+			desc: "Testing parse the ansible core settings",
+			data: `[
+				{"name": "DEFAULT_FORKS", "value": 10, "origin": "env: ANSIBLE_FORKS"},
+				{"name": "DEFAULT_TIMEOUT", "value": 10, "origin": "default"},
+				{"name": "HOST_KEY_CHECKING", "value": false, "origin": "/etc/ansible/ansible.cfg"},
+				{"name": "COLLECTIONS_PATHS", "value": ["/usr/share/collections"], "origin": "REQUIRED"}
+			]`,
+			res: &AnsibleConfigDump{
+				Settings: []*AnsibleConfigSetting{
+					{Name: "DEFAULT_FORKS", Value: float64(10), Origin: "env: ANSIBLE_FORKS", OriginKind: OriginEnv, OriginSource: "ANSIBLE_FORKS"},
+					{Name: "DEFAULT_TIMEOUT", Value: float64(10), Origin: "default", OriginKind: OriginDefault},
+					{Name: "HOST_KEY_CHECKING", Value: false, Origin: "/etc/ansible/ansible.cfg", OriginKind: OriginIni, OriginSource: "/etc/ansible/ansible.cfg"},
+					{Name: "COLLECTIONS_PATHS", Value: []interface{}{"/usr/share/collections"}, Origin: "REQUIRED", OriginKind: OriginRequired},
+				},
+			},
+		},
+		{
+			desc: "Testing parse the settings of all the plugin types",
+			data: `[
+				{"name": "DEFAULT_FORKS", "value": 5, "origin": "default"},
+				{"CONNECTION_PLUGINS": [
+					{"ssh": [{"name": "pipelining", "value": true, "origin": "C:\\ansible: config\\ansible.cfg"}]},
+					{"local": []}
+				]}
+			]`,
+			res: &AnsibleConfigDump{
+				Settings: []*AnsibleConfigSetting{
+					{Name: "DEFAULT_FORKS", Value: float64(5), Origin: "default", OriginKind: OriginDefault},
+					{Name: "pipelining", Value: true, Origin: "C:\\ansible: config\\ansible.cfg", OriginKind: OriginIni, OriginSource: "C:\\ansible: config\\ansible.cfg", PluginType: "connection", Plugin: "ssh"},
+				},
+			},
+		},
+		{
+			desc: "Testing parse the settings of a plugin type",
+			data: `[{"ssh": [{"name": "pipelining", "value": true, "origin": "var: ansible_pipelining"}]}]`,
+			res: &AnsibleConfigDump{
+				Settings: []*AnsibleConfigSetting{
+					{Name: "pipelining", Value: true, Origin: "var: ansible_pipelining", OriginKind: OriginVar, OriginSource: "ansible_pipelining", Plugin: "ssh"},
+				},
+			},
+		},
+		{
+			desc:       "Testing parse an invalid ansible-config dump output",
+			data:       `not a json`,
+			err:        true,
+			errMessage: "Error decoding the ansible-config dump output",
+		},
+		{
+			desc:       "Testing parse an ansible-config dump output with an invalid setting",
+			data:       `[{"ssh": [{"value": true}]}]`,
+			err:        true,
+			errMessage: "Error parsing the 'ssh' settings of the ansible-config dump output",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := ParseAnsibleConfigDump([]byte(test.data))
+			if test.err {
+				assert.ErrorContains(t, err, test.errMessage)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestAnsibleConfigDumpQueries(t *testing.T) {
+	dump, err := ParseAnsibleConfigDump([]byte(`[
+		{"name": "DEFAULT_FORKS", "value": 10, "origin": "env: ANSIBLE_FORKS"},
+		{"name": "DEFAULT_TIMEOUT", "value": 10, "origin": "default"},
+		{"name": "HOST_KEY_CHECKING", "value": false, "origin": "/etc/ansible/ansible.cfg"},
+		{"CONNECTION_PLUGINS": [{"ssh": [{"name": "pipelining", "value": true, "origin": "/etc/ansible/ansible.cfg"}]}]}
+	]`))
+	assert.NoError(t, err)
+
+	setting, exists := dump.Setting("DEFAULT_FORKS")
+	assert.True(t, exists)
+	assert.Equal(t, float64(10), setting.Value)
+
+	_, exists = dump.Setting("pipelining")
+	assert.False(t, exists)
+
+	setting, exists = dump.PluginSetting("ssh", "pipelining")
+	assert.True(t, exists)
+	assert.Equal(t, true, setting.Value)
+
+	changed := []string{}
+	for _, setting := range dump.Changed() {
+		changed = append(changed, setting.Name)
+	}
+	assert.Equal(t, []string{"DEFAULT_FORKS", "HOST_KEY_CHECKING", "pipelining"}, changed)
+
+	ini := []string{}
+	for _, setting := range dump.ByOriginKind(OriginIni) {
+		ini = append(ini, setting.Name)
+	}
+	assert.Equal(t, []string{"HOST_KEY_CHECKING", "pipelining"}, ini)
+}
@@ -0,0 +1,13 @@
+package config
+
+import (
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+)
+
+// AnsibleConfigErrorEnrich is the error enricher for ansible-config errors. It is an execute.ExitCodeErrorEnrich that converts the errors into the typed errors defined in the execute package, such as execute.GeneralError, when the exit code is known
+type AnsibleConfigErrorEnrich = execute.ExitCodeErrorEnrich
+
+// NewAnsibleConfigErrorEnrich creates a new AnsibleConfigErrorEnrich instance
+func NewAnsibleConfigErrorEnrich() *AnsibleConfigErrorEnrich {
+	return execute.NewExitCodeErrorEnrich(DefaultAnsibleConfigBinary)
+}
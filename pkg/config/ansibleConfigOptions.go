@@ -0,0 +1,228 @@
+package config
+
+import (
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+
+	// ConfigFlag path to configuration file, defaults to first file found in precedence
+	ConfigFlag = "--config"
+
+	// DisabledFlag prefixes all entries with a comment character to disable them, used by init
+	DisabledFlag = "--disabled"
+
+	// FormatFlag output format for list, dump or init. The supported values depend on the subcommand: json and yaml for list, json, yaml and display for dump, and ini, env and vars for init
+	FormatFlag = "--format"
+
+	// OnlyChangedFlag only show configurations that have changed from the default, used by dump
+	OnlyChangedFlag = "--only-changed"
+
+	// TypeFlag filter down to a specific plugin type, such as base, all, callback or connection
+	TypeFlag = "--type"
+
+	// VerboseFlag verbose mode enabled
+	VerboseFlag = "-vvvv"
+
+	// VerboseVFlag verbose with -v is enabled
+	VerboseVFlag = "-v"
+
+	// VerboseVVFlag verbose with -vv is enabled
+	VerboseVVFlag = "-vv"
+
+	// VerboseVVVFlag verbose with -vvv is enabled
+	VerboseVVVFlag = "-vvv"
+
+	// VerboseVVVVFlag verbose with -vvvv is enabled
+	VerboseVVVVFlag = "-vvvv"
+
+	// VersionFlag show program's version number, config file location, configured module search path, module location, executable location and exit
+	VersionFlag = "--version"
+)
+
+const (
+	// FormatJSON is the JSON output format, supported by list and dump
+	FormatJSON = "json"
+
+	// FormatYAML is the YAML output format, supported by list and dump
+	FormatYAML = "yaml"
+
+	// FormatDisplay is the human readable output format, supported by dump
+	FormatDisplay = "display"
+
+	// FormatINI is the ansible.cfg output format, supported by init
+	FormatINI = "ini"
+
+	// FormatEnv is the environment variables output format, supported by init
+	FormatEnv = "env"
+
+	// FormatVars is the variables output format, supported by init
+	FormatVars = "vars"
+
+	// TypeBase is the type of the ansible core settings
+	TypeBase = "base"
+
+	// TypeAll is the type that includes the ansible core settings and the settings of all the plugin types
+	TypeAll = "all"
+)
+
+// AnsibleConfigOptions represents the options that can be passed to the ansible-config command.
+type AnsibleConfigOptions struct {
+
+	// Config path to configuration file, defaults to first file found in precedence
+	Config string
+
+	// Disabled prefixes all entries with a comment character to disable them, used by init
+	Disabled bool
+
+	// Format output format for list, dump or init
+	Format string
+
+	// OnlyChanged only show configurations that have changed from the default, used by dump
+	OnlyChanged bool
+
+	// Type filter down to a specific plugin type
+	Type string
+
+	// Verbose verbose mode enabled
+	Verbose bool
+
+	// Verbose verbose mode -v enabled
+	VerboseV bool
+
+	// Verbose verbose mode -vv enabled
+	VerboseVV bool
+
+	// Verbose verbose mode -vvv enabled
+	VerboseVVV bool
+
+	// Verbose verbose mode -vvvv enabled
+	VerboseVVVV bool
+
+	// Version show program's version number, config file location, configured module search path, module location, executable location and exit
+	Version bool
+}
+
+// GenerateCommandOptions generates the command line options for the ansible-config command.
+func (o *AnsibleConfigOptions) GenerateCommandOptions() ([]string, error) {
+
+	errContext := "(config::AnsibleConfigOptions::GenerateCommandOptions)"
+	options := []string{}
+
+	if o == nil {
+		return nil, errors.New(errContext, "AnsibleConfigOptions is nil")
+	}
+
+	if o.Config != "" {
+		options = append(options, ConfigFlag, o.Config)
+	}
+
+	if o.Disabled {
+		options = append(options, DisabledFlag)
+	}
+
+	if o.Format != "" {
+		options = append(options, FormatFlag, o.Format)
+	}
+
+	if o.OnlyChanged {
+		options = append(options, OnlyChangedFlag)
+	}
+
+	if o.Type != "" {
+		options = append(options, TypeFlag, o.Type)
+	}
+
+	verboseFlag, err := o.generateVerbosityFlag()
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	if verboseFlag != "" {
+		options = append(options, verboseFlag)
+	}
+
+	if o.Version {
+		options = append(options, VersionFlag)
+	}
+
+	return options, nil
+}
+
+// generateVerbosityFlag return a string with the verbose flag. Higher verbosity (more v's) has precedence over lower
+func (o *AnsibleConfigOptions) generateVerbosityFlag() (string, error) {
+	if o.Verbose {
+		return VerboseFlag, nil
+	}
+
+	if o.VerboseVVVV {
+		return VerboseVVVVFlag, nil
+	}
+
+	if o.VerboseVVV {
+		return VerboseVVVFlag, nil
+	}
+
+	if o.VerboseVV {
+		return VerboseVVFlag, nil
+	}
+
+	if o.VerboseV {
+		return VerboseVFlag, nil
+	}
+
+	return "", nil
+}
+
+// String returns a string representation of the ansible-config options.
+func (o *AnsibleConfigOptions) String() string {
+	str := ""
+
+	if o.Config != "" {
+		str = fmt.Sprintf("%s %s %s", str, ConfigFlag, o.Config)
+	}
+
+	if o.Disabled {
+		str = fmt.Sprintf("%s %s", str, DisabledFlag)
+	}
+
+	if o.Format != "" {
+		str = fmt.Sprintf("%s %s %s", str, FormatFlag, o.Format)
+	}
+
+	if o.OnlyChanged {
+		str = fmt.Sprintf("%s %s", str, OnlyChangedFlag)
+	}
+
+	if o.Type != "" {
+		str = fmt.Sprintf("%s %s %s", str, TypeFlag, o.Type)
+	}
+
+	if o.Verbose {
+		str = fmt.Sprintf("%s %s", str, VerboseFlag)
+	}
+
+	if o.VerboseV {
+		str = fmt.Sprintf("%s %s", str, VerboseVFlag)
+	}
+
+	if o.VerboseVV {
+		str = fmt.Sprintf("%s %s", str, VerboseVVFlag)
+	}
+
+	if o.VerboseVVV {
+		str = fmt.Sprintf("%s %s", str, VerboseVVVFlag)
+	}
+
+	if o.VerboseVVVV {
+		str = fmt.Sprintf("%s %s", str, VerboseVVVVFlag)
+	}
+
+	if o.Version {
+		str = fmt.Sprintf("%s %s", str, VersionFlag)
+	}
+
+	return str
+}
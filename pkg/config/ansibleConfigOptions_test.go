@@ -0,0 +1,87 @@
+package config
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleConfigOptionsGenerateCommandOptions(t *testing.T) {
+
+	errContext := "(config::AnsibleConfigOptions::GenerateCommandOptions)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleConfigOptions
+		err     error
+		expect  []string
+	}{
+		{
+			desc:    "Testing nil AnsibleConfigOptions definition",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleConfigOptions is nil"),
+			expect:  []string{},
+		},
+		{
+			desc:    "Testing an empty AnsibleConfigOptions definition",
+			options: &AnsibleConfigOptions{},
+			err:     nil,
+			expect:  []string{},
+		},
+		{
+			desc: "Testing AnsibleConfigOptions with all flags",
+			options: &AnsibleConfigOptions{
+				Config:      "ansible.cfg",
+				Disabled:    true,
+				Format:      FormatJSON,
+				OnlyChanged: true,
+				Type:        TypeAll,
+				Verbose:     true,
+				VerboseV:    true,
+				VerboseVV:   true,
+				VerboseVVV:  true,
+				VerboseVVVV: true,
+				Version:     true,
+			},
+			err: nil,
+			expect: []string{
+				ConfigFlag, "ansible.cfg",
+				DisabledFlag,
+				FormatFlag, FormatJSON,
+				OnlyChangedFlag,
+				TypeFlag, TypeAll,
+				VerboseVVVVFlag,
+				VersionFlag,
+			},
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options, err := test.options.GenerateCommandOptions()
+			if err != nil && assert.Error(t, err) {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expect, options, "Unexpected options value")
+			}
+		})
+	}
+}
+
+func TestAnsibleConfigOptionsString(t *testing.T) {
+	t.Run("Testing AnsibleConfigOptions string", func(t *testing.T) {
+		options := &AnsibleConfigOptions{
+			Config:      "ansible.cfg",
+			Format:      FormatJSON,
+			OnlyChanged: true,
+			Type:        TypeBase,
+			VerboseV:    true,
+		}
+
+		assert.Equal(t, " --config ansible.cfg --format json --only-changed --type base -v", options.String())
+	})
+}
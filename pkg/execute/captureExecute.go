@@ -0,0 +1,30 @@
+package execute
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/result/capture"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// CaptureExecute runs the command using a DefaultExecute set by the options, capturing its stdout by a CaptureResults, and calls the parse function with the captured stdout once the command succeeds. The writer and the output mechanism set by the options are replaced to capture the stdout
+func CaptureExecute(ctx context.Context, parse func(stdout []byte) error, options ...ExecuteOptions) error {
+	errContext := "(execute::CaptureExecute)"
+
+	if parse == nil {
+		return errors.New(errContext, "A parse function is required to parse the output of the execution")
+	}
+
+	results := capture.NewCaptureResults()
+
+	exec := NewDefaultExecute(options...)
+	exec.Write = results.Writer()
+	exec.WithOutput(results)
+
+	err := exec.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	return parse(results.Stdout())
+}
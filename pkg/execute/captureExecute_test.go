@@ -0,0 +1,81 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/mocks"
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureExecute(t *testing.T) {
+	tests := []struct {
+		desc    string
+		stdout  string
+		waitErr error
+		parse   func(stdout []byte) error
+		err     string
+	}{
+		{
+			desc:   "Testing execute a command parsing the stdout",
+			stdout: "output",
+			parse: func(stdout []byte) error {
+				if string(stdout) != "output" {
+					return fmt.Errorf("unexpected stdout '%s'", stdout)
+				}
+				return nil
+			},
+		},
+		{
+			desc:   "Testing execute a command returning the error of the parse function",
+			stdout: "output",
+			parse: func(stdout []byte) error {
+				return fmt.Errorf("parse error")
+			},
+			err: "parse error",
+		},
+		{
+			desc:    "Testing execute a command returning an error when the command fails",
+			waitErr: fmt.Errorf("command failed"),
+			parse: func(stdout []byte) error {
+				return fmt.Errorf("the stdout of a failed command must not be parsed")
+			},
+			err: "command failed",
+		},
+		{
+			desc: "Testing execute a command returning an error when the parse function is not defined",
+			err:  "A parse function is required to parse the output of the execution",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			cmd := exec.NewMockCmd()
+			cmd.On("StdoutPipe").Return(io.NopCloser(bytes.NewBufferString(test.stdout)), nil)
+			cmd.On("StderrPipe").Return(io.NopCloser(bytes.NewBufferString("")), nil)
+			cmd.On("Start").Return(nil)
+			cmd.On("Wait").Return(test.waitErr)
+
+			executable := exec.NewMockExec()
+			executable.On("CommandContext", context.TODO(), "ansible-inventory", []string{"--list"}).Return(cmd)
+
+			err := CaptureExecute(context.TODO(), test.parse,
+				WithCmd(mocks.NewMockAnsibleCmd([]string{"ansible-inventory", "--list"}, nil)),
+				WithExecutable(executable),
+				WithWrite(io.Discard),
+			)
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
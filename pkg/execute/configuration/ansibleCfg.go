@@ -0,0 +1,302 @@
+package configuration
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	// AnsibleCfgDefaultsSection is the ansible.cfg section that holds the ansible general settings
+	AnsibleCfgDefaultsSection = "defaults"
+
+	// ansibleCfgInlineComment is the prefix of the inline comments, which ansible only accepts after a whitespace
+	ansibleCfgInlineComment = ";"
+)
+
+// UnsupportedAnsibleCfgEntriesError is the error returned when an ansible.cfg file contains entries that do not correspond to any configuration setting, such as the plugins settings. The entries are named as `section.key`
+type UnsupportedAnsibleCfgEntriesError struct {
+	Entries []string
+}
+
+// Error returns the error message
+func (e *UnsupportedAnsibleCfgEntriesError) Error() string {
+	return fmt.Sprintf("ansible.cfg entries not supported by the configuration settings: %s", strings.Join(e.Entries, ", "))
+}
+
+// AnsibleCfg returns the configuration settings rendered as an ansible.cfg file. The sections are sorted alphabetically, except the defaults section that comes first, and so are the keys within each section. The settings that can only be defined by environment variable are written as comments at the beginning of the file
+func (e *AnsibleWithConfigurationSettingsExecute) AnsibleCfg() ([]byte, error) {
+	var buff bytes.Buffer
+
+	sections := map[string]map[string]string{}
+	envOnly := []string{}
+
+	for envVar, value := range e.configurationSettings {
+		metadata, exists := LookupConfigurationSetting(envVar)
+		if !exists {
+			return nil, fmt.Errorf("configuration setting '%s' can not be written to an ansible.cfg file because its section and key are unknown", envVar)
+		}
+
+		if len(metadata.Ini) == 0 {
+			envOnly = append(envOnly, fmt.Sprintf("# %s=%s", envVar, strings.ReplaceAll(value, "\n", "\\n")))
+			continue
+		}
+
+		rendered, err := renderAnsibleCfgValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("configuration setting '%s' can not be written to an ansible.cfg file: %w", envVar, err)
+		}
+
+		ini := metadata.Ini[0]
+		if _, exists := sections[ini.Section]; !exists {
+			sections[ini.Section] = map[string]string{}
+		}
+		sections[ini.Section][ini.Key] = rendered
+	}
+
+	if len(envOnly) > 0 {
+		sort.Strings(envOnly)
+		fmt.Fprintln(&buff, "# The following settings can only be defined by environment variable")
+		for _, line := range envOnly {
+			fmt.Fprintln(&buff, line)
+		}
+	}
+
+	for _, section := range sortedAnsibleCfgSections(sections) {
+		if buff.Len() > 0 {
+			fmt.Fprintln(&buff)
+		}
+		fmt.Fprintf(&buff, "[%s]\n", section)
+
+		keys := make([]string, 0, len(sections[section]))
+		for key := range sections[section] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			fmt.Fprintf(&buff, "%s = %s\n", key, sections[section][key])
+		}
+	}
+
+	return buff.Bytes(), nil
+}
+
+// WriteAnsibleCfg writes the configuration settings to the ansible.cfg file on path
+func (e *AnsibleWithConfigurationSettingsExecute) WriteAnsibleCfg(path string) error {
+	content, err := e.AnsibleCfg()
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(path, content, 0600)
+	if err != nil {
+		return fmt.Errorf("error writing the ansible.cfg file '%s': %w", path, err)
+	}
+
+	return nil
+}
+
+// RenderAnsibleCfg returns the configuration settings set by the options rendered as an ansible.cfg file
+func RenderAnsibleCfg(options ...ConfigurationSettingsFunc) ([]byte, error) {
+	return NewAnsibleWithConfigurationSettingsExecute(nil, options...).AnsibleCfg()
+}
+
+// LoadAnsibleCfg parses an ansible.cfg file and returns the options that set the configuration settings it defines. When a setting is defined by several entries, the one with the highest precedence for ansible is used. When the file contains entries that do not correspond to any configuration setting, it returns the options for the rest of entries along with an UnsupportedAnsibleCfgEntriesError
+func LoadAnsibleCfg(reader io.Reader) ([]ConfigurationSettingsFunc, error) {
+	entries, err := parseAnsibleCfg(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the ansible.cfg file: %w", err)
+	}
+
+	values := map[string]string{}
+	precedences := map[string]int{}
+	unsupported := []string{}
+
+	for _, entry := range entries {
+		metadata, exists := LookupConfigurationSettingByIni(entry.section, entry.key)
+		if !exists {
+			unsupported = append(unsupported, fmt.Sprintf("%s.%s", entry.section, entry.key))
+			continue
+		}
+
+		precedence := metadata.iniPrecedence(entry.section, entry.key)
+		if current, exists := precedences[metadata.EnvVar]; exists && current > precedence {
+			continue
+		}
+
+		precedences[metadata.EnvVar] = precedence
+		values[metadata.EnvVar] = entry.value
+	}
+
+	envVars := make([]string, 0, len(values))
+	for envVar := range values {
+		envVars = append(envVars, envVar)
+	}
+	sort.Strings(envVars)
+
+	options := make([]ConfigurationSettingsFunc, 0, len(envVars))
+	for _, envVar := range envVars {
+		options = append(options, withConfigurationSetting(envVar, values[envVar]))
+	}
+
+	if len(unsupported) > 0 {
+		return options, &UnsupportedAnsibleCfgEntriesError{Entries: unsupported}
+	}
+
+	return options, nil
+}
+
+// LoadAnsibleCfgFile parses the ansible.cfg file on path and returns the options that set the configuration settings it defines, as LoadAnsibleCfg does
+func LoadAnsibleCfgFile(path string) ([]ConfigurationSettingsFunc, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening the ansible.cfg file '%s': %w", path, err)
+	}
+	defer file.Close()
+
+	return LoadAnsibleCfg(file)
+}
+
+// withConfigurationSetting sets the value of the configuration setting defined by the environment variable
+func withConfigurationSetting(envVar, value string) ConfigurationSettingsFunc {
+	return func(e *AnsibleWithConfigurationSettingsExecute) {
+		e.configurationSettings[envVar] = value
+	}
+}
+
+// ansibleCfgEntry is an entry of an ansible.cfg file
+type ansibleCfgEntry struct {
+	section string
+	key     string
+	value   string
+}
+
+// parseAnsibleCfg parses the entries of an ansible.cfg file, following the rules of the python configparser used by ansible: `key = value` and `key: value` entries, case insensitive keys, comments starting by `#` or `;`, inline comments starting by ` ;`, indented continuation lines and `%%` escaping
+func parseAnsibleCfg(reader io.Reader) ([]*ansibleCfgEntry, error) {
+	var section string
+	var current *ansibleCfgEntry
+
+	entries := []*ansibleCfgEntry{}
+	defined := map[string]struct{}{}
+
+	scanner := bufio.NewScanner(reader)
+	number := 0
+	for scanner.Scan() {
+		number++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" {
+			current = nil
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") {
+			continue
+		}
+
+		value := unescapeAnsibleCfgValue(stripAnsibleCfgInlineComment(trimmed))
+
+		// indented lines continue the value of the previous entry
+		if current != nil && line != trimmed {
+			current.value = fmt.Sprintf("%s\n%s", current.value, value)
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			section = strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+			current = nil
+			continue
+		}
+
+		separator := strings.IndexAny(trimmed, "=:")
+		if separator <= 0 {
+			return nil, fmt.Errorf("invalid line %d: '%s'", number, line)
+		}
+
+		if section == "" {
+			return nil, fmt.Errorf("line %d is not within any section: '%s'", number, line)
+		}
+
+		key := strings.ToLower(strings.TrimSpace(trimmed[:separator]))
+		id := fmt.Sprintf("%s.%s", section, key)
+		if _, exists := defined[id]; exists {
+			return nil, fmt.Errorf("key '%s' is defined more than once in section '%s'", key, section)
+		}
+		defined[id] = struct{}{}
+
+		current = &ansibleCfgEntry{
+			section: section,
+			key:     key,
+			value:   unescapeAnsibleCfgValue(strings.TrimSpace(stripAnsibleCfgInlineComment(trimmed[separator+1:]))),
+		}
+		entries = append(entries, current)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// renderAnsibleCfgValue returns the value escaped to be written to an ansible.cfg file. The multiline values are written using indented continuation lines
+func renderAnsibleCfgValue(value string) (string, error) {
+	lines := strings.Split(value, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != line {
+			return "", fmt.Errorf("leading or trailing whitespaces are not preserved by ansible.cfg files")
+		}
+
+		if stripAnsibleCfgInlineComment(line) != line {
+			return "", fmt.Errorf("' %s' would start an inline comment", ansibleCfgInlineComment)
+		}
+
+		if line == "" && len(lines) > 1 {
+			return "", fmt.Errorf("empty lines are not preserved by ansible.cfg files")
+		}
+
+		lines[i] = strings.ReplaceAll(line, "%", "%%")
+	}
+
+	return strings.Join(lines, "\n\t"), nil
+}
+
+// stripAnsibleCfgInlineComment removes the inline comment from the value
+func stripAnsibleCfgInlineComment(value string) string {
+	for i := 1; i < len(value); i++ {
+		if strings.HasPrefix(value[i:], ansibleCfgInlineComment) && (value[i-1] == ' ' || value[i-1] == '\t') {
+			return strings.TrimRight(value[:i], " \t")
+		}
+	}
+
+	return value
+}
+
+// unescapeAnsibleCfgValue replaces the escaped percent signs
+func unescapeAnsibleCfgValue(value string) string {
+	return strings.ReplaceAll(value, "%%", "%")
+}
+
+// sortedAnsibleCfgSections returns the sections sorted alphabetically, except the defaults section that comes first
+func sortedAnsibleCfgSections(sections map[string]map[string]string) []string {
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if names[i] == AnsibleCfgDefaultsSection || names[j] == AnsibleCfgDefaultsSection {
+			return names[i] == AnsibleCfgDefaultsSection && names[j] != AnsibleCfgDefaultsSection
+		}
+		return names[i] < names[j]
+	})
+
+	return names
+}
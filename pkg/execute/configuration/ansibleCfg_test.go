@@ -0,0 +1,224 @@
+package configuration
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleCfg(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options []ConfigurationSettingsFunc
+		res     string
+		err     error
+	}{
+		{
+			desc: "Testing render the configuration settings as an ansible.cfg file",
+			options: []ConfigurationSettingsFunc{
+				WithAnsiblePipelining(),
+				WithAnsibleForks(10),
+				WithAnsibleRemoteUser("deploy"),
+				WithAnsibleBecome(),
+				WithAnsibleColorOk("green"),
+				WithAnsibleHostKeyChecking(),
+			},
+			res: `[defaults]
+forks = 10
+host_key_checking = true
+remote_user = deploy
+
+[colors]
+ok = green
+
+[connection]
+pipelining = true
+
+[privilege_escalation]
+become = true
+`,
+		},
+		{
+			desc: "Testing render the settings that can only be defined by environment variable as comments",
+			options: []ConfigurationSettingsFunc{
+				WithEditor("vim"),
+				WithAnsibleForks(5),
+			},
+			res: `# The following settings can only be defined by environment variable
+# EDITOR=vim
+
+[defaults]
+forks = 5
+`,
+		},
+		{
+			desc: "Testing render escaped and multiline values",
+			options: []ConfigurationSettingsFunc{
+				WithAnsibleCowSelection("100%"),
+				WithAnsibleLogFilter("first\nsecond"),
+			},
+			res: `[defaults]
+cow_selection = 100%%
+log_filter = first
+	second
+`,
+		},
+		{
+			desc:    "Testing render an empty configuration",
+			options: []ConfigurationSettingsFunc{},
+			res:     "",
+		},
+		{
+			desc: "Testing error rendering a value that would start an inline comment",
+			options: []ConfigurationSettingsFunc{
+				WithAnsibleRemoteUser("deploy ;comment"),
+			},
+			err: errors.New("configuration setting 'ANSIBLE_REMOTE_USER' can not be written to an ansible.cfg file: ' ;' would start an inline comment"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := RenderAnsibleCfg(test.options...)
+			if test.err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, string(res))
+		})
+	}
+}
+
+func TestLoadAnsibleCfg(t *testing.T) {
+	tests := []struct {
+		desc        string
+		content     string
+		res         map[string]string
+		unsupported []string
+		err         error
+	}{
+		{
+			desc: "Testing load the configuration settings from an ansible.cfg file",
+			content: `# ansible configuration
+[defaults]
+forks = 10
+Remote_User: deploy ; inline comment
+cow_selection = 100%%
+log_filter = first
+    second
+
+[privilege_escalation]
+become=True
+`,
+			res: map[string]string{
+				AnsibleForks:        "10",
+				AnsibleRemoteUser:   "deploy",
+				AnsibleCowSelection: "100%",
+				AnsibleLogFilter:    "first\nsecond",
+				AnsibleBecome:       "True",
+			},
+		},
+		{
+			desc: "Testing load a setting defined by several entries using the one with the highest precedence",
+			content: `[ssh_connection]
+pipelining = false
+
+[connection]
+pipelining = true
+`,
+			res: map[string]string{
+				AnsiblePipelining: "false",
+			},
+		},
+		{
+			desc: "Testing load an ansible.cfg file with entries that do not correspond to any setting",
+			content: `[defaults]
+forks = 10
+
+[ssh_connection]
+ssh_args = -o ControlMaster=auto
+`,
+			res: map[string]string{
+				AnsibleForks: "10",
+			},
+			unsupported: []string{"ssh_connection.ssh_args"},
+		},
+		{
+			desc:    "Testing error loading an entry out of any section",
+			content: "forks = 10\n",
+			err:     errors.New("error parsing the ansible.cfg file: line 1 is not within any section: 'forks = 10'"),
+		},
+		{
+			desc:    "Testing error loading a key defined twice in the same section",
+			content: "[defaults]\nforks = 10\nFORKS = 5\n",
+			err:     errors.New("error parsing the ansible.cfg file: key 'forks' is defined more than once in section 'defaults'"),
+		},
+		{
+			desc:    "Testing error loading an invalid line",
+			content: "[defaults]\nforks\n",
+			err:     errors.New("error parsing the ansible.cfg file: invalid line 2: 'forks'"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options, err := LoadAnsibleCfg(strings.NewReader(test.content))
+			if test.err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+				return
+			}
+
+			if test.unsupported != nil {
+				var unsupportedErr *UnsupportedAnsibleCfgEntriesError
+				assert.True(t, errors.As(err, &unsupportedErr))
+				assert.Equal(t, test.unsupported, unsupportedErr.Entries)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			exec := NewAnsibleWithConfigurationSettingsExecute(nil, options...)
+			assert.Equal(t, test.res, exec.configurationSettings)
+		})
+	}
+}
+
+func TestAnsibleCfgRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ansible.cfg")
+
+	exec := NewAnsibleWithConfigurationSettingsExecute(nil,
+		WithAnsibleForks(20),
+		WithAnsiblePipelining(),
+		WithAnsibleInventory("inventory/hosts.yml,inventory/extra.yml"),
+		WithAnsibleLogFilter("first\nsecond"),
+		WithoutAnsibleHostKeyChecking(),
+		WithAnsibleRunTags("deploy"),
+	)
+
+	err := exec.WriteAnsibleCfg(path)
+	assert.NoError(t, err)
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	options, err := LoadAnsibleCfgFile(path)
+	assert.NoError(t, err)
+
+	loaded := NewAnsibleWithConfigurationSettingsExecute(nil, options...)
+	assert.Equal(t, exec.configurationSettings, loaded.configurationSettings)
+}
+
+func TestLoadAnsibleCfgFileNotFound(t *testing.T) {
+	_, err := LoadAnsibleCfgFile(filepath.Join(t.TempDir(), "ansible.cfg"))
+	assert.ErrorContains(t, err, "error opening the ansible.cfg file")
+}
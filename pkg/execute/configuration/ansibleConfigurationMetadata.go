@@ -0,0 +1,195 @@
+package configuration
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ConfigurationSettingType is the type of the value of a configuration setting
+type ConfigurationSettingType string
+
+const (
+	// ConfigurationSettingTypeBool is the type of the boolean settings, such as true, false, yes or no
+	ConfigurationSettingTypeBool ConfigurationSettingType = "bool"
+
+	// ConfigurationSettingTypeInt is the type of the integer settings
+	ConfigurationSettingTypeInt ConfigurationSettingType = "int"
+
+	// ConfigurationSettingTypeFloat is the type of the floating point number settings
+	ConfigurationSettingTypeFloat ConfigurationSettingType = "float"
+
+	// ConfigurationSettingTypeList is the type of the comma separated list settings
+	ConfigurationSettingTypeList ConfigurationSettingType = "list"
+
+	// ConfigurationSettingTypePath is the type of the path settings
+	ConfigurationSettingTypePath ConfigurationSettingType = "path"
+
+	// ConfigurationSettingTypePathSpec is the type of the colon separated list of paths settings
+	ConfigurationSettingTypePathSpec ConfigurationSettingType = "pathspec"
+
+	// ConfigurationSettingTypePathList is the type of the comma separated list of paths settings
+	ConfigurationSettingTypePathList ConfigurationSettingType = "pathlist"
+
+	// ConfigurationSettingTypeString is the type of the string settings
+	ConfigurationSettingTypeString ConfigurationSettingType = "string"
+)
+
+var (
+	// booleanTrueValues are the values that ansible accepts as a true boolean
+	booleanTrueValues = []string{"y", "yes", "on", "1", "true", "t"}
+
+	// booleanFalseValues are the values that ansible accepts as a false boolean
+	booleanFalseValues = []string{"n", "no", "off", "0", "false", "f"}
+)
+
+// ConfigurationSettingIni is an ansible.cfg entry that defines a configuration setting
+type ConfigurationSettingIni struct {
+	// Section is the ansible.cfg section, such as defaults
+	Section string
+	// Key is the key within the section, such as forks
+	Key string
+}
+
+// ConfigurationSettingMetadata describes an ansible configuration setting
+type ConfigurationSettingMetadata struct {
+	// Name is the ansible configuration setting name, such as DEFAULT_FORKS
+	Name string
+	// EnvVar is the environment variable that defines the setting, such as ANSIBLE_FORKS
+	EnvVar string
+	// Type is the type of the setting value
+	Type ConfigurationSettingType
+	// Choices are the values allowed for the setting. When the setting is a list, they are the values allowed for each item. It is empty when any value of the type is allowed
+	Choices []string
+	// Ini are the ansible.cfg entries that define the setting, sorted by ascending precedence. The first one is used to write the setting to an ansible.cfg file. It is empty when the setting can only be defined by environment variable
+	Ini []ConfigurationSettingIni
+}
+
+// LookupConfigurationSetting returns the metadata of the configuration setting defined by the environment variable
+func LookupConfigurationSetting(envVar string) (*ConfigurationSettingMetadata, bool) {
+	metadata, exists := configurationSettingsMetadata[envVar]
+	return metadata, exists
+}
+
+// LookupConfigurationSettingByIni returns the metadata of the configuration setting defined by the ansible.cfg section and key. The key is case insensitive, as it is for ansible
+func LookupConfigurationSettingByIni(section, key string) (*ConfigurationSettingMetadata, bool) {
+	key = strings.ToLower(key)
+
+	for _, metadata := range configurationSettingsMetadata {
+		for _, ini := range metadata.Ini {
+			if ini.Section == section && ini.Key == key {
+				return metadata, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// ConfigurationSettingsMetadata returns the metadata of all the configuration settings sorted by environment variable
+func ConfigurationSettingsMetadata() []*ConfigurationSettingMetadata {
+	settings := make([]*ConfigurationSettingMetadata, 0, len(configurationSettingsMetadata))
+	for _, metadata := range configurationSettingsMetadata {
+		settings = append(settings, metadata)
+	}
+
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].EnvVar < settings[j].EnvVar
+	})
+
+	return settings
+}
+
+// Validate returns an error when the value is not valid for the setting type or it is not one of the allowed choices
+func (m *ConfigurationSettingMetadata) Validate(value string) error {
+	trimmed := strings.TrimSpace(value)
+	items := []string{trimmed}
+
+	switch m.Type {
+	case ConfigurationSettingTypeBool:
+		lower := strings.ToLower(trimmed)
+		if !containsString(booleanTrueValues, lower) && !containsString(booleanFalseValues, lower) {
+			return fmt.Errorf("'%s' is not a boolean, valid values are: %s", value, strings.Join(append(append([]string{}, booleanTrueValues...), booleanFalseValues...), ", "))
+		}
+	case ConfigurationSettingTypeInt:
+		number, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil || math.IsInf(number, 0) || number != math.Trunc(number) {
+			return fmt.Errorf("'%s' is not an integer", value)
+		}
+	case ConfigurationSettingTypeFloat:
+		_, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil {
+			return fmt.Errorf("'%s' is not a float", value)
+		}
+	case ConfigurationSettingTypeList:
+		items = splitConfigurationSettingList(trimmed)
+	case ConfigurationSettingTypePath, ConfigurationSettingTypePathSpec, ConfigurationSettingTypePathList:
+		if strings.ContainsRune(value, 0) {
+			return fmt.Errorf("path contains a null character")
+		}
+	}
+
+	if len(m.Choices) == 0 {
+		return nil
+	}
+
+	for _, item := range items {
+		if !containsString(m.Choices, item) {
+			return fmt.Errorf("'%s' is not a valid choice, valid values are: %s", item, strings.Join(m.Choices, ", "))
+		}
+	}
+
+	return nil
+}
+
+// iniPrecedence returns the precedence of the ansible.cfg entry among the entries that define the setting. It returns -1 when the entry does not define the setting
+func (m *ConfigurationSettingMetadata) iniPrecedence(section, key string) int {
+	for i, ini := range m.Ini {
+		if ini.Section == section && ini.Key == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// ValidateConfigurationSetting returns an error when the value is not valid for the configuration setting defined by the environment variable. The settings without metadata are not validated
+func ValidateConfigurationSetting(envVar, value string) error {
+	metadata, exists := LookupConfigurationSetting(envVar)
+	if !exists {
+		return nil
+	}
+
+	err := metadata.Validate(value)
+	if err != nil {
+		return fmt.Errorf("invalid value for configuration setting '%s': %w", envVar, err)
+	}
+
+	return nil
+}
+
+// splitConfigurationSettingList returns the trimmed items of a list value, ignoring the empty ones
+func splitConfigurationSettingList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// containsString returns whether the value is one of the values
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,265 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigurationSettingsMetadata(t *testing.T) {
+	ini := map[string]string{}
+
+	for envVar, metadata := range configurationSettingsMetadata {
+		assert.Equal(t, envVar, metadata.EnvVar, "metadata of '%s' is indexed by another environment variable", metadata.EnvVar)
+		assert.NotEmpty(t, metadata.Name, "metadata of '%s' has no name", envVar)
+		assert.NotEmpty(t, metadata.Type, "metadata of '%s' has no type", envVar)
+
+		for _, entry := range metadata.Ini {
+			id := fmt.Sprintf("%s.%s", entry.Section, entry.Key)
+			owner, exists := ini[id]
+			assert.False(t, exists, "ansible.cfg entry '%s' is defined by '%s' and '%s'", id, owner, envVar)
+			ini[id] = envVar
+		}
+	}
+}
+
+// TestConfigurationSettingsMetadataCoverage checks that every configuration setting constant has metadata, so the settings can be validated and written to an ansible.cfg file
+func TestConfigurationSettingsMetadataCoverage(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "ansibleConfiguration.go", nil, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, decl := range file.Decls {
+		genDecl, isGenDecl := decl.(*ast.GenDecl)
+		if !isGenDecl || genDecl.Tok != token.CONST {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, value := range valueSpec.Values {
+				literal, isLiteral := value.(*ast.BasicLit)
+				if !isLiteral || literal.Kind != token.STRING {
+					continue
+				}
+
+				envVar, err := strconv.Unquote(literal.Value)
+				assert.NoError(t, err)
+
+				_, exists := configurationSettingsMetadata[envVar]
+				assert.True(t, exists, "constant '%s' has no metadata", valueSpec.Names[i].Name)
+			}
+		}
+	}
+}
+
+func TestLookupConfigurationSetting(t *testing.T) {
+	tests := []struct {
+		desc   string
+		envVar string
+		res    *ConfigurationSettingMetadata
+		exists bool
+	}{
+		{
+			desc:   "Testing lookup a configuration setting",
+			envVar: AnsibleForks,
+			res: &ConfigurationSettingMetadata{
+				Name:   "DEFAULT_FORKS",
+				EnvVar: AnsibleForks,
+				Type:   ConfigurationSettingTypeInt,
+				Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "forks"}},
+			},
+			exists: true,
+		},
+		{
+			desc:   "Testing lookup a configuration setting that can only be defined by environment variable",
+			envVar: Editor,
+			res: &ConfigurationSettingMetadata{
+				Name:   "EDITOR",
+				EnvVar: Editor,
+				Type:   ConfigurationSettingTypeString,
+			},
+			exists: true,
+		},
+		{
+			desc:   "Testing lookup an unknown configuration setting",
+			envVar: "ANSIBLE_UNKNOWN",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, exists := LookupConfigurationSetting(test.envVar)
+			assert.Equal(t, test.exists, exists)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestLookupConfigurationSettingByIni(t *testing.T) {
+	tests := []struct {
+		desc    string
+		section string
+		key     string
+		res     string
+		exists  bool
+	}{
+		{
+			desc:    "Testing lookup a configuration setting by its ansible.cfg entry",
+			section: "defaults",
+			key:     "forks",
+			res:     AnsibleForks,
+			exists:  true,
+		},
+		{
+			desc:    "Testing lookup a configuration setting by an alternative ansible.cfg entry",
+			section: "defaults",
+			key:     "Pipelining",
+			res:     AnsiblePipelining,
+			exists:  true,
+		},
+		{
+			desc:    "Testing lookup a configuration setting by a connection plugin ansible.cfg entry",
+			section: "ssh_connection",
+			key:     "pipelining",
+			res:     AnsiblePipelining,
+			exists:  true,
+		},
+		{
+			desc:    "Testing lookup an unknown ansible.cfg entry",
+			section: "defaults",
+			key:     "unknown",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, exists := LookupConfigurationSettingByIni(test.section, test.key)
+			assert.Equal(t, test.exists, exists)
+			if test.exists {
+				assert.Equal(t, test.res, res.EnvVar)
+			}
+		})
+	}
+}
+
+func TestConfigurationSettingsMetadataSorted(t *testing.T) {
+	settings := ConfigurationSettingsMetadata()
+
+	assert.Len(t, settings, len(configurationSettingsMetadata))
+	for i := 1; i < len(settings); i++ {
+		assert.Less(t, settings[i-1].EnvVar, settings[i].EnvVar)
+	}
+}
+
+func TestValidateConfigurationSetting(t *testing.T) {
+	tests := []struct {
+		desc   string
+		envVar string
+		value  string
+		err    error
+	}{
+		{
+			desc:   "Testing validate a boolean setting",
+			envVar: AnsiblePipelining,
+			value:  "Yes",
+		},
+		{
+			desc:   "Testing validate an invalid boolean setting",
+			envVar: AnsiblePipelining,
+			value:  "maybe",
+			err:    errors.New("invalid value for configuration setting 'ANSIBLE_PIPELINING': 'maybe' is not a boolean, valid values are: y, yes, on, 1, true, t, n, no, off, 0, false, f"),
+		},
+		{
+			desc:   "Testing validate an integer setting",
+			envVar: AnsibleForks,
+			value:  "10",
+		},
+		{
+			desc:   "Testing validate an invalid integer setting",
+			envVar: AnsibleForks,
+			value:  "abc",
+			err:    errors.New("invalid value for configuration setting 'ANSIBLE_FORKS': 'abc' is not an integer"),
+		},
+		{
+			desc:   "Testing validate a decimal value on an integer setting",
+			envVar: AnsibleForks,
+			value:  "1.5",
+			err:    errors.New("invalid value for configuration setting 'ANSIBLE_FORKS': '1.5' is not an integer"),
+		},
+		{
+			desc:   "Testing validate a float setting",
+			envVar: AnsibleWorkerShutdownPollDelay,
+			value:  "0.1",
+		},
+		{
+			desc:   "Testing validate an invalid float setting",
+			envVar: AnsibleWorkerShutdownPollDelay,
+			value:  "fast",
+			err:    errors.New("invalid value for configuration setting 'ANSIBLE_WORKER_SHUTDOWN_POLL_DELAY': 'fast' is not a float"),
+		},
+		{
+			desc:   "Testing validate a path setting",
+			envVar: AnsibleLogPath,
+			value:  "/var/log/ansible.log",
+		},
+		{
+			desc:   "Testing validate an invalid path setting",
+			envVar: AnsibleLogPath,
+			value:  "/var/log/\x00ansible.log",
+			err:    errors.New("invalid value for configuration setting 'ANSIBLE_LOG_PATH': path contains a null character"),
+		},
+		{
+			desc:   "Testing validate an enum setting",
+			envVar: AnsibleGathering,
+			value:  "smart",
+		},
+		{
+			desc:   "Testing validate an invalid enum setting",
+			envVar: AnsibleGathering,
+			value:  "always",
+			err:    errors.New("invalid value for configuration setting 'ANSIBLE_GATHERING': 'always' is not a valid choice, valid values are: smart, explicit, implicit"),
+		},
+		{
+			desc:   "Testing validate a list setting with choices",
+			envVar: AnsiblePrecedence,
+			value:  "all_inventory, groups_inventory",
+		},
+		{
+			desc:   "Testing validate an invalid item of a list setting with choices",
+			envVar: AnsiblePrecedence,
+			value:  "all_inventory,host_inventory",
+			err:    errors.New("invalid value for configuration setting 'ANSIBLE_PRECEDENCE': 'host_inventory' is not a valid choice, valid values are: all_inventory, groups_inventory, all_plugins_inventory, all_plugins_play, groups_plugins_inventory, groups_plugins_play"),
+		},
+		{
+			desc:   "Testing validate a setting without metadata",
+			envVar: "ANSIBLE_UNKNOWN",
+			value:  "any",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := ValidateConfigurationSetting(test.envVar, test.value)
+			if test.err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,1171 @@
+// Code generated by utils/cmd/configGenerator.go metadata; DO NOT EDIT.
+
+package configuration
+
+// configurationSettingsMetadata is the metadata of the ansible configuration settings that can be defined by environment variable, indexed by the environment variable name
+var configurationSettingsMetadata = map[string]*ConfigurationSettingMetadata{
+	AnsibleActionWarnings: {
+		Name:   "ACTION_WARNINGS",
+		EnvVar: AnsibleActionWarnings,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "action_warnings"}},
+	},
+	AnsibleAgnosticBecomePrompt: {
+		Name:   "AGNOSTIC_BECOME_PROMPT",
+		EnvVar: AnsibleAgnosticBecomePrompt,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "privilege_escalation", Key: "agnostic_become_prompt"}},
+	},
+	AnsibleConnectionPath: {
+		Name:   "ANSIBLE_CONNECTION_PATH",
+		EnvVar: AnsibleConnectionPath,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "persistent_connection", Key: "ansible_connection_path"}},
+	},
+	AnsibleCowAcceptlist: {
+		Name:   "ANSIBLE_COW_ACCEPTLIST",
+		EnvVar: AnsibleCowAcceptlist,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "cowsay_enabled_stencils"}},
+	},
+	AnsibleCowPath: {
+		Name:   "ANSIBLE_COW_PATH",
+		EnvVar: AnsibleCowPath,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "cowpath"}},
+	},
+	AnsibleCowSelection: {
+		Name:   "ANSIBLE_COW_SELECTION",
+		EnvVar: AnsibleCowSelection,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "cow_selection"}},
+	},
+	AnsibleForceColor: {
+		Name:   "ANSIBLE_FORCE_COLOR",
+		EnvVar: AnsibleForceColor,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "force_color"}},
+	},
+	AnsibleHome: {
+		Name:   "ANSIBLE_HOME",
+		EnvVar: AnsibleHome,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "home"}},
+	},
+	NoColor: {
+		Name:   "ANSIBLE_NOCOLOR",
+		EnvVar: NoColor,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "nocolor"}},
+	},
+	AnsibleNocows: {
+		Name:   "ANSIBLE_NOCOWS",
+		EnvVar: AnsibleNocows,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "nocows"}},
+	},
+	AnsiblePipelining: {
+		Name:   "ANSIBLE_PIPELINING",
+		EnvVar: AnsiblePipelining,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "connection", Key: "pipelining"}, {Section: "defaults", Key: "pipelining"}, {Section: "ssh_connection", Key: "pipelining"}},
+	},
+	AnsibleAnyErrorsFatal: {
+		Name:   "ANY_ERRORS_FATAL",
+		EnvVar: AnsibleAnyErrorsFatal,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "any_errors_fatal"}},
+	},
+	AnsibleBecomeAllowSameUser: {
+		Name:   "BECOME_ALLOW_SAME_USER",
+		EnvVar: AnsibleBecomeAllowSameUser,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "privilege_escalation", Key: "become_allow_same_user"}},
+	},
+	AnsibleBecomePasswordFile: {
+		Name:   "BECOME_PASSWORD_FILE",
+		EnvVar: AnsibleBecomePasswordFile,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "become_password_file"}},
+	},
+	AnsibleBecomePlugins: {
+		Name:   "BECOME_PLUGIN_PATH",
+		EnvVar: AnsibleBecomePlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "become_plugins"}},
+	},
+	AnsibleCachePlugin: {
+		Name:   "CACHE_PLUGIN",
+		EnvVar: AnsibleCachePlugin,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "fact_caching"}},
+	},
+	AnsibleCachePluginConnection: {
+		Name:   "CACHE_PLUGIN_CONNECTION",
+		EnvVar: AnsibleCachePluginConnection,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "fact_caching_connection"}},
+	},
+	AnsibleCachePluginPrefix: {
+		Name:   "CACHE_PLUGIN_PREFIX",
+		EnvVar: AnsibleCachePluginPrefix,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "fact_caching_prefix"}},
+	},
+	AnsibleCachePluginTimeout: {
+		Name:   "CACHE_PLUGIN_TIMEOUT",
+		EnvVar: AnsibleCachePluginTimeout,
+		Type:   ConfigurationSettingTypeInt,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "fact_caching_timeout"}},
+	},
+	AnsibleCallbacksEnabled: {
+		Name:   "CALLBACKS_ENABLED",
+		EnvVar: AnsibleCallbacksEnabled,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "callbacks_enabled"}},
+	},
+	AnsibleCollectionsOnAnsibleVersionMismatch: {
+		Name:    "COLLECTIONS_ON_ANSIBLE_VERSION_MISMATCH",
+		EnvVar:  AnsibleCollectionsOnAnsibleVersionMismatch,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"error", "warning", "ignore"},
+		Ini:     []ConfigurationSettingIni{{Section: "defaults", Key: "collections_on_ansible_version_mismatch"}},
+	},
+	AnsibleCollectionsPaths: {
+		Name:   "COLLECTIONS_PATHS",
+		EnvVar: AnsibleCollectionsPaths,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "collections_path"}, {Section: "defaults", Key: "collections_paths"}},
+	},
+	AnsibleCollectionsScanSysPath: {
+		Name:   "COLLECTIONS_SCAN_SYS_PATH",
+		EnvVar: AnsibleCollectionsScanSysPath,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "collections_scan_sys_path"}},
+	},
+	AnsibleColorChanged: {
+		Name:    "COLOR_CHANGED",
+		EnvVar:  AnsibleColorChanged,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"black", "bright gray", "blue", "white", "green", "bright blue", "cyan", "bright green", "red", "bright cyan", "purple", "bright red", "yellow", "bright purple", "dark gray", "bright yellow", "magenta", "bright magenta", "normal"},
+		Ini:     []ConfigurationSettingIni{{Section: "colors", Key: "changed"}},
+	},
+	AnsibleColorConsolePrompt: {
+		Name:    "COLOR_CONSOLE_PROMPT",
+		EnvVar:  AnsibleColorConsolePrompt,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"black", "bright gray", "blue", "white", "green", "bright blue", "cyan", "bright green", "red", "bright cyan", "purple", "bright red", "yellow", "bright purple", "dark gray", "bright yellow", "magenta", "bright magenta", "normal"},
+		Ini:     []ConfigurationSettingIni{{Section: "colors", Key: "console_prompt"}},
+	},
+	AnsibleColorDebug: {
+		Name:    "COLOR_DEBUG",
+		EnvVar:  AnsibleColorDebug,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"black", "bright gray", "blue", "white", "green", "bright blue", "cyan", "bright green", "red", "bright cyan", "purple", "bright red", "yellow", "bright purple", "dark gray", "bright yellow", "magenta", "bright magenta", "normal"},
+		Ini:     []ConfigurationSettingIni{{Section: "colors", Key: "debug"}},
+	},
+	AnsibleColorDeprecate: {
+		Name:    "COLOR_DEPRECATE",
+		EnvVar:  AnsibleColorDeprecate,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"black", "bright gray", "blue", "white", "green", "bright blue", "cyan", "bright green", "red", "bright cyan", "purple", "bright red", "yellow", "bright purple", "dark gray", "bright yellow", "magenta", "bright magenta", "normal"},
+		Ini:     []ConfigurationSettingIni{{Section: "colors", Key: "deprecate"}},
+	},
+	AnsibleColorDiffAdd: {
+		Name:    "COLOR_DIFF_ADD",
+		EnvVar:  AnsibleColorDiffAdd,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"black", "bright gray", "blue", "white", "green", "bright blue", "cyan", "bright green", "red", "bright cyan", "purple", "bright red", "yellow", "bright purple", "dark gray", "bright yellow", "magenta", "bright magenta", "normal"},
+		Ini:     []ConfigurationSettingIni{{Section: "colors", Key: "diff_add"}},
+	},
+	AnsibleColorDiffLines: {
+		Name:    "COLOR_DIFF_LINES",
+		EnvVar:  AnsibleColorDiffLines,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"black", "bright gray", "blue", "white", "green", "bright blue", "cyan", "bright green", "red", "bright cyan", "purple", "bright red", "yellow", "bright purple", "dark gray", "bright yellow", "magenta", "bright magenta", "normal"},
+		Ini:     []ConfigurationSettingIni{{Section: "colors", Key: "diff_lines"}},
+	},
+	AnsibleColorDiffRemove: {
+		Name:    "COLOR_DIFF_REMOVE",
+		EnvVar:  AnsibleColorDiffRemove,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"black", "bright gray", "blue", "white", "green", "bright blue", "cyan", "bright green", "red", "bright cyan", "purple", "bright red", "yellow", "bright purple", "dark gray", "bright yellow", "magenta", "bright magenta", "normal"},
+		Ini:     []ConfigurationSettingIni{{Section: "colors", Key: "diff_remove"}},
+	},
+	AnsibleColorError: {
+		Name:    "COLOR_ERROR",
+		EnvVar:  AnsibleColorError,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"black", "bright gray", "blue", "white", "green", "bright blue", "cyan", "bright green", "red", "bright cyan", "purple", "bright red", "yellow", "bright purple", "dark gray", "bright yellow", "magenta", "bright magenta", "normal"},
+		Ini:     []ConfigurationSettingIni{{Section: "colors", Key: "error"}},
+	},
+	AnsibleColorHighlight: {
+		Name:    "COLOR_HIGHLIGHT",
+		EnvVar:  AnsibleColorHighlight,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"black", "bright gray", "blue", "white", "green", "bright blue", "cyan", "bright green", "red", "bright cyan", "purple", "bright red", "yellow", "bright purple", "dark gray", "bright yellow", "magenta", "bright magenta", "normal"},
+		Ini:     []ConfigurationSettingIni{{Section: "colors", Key: "highlight"}},
+	},
+	AnsibleColorOk: {
+		Name:    "COLOR_OK",
+		EnvVar:  AnsibleColorOk,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"black", "bright gray", "blue", "white", "green", "bright blue", "cyan", "bright green", "red", "bright cyan", "purple", "bright red", "yellow", "bright purple", "dark gray", "bright yellow", "magenta", "bright magenta", "normal"},
+		Ini:     []ConfigurationSettingIni{{Section: "colors", Key: "ok"}},
+	},
+	AnsibleColorSkip: {
+		Name:    "COLOR_SKIP",
+		EnvVar:  AnsibleColorSkip,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"black", "bright gray", "blue", "white", "green", "bright blue", "cyan", "bright green", "red", "bright cyan", "purple", "bright red", "yellow", "bright purple", "dark gray", "bright yellow", "magenta", "bright magenta", "normal"},
+		Ini:     []ConfigurationSettingIni{{Section: "colors", Key: "skip"}},
+	},
+	AnsibleColorUnreachable: {
+		Name:    "COLOR_UNREACHABLE",
+		EnvVar:  AnsibleColorUnreachable,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"black", "bright gray", "blue", "white", "green", "bright blue", "cyan", "bright green", "red", "bright cyan", "purple", "bright red", "yellow", "bright purple", "dark gray", "bright yellow", "magenta", "bright magenta", "normal"},
+		Ini:     []ConfigurationSettingIni{{Section: "colors", Key: "unreachable"}},
+	},
+	AnsibleColorVerbose: {
+		Name:    "COLOR_VERBOSE",
+		EnvVar:  AnsibleColorVerbose,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"black", "bright gray", "blue", "white", "green", "bright blue", "cyan", "bright green", "red", "bright cyan", "purple", "bright red", "yellow", "bright purple", "dark gray", "bright yellow", "magenta", "bright magenta", "normal"},
+		Ini:     []ConfigurationSettingIni{{Section: "colors", Key: "verbose"}},
+	},
+	AnsibleColorWarn: {
+		Name:    "COLOR_WARN",
+		EnvVar:  AnsibleColorWarn,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"black", "bright gray", "blue", "white", "green", "bright blue", "cyan", "bright green", "red", "bright cyan", "purple", "bright red", "yellow", "bright purple", "dark gray", "bright yellow", "magenta", "bright magenta", "normal"},
+		Ini:     []ConfigurationSettingIni{{Section: "colors", Key: "warn"}},
+	},
+	AnsibleConnectionPasswordFile: {
+		Name:   "CONNECTION_PASSWORD_FILE",
+		EnvVar: AnsibleConnectionPasswordFile,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "connection_password_file"}},
+	},
+	AnsibleCoverageRemoteOutput: {
+		Name:   "COVERAGE_REMOTE_OUTPUT",
+		EnvVar: AnsibleCoverageRemoteOutput,
+		Type:   ConfigurationSettingTypeString,
+	},
+	AnsibleCoverageRemotePathFilter: {
+		Name:   "COVERAGE_REMOTE_PATH_FILTER",
+		EnvVar: AnsibleCoverageRemotePathFilter,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "_ansible_coverage_remote_path_filter"}},
+	},
+	AnsibleActionPlugins: {
+		Name:   "DEFAULT_ACTION_PLUGIN_PATH",
+		EnvVar: AnsibleActionPlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "action_plugins"}},
+	},
+	AnsibleAskPass: {
+		Name:   "DEFAULT_ASK_PASS",
+		EnvVar: AnsibleAskPass,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "ask_pass"}},
+	},
+	AnsibleAskVaultPass: {
+		Name:   "DEFAULT_ASK_VAULT_PASS",
+		EnvVar: AnsibleAskVaultPass,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "ask_vault_pass"}},
+	},
+	AnsibleBecome: {
+		Name:   "DEFAULT_BECOME",
+		EnvVar: AnsibleBecome,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "privilege_escalation", Key: "become"}},
+	},
+	AnsibleBecomeAskPass: {
+		Name:   "DEFAULT_BECOME_ASK_PASS",
+		EnvVar: AnsibleBecomeAskPass,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "privilege_escalation", Key: "become_ask_pass"}},
+	},
+	AnsibleBecomeExe: {
+		Name:   "DEFAULT_BECOME_EXE",
+		EnvVar: AnsibleBecomeExe,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "privilege_escalation", Key: "become_exe"}},
+	},
+	AnsibleBecomeFlags: {
+		Name:   "DEFAULT_BECOME_FLAGS",
+		EnvVar: AnsibleBecomeFlags,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "privilege_escalation", Key: "become_flags"}},
+	},
+	AnsibleBecomeMethod: {
+		Name:   "DEFAULT_BECOME_METHOD",
+		EnvVar: AnsibleBecomeMethod,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "privilege_escalation", Key: "become_method"}},
+	},
+	AnsibleBecomeUser: {
+		Name:   "DEFAULT_BECOME_USER",
+		EnvVar: AnsibleBecomeUser,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "privilege_escalation", Key: "become_user"}},
+	},
+	AnsibleCachePlugins: {
+		Name:   "DEFAULT_CACHE_PLUGIN_PATH",
+		EnvVar: AnsibleCachePlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "cache_plugins"}},
+	},
+	AnsibleCallbackPlugins: {
+		Name:   "DEFAULT_CALLBACK_PLUGIN_PATH",
+		EnvVar: AnsibleCallbackPlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "callback_plugins"}},
+	},
+	AnsibleCliconfPlugins: {
+		Name:   "DEFAULT_CLICONF_PLUGIN_PATH",
+		EnvVar: AnsibleCliconfPlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "cliconf_plugins"}},
+	},
+	AnsibleConnectionPlugins: {
+		Name:   "DEFAULT_CONNECTION_PLUGIN_PATH",
+		EnvVar: AnsibleConnectionPlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "connection_plugins"}},
+	},
+	AnsibleDebug: {
+		Name:   "DEFAULT_DEBUG",
+		EnvVar: AnsibleDebug,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "debug"}},
+	},
+	AnsibleExecutable: {
+		Name:   "DEFAULT_EXECUTABLE",
+		EnvVar: AnsibleExecutable,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "executable"}},
+	},
+	AnsibleFactPath: {
+		Name:   "DEFAULT_FACT_PATH",
+		EnvVar: AnsibleFactPath,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "fact_path"}},
+	},
+	AnsibleFilterPlugins: {
+		Name:   "DEFAULT_FILTER_PLUGIN_PATH",
+		EnvVar: AnsibleFilterPlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "filter_plugins"}},
+	},
+	AnsibleForceHandlers: {
+		Name:   "DEFAULT_FORCE_HANDLERS",
+		EnvVar: AnsibleForceHandlers,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "force_handlers"}},
+	},
+	AnsibleForks: {
+		Name:   "DEFAULT_FORKS",
+		EnvVar: AnsibleForks,
+		Type:   ConfigurationSettingTypeInt,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "forks"}},
+	},
+	AnsibleGathering: {
+		Name:    "DEFAULT_GATHERING",
+		EnvVar:  AnsibleGathering,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"smart", "explicit", "implicit"},
+		Ini:     []ConfigurationSettingIni{{Section: "defaults", Key: "gathering"}},
+	},
+	AnsibleGatherSubset: {
+		Name:   "DEFAULT_GATHER_SUBSET",
+		EnvVar: AnsibleGatherSubset,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "gather_subset"}},
+	},
+	AnsibleGatherTimeout: {
+		Name:   "DEFAULT_GATHER_TIMEOUT",
+		EnvVar: AnsibleGatherTimeout,
+		Type:   ConfigurationSettingTypeInt,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "gather_timeout"}},
+	},
+	AnsibleHashBehaviour: {
+		Name:    "DEFAULT_HASH_BEHAVIOUR",
+		EnvVar:  AnsibleHashBehaviour,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"replace", "merge"},
+		Ini:     []ConfigurationSettingIni{{Section: "defaults", Key: "hash_behaviour"}},
+	},
+	AnsibleInventory: {
+		Name:   "DEFAULT_HOST_LIST",
+		EnvVar: AnsibleInventory,
+		Type:   ConfigurationSettingTypePathList,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "inventory"}},
+	},
+	AnsibleHttpapiPlugins: {
+		Name:   "DEFAULT_HTTPAPI_PLUGIN_PATH",
+		EnvVar: AnsibleHttpapiPlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "httpapi_plugins"}},
+	},
+	AnsibleInventoryPlugins: {
+		Name:   "DEFAULT_INVENTORY_PLUGIN_PATH",
+		EnvVar: AnsibleInventoryPlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "inventory_plugins"}},
+	},
+	AnsibleJinja2Extensions: {
+		Name:   "DEFAULT_JINJA2_EXTENSIONS",
+		EnvVar: AnsibleJinja2Extensions,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "jinja2_extensions"}},
+	},
+	AnsibleJinja2Native: {
+		Name:   "DEFAULT_JINJA2_NATIVE",
+		EnvVar: AnsibleJinja2Native,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "jinja2_native"}},
+	},
+	AnsibleKeepRemoteFiles: {
+		Name:   "DEFAULT_KEEP_REMOTE_FILES",
+		EnvVar: AnsibleKeepRemoteFiles,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "keep_remote_files"}},
+	},
+	AnsibleLibvirtLxcNoseclabel: {
+		Name:   "DEFAULT_LIBVIRT_LXC_NOSECLABEL",
+		EnvVar: AnsibleLibvirtLxcNoseclabel,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "selinux", Key: "libvirt_lxc_noseclabel"}},
+	},
+	AnsibleLoadCallbackPlugins: {
+		Name:   "DEFAULT_LOAD_CALLBACK_PLUGINS",
+		EnvVar: AnsibleLoadCallbackPlugins,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "bin_ansible_callbacks"}},
+	},
+	AnsibleLocalTemp: {
+		Name:   "DEFAULT_LOCAL_TMP",
+		EnvVar: AnsibleLocalTemp,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "local_tmp"}},
+	},
+	AnsibleLogFilter: {
+		Name:   "DEFAULT_LOG_FILTER",
+		EnvVar: AnsibleLogFilter,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "log_filter"}},
+	},
+	AnsibleLogPath: {
+		Name:   "DEFAULT_LOG_PATH",
+		EnvVar: AnsibleLogPath,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "log_path"}},
+	},
+	AnsibleLookupPlugins: {
+		Name:   "DEFAULT_LOOKUP_PLUGIN_PATH",
+		EnvVar: AnsibleLookupPlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "lookup_plugins"}},
+	},
+	AnsibleModuleArgs: {
+		Name:   "DEFAULT_MODULE_ARGS",
+		EnvVar: AnsibleModuleArgs,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "module_args"}},
+	},
+	AnsibleLibrary: {
+		Name:   "DEFAULT_MODULE_PATH",
+		EnvVar: AnsibleLibrary,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "library"}},
+	},
+	AnsibleModuleUtils: {
+		Name:   "DEFAULT_MODULE_UTILS_PATH",
+		EnvVar: AnsibleModuleUtils,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "module_utils"}},
+	},
+	AnsibleNetconfPlugins: {
+		Name:   "DEFAULT_NETCONF_PLUGIN_PATH",
+		EnvVar: AnsibleNetconfPlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "netconf_plugins"}},
+	},
+	AnsibleNoLog: {
+		Name:   "DEFAULT_NO_LOG",
+		EnvVar: AnsibleNoLog,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "no_log"}},
+	},
+	AnsibleNoTargetSyslog: {
+		Name:   "DEFAULT_NO_TARGET_SYSLOG",
+		EnvVar: AnsibleNoTargetSyslog,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "no_target_syslog"}},
+	},
+	AnsibleNullRepresentation: {
+		Name:   "DEFAULT_NULL_REPRESENTATION",
+		EnvVar: AnsibleNullRepresentation,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "null_representation"}},
+	},
+	AnsiblePollInterval: {
+		Name:   "DEFAULT_POLL_INTERVAL",
+		EnvVar: AnsiblePollInterval,
+		Type:   ConfigurationSettingTypeInt,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "poll_interval"}},
+	},
+	AnsiblePrivateKeyFile: {
+		Name:   "DEFAULT_PRIVATE_KEY_FILE",
+		EnvVar: AnsiblePrivateKeyFile,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "private_key_file"}},
+	},
+	AnsiblePrivateRoleVars: {
+		Name:   "DEFAULT_PRIVATE_ROLE_VARS",
+		EnvVar: AnsiblePrivateRoleVars,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "private_role_vars"}},
+	},
+	AnsibleRemotePort: {
+		Name:   "DEFAULT_REMOTE_PORT",
+		EnvVar: AnsibleRemotePort,
+		Type:   ConfigurationSettingTypeInt,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "remote_port"}},
+	},
+	AnsibleRemoteUser: {
+		Name:   "DEFAULT_REMOTE_USER",
+		EnvVar: AnsibleRemoteUser,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "remote_user"}},
+	},
+	AnsibleRolesPath: {
+		Name:   "DEFAULT_ROLES_PATH",
+		EnvVar: AnsibleRolesPath,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "roles_path"}},
+	},
+	AnsibleSelinuxSpecialFs: {
+		Name:   "DEFAULT_SELINUX_SPECIAL_FS",
+		EnvVar: AnsibleSelinuxSpecialFs,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "selinux", Key: "special_context_filesystems"}},
+	},
+	AnsibleStdoutCallback: {
+		Name:   "DEFAULT_STDOUT_CALLBACK",
+		EnvVar: AnsibleStdoutCallback,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "stdout_callback"}},
+	},
+	AnsibleStrategy: {
+		Name:   "DEFAULT_STRATEGY",
+		EnvVar: AnsibleStrategy,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "strategy"}},
+	},
+	AnsibleStrategyPlugins: {
+		Name:   "DEFAULT_STRATEGY_PLUGIN_PATH",
+		EnvVar: AnsibleStrategyPlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "strategy_plugins"}},
+	},
+	AnsibleSu: {
+		Name:   "DEFAULT_SU",
+		EnvVar: AnsibleSu,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "su"}},
+	},
+	AnsibleSyslogFacility: {
+		Name:   "DEFAULT_SYSLOG_FACILITY",
+		EnvVar: AnsibleSyslogFacility,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "syslog_facility"}},
+	},
+	AnsibleTerminalPlugins: {
+		Name:   "DEFAULT_TERMINAL_PLUGIN_PATH",
+		EnvVar: AnsibleTerminalPlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "terminal_plugins"}},
+	},
+	AnsibleTestPlugins: {
+		Name:   "DEFAULT_TEST_PLUGIN_PATH",
+		EnvVar: AnsibleTestPlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "test_plugins"}},
+	},
+	AnsibleTimeout: {
+		Name:   "DEFAULT_TIMEOUT",
+		EnvVar: AnsibleTimeout,
+		Type:   ConfigurationSettingTypeInt,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "timeout"}},
+	},
+	AnsibleTransport: {
+		Name:   "DEFAULT_TRANSPORT",
+		EnvVar: AnsibleTransport,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "transport"}},
+	},
+	AnsibleErrorOnUndefinedVars: {
+		Name:   "DEFAULT_UNDEFINED_VAR_BEHAVIOR",
+		EnvVar: AnsibleErrorOnUndefinedVars,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "error_on_undefined_vars"}},
+	},
+	AnsibleVarsPlugins: {
+		Name:   "DEFAULT_VARS_PLUGIN_PATH",
+		EnvVar: AnsibleVarsPlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "vars_plugins"}},
+	},
+	AnsibleVaultEncryptIdentity: {
+		Name:   "DEFAULT_VAULT_ENCRYPT_IDENTITY",
+		EnvVar: AnsibleVaultEncryptIdentity,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "vault_encrypt_identity"}},
+	},
+	AnsibleVaultIdentity: {
+		Name:   "DEFAULT_VAULT_IDENTITY",
+		EnvVar: AnsibleVaultIdentity,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "vault_identity"}},
+	},
+	AnsibleVaultIdentityList: {
+		Name:   "DEFAULT_VAULT_IDENTITY_LIST",
+		EnvVar: AnsibleVaultIdentityList,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "vault_identity_list"}},
+	},
+	AnsibleVaultIdMatch: {
+		Name:   "DEFAULT_VAULT_ID_MATCH",
+		EnvVar: AnsibleVaultIdMatch,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "vault_id_match"}},
+	},
+	AnsibleVaultPasswordFile: {
+		Name:   "DEFAULT_VAULT_PASSWORD_FILE",
+		EnvVar: AnsibleVaultPasswordFile,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "vault_password_file"}},
+	},
+	AnsibleVerbosity: {
+		Name:   "DEFAULT_VERBOSITY",
+		EnvVar: AnsibleVerbosity,
+		Type:   ConfigurationSettingTypeInt,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "verbosity"}},
+	},
+	AnsibleDeprecationWarnings: {
+		Name:   "DEPRECATION_WARNINGS",
+		EnvVar: AnsibleDeprecationWarnings,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "deprecation_warnings"}},
+	},
+	AnsibleDevelWarning: {
+		Name:   "DEVEL_WARNING",
+		EnvVar: AnsibleDevelWarning,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "devel_warning"}},
+	},
+	AnsibleDiffAlways: {
+		Name:   "DIFF_ALWAYS",
+		EnvVar: AnsibleDiffAlways,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "diff", Key: "always"}},
+	},
+	AnsibleDiffContext: {
+		Name:   "DIFF_CONTEXT",
+		EnvVar: AnsibleDiffContext,
+		Type:   ConfigurationSettingTypeInt,
+		Ini:    []ConfigurationSettingIni{{Section: "diff", Key: "context"}},
+	},
+	AnsibleDisplayArgsToStdout: {
+		Name:   "DISPLAY_ARGS_TO_STDOUT",
+		EnvVar: AnsibleDisplayArgsToStdout,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "display_args_to_stdout"}},
+	},
+	AnsibleDisplaySkippedHosts: {
+		Name:   "DISPLAY_SKIPPED_HOSTS",
+		EnvVar: AnsibleDisplaySkippedHosts,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "display_skipped_hosts"}},
+	},
+	AnsibleDocFragmentPlugins: {
+		Name:   "DOC_FRAGMENT_PLUGIN_PATH",
+		EnvVar: AnsibleDocFragmentPlugins,
+		Type:   ConfigurationSettingTypePathSpec,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "doc_fragment_plugins"}},
+	},
+	AnsibleDuplicateYamlDictKey: {
+		Name:    "DUPLICATE_YAML_DICT_KEY",
+		EnvVar:  AnsibleDuplicateYamlDictKey,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"warn", "error", "ignore"},
+		Ini:     []ConfigurationSettingIni{{Section: "defaults", Key: "duplicate_dict_key"}},
+	},
+	Editor: {
+		Name:   "EDITOR",
+		EnvVar: Editor,
+		Type:   ConfigurationSettingTypeString,
+	},
+	AnsibleEnableTaskDebugger: {
+		Name:   "ENABLE_TASK_DEBUGGER",
+		EnvVar: AnsibleEnableTaskDebugger,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "enable_task_debugger"}},
+	},
+	AnsibleErrorOnMissingHandler: {
+		Name:   "ERROR_ON_MISSING_HANDLER",
+		EnvVar: AnsibleErrorOnMissingHandler,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "error_on_missing_handler"}},
+	},
+	AnsibleFactsModules: {
+		Name:   "FACTS_MODULES",
+		EnvVar: AnsibleFactsModules,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "facts_modules"}},
+	},
+	AnsibleGalaxyCacheDir: {
+		Name:   "GALAXY_CACHE_DIR",
+		EnvVar: AnsibleGalaxyCacheDir,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "cache_dir"}},
+	},
+	AnsibleGalaxyCollectionsPathWarning: {
+		Name:   "GALAXY_COLLECTIONS_PATH_WARNING",
+		EnvVar: AnsibleGalaxyCollectionsPathWarning,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "collections_path_warning"}},
+	},
+	AnsibleGalaxyCollectionSkeleton: {
+		Name:   "GALAXY_COLLECTION_SKELETON",
+		EnvVar: AnsibleGalaxyCollectionSkeleton,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "collection_skeleton"}},
+	},
+	AnsibleGalaxyCollectionSkeletonIgnore: {
+		Name:   "GALAXY_COLLECTION_SKELETON_IGNORE",
+		EnvVar: AnsibleGalaxyCollectionSkeletonIgnore,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "collection_skeleton_ignore"}},
+	},
+	AnsibleGalaxyDisableGpgVerify: {
+		Name:   "GALAXY_DISABLE_GPG_VERIFY",
+		EnvVar: AnsibleGalaxyDisableGpgVerify,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "disable_gpg_verify"}},
+	},
+	AnsibleGalaxyDisplayProgress: {
+		Name:   "GALAXY_DISPLAY_PROGRESS",
+		EnvVar: AnsibleGalaxyDisplayProgress,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "display_progress"}},
+	},
+	AnsibleGalaxyGpgKeyring: {
+		Name:   "GALAXY_GPG_KEYRING",
+		EnvVar: AnsibleGalaxyGpgKeyring,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "gpg_keyring"}},
+	},
+	AnsibleGalaxyIgnore: {
+		Name:   "GALAXY_IGNORE_CERTS",
+		EnvVar: AnsibleGalaxyIgnore,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "ignore_certs"}},
+	},
+	AnsibleGalaxyIgnoreSignatureStatusCodes: {
+		Name:   "GALAXY_IGNORE_INVALID_SIGNATURE_STATUS_CODES",
+		EnvVar: AnsibleGalaxyIgnoreSignatureStatusCodes,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "ignore_signature_status_codes"}},
+	},
+	AnsibleGalaxyRequiredValidSignatureCount: {
+		Name:   "GALAXY_REQUIRED_VALID_SIGNATURE_COUNT",
+		EnvVar: AnsibleGalaxyRequiredValidSignatureCount,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "required_valid_signature_count"}},
+	},
+	AnsibleGalaxyRoleSkeleton: {
+		Name:   "GALAXY_ROLE_SKELETON",
+		EnvVar: AnsibleGalaxyRoleSkeleton,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "role_skeleton"}},
+	},
+	AnsibleGalaxyRoleSkeletonIgnore: {
+		Name:   "GALAXY_ROLE_SKELETON_IGNORE",
+		EnvVar: AnsibleGalaxyRoleSkeletonIgnore,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "role_skeleton_ignore"}},
+	},
+	AnsibleGalaxyServer: {
+		Name:   "GALAXY_SERVER",
+		EnvVar: AnsibleGalaxyServer,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "server"}},
+	},
+	AnsibleGalaxyServerList: {
+		Name:   "GALAXY_SERVER_LIST",
+		EnvVar: AnsibleGalaxyServerList,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "server_list"}},
+	},
+	AnsibleGalaxyServerTimeout: {
+		Name:   "GALAXY_SERVER_TIMEOUT",
+		EnvVar: AnsibleGalaxyServerTimeout,
+		Type:   ConfigurationSettingTypeInt,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "server_timeout"}},
+	},
+	AnsibleGalaxyTokenPath: {
+		Name:   "GALAXY_TOKEN_PATH",
+		EnvVar: AnsibleGalaxyTokenPath,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "galaxy", Key: "token_path"}},
+	},
+	AnsibleHostKeyChecking: {
+		Name:   "HOST_KEY_CHECKING",
+		EnvVar: AnsibleHostKeyChecking,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "host_key_checking"}},
+	},
+	AnsibleHostPatternMismatch: {
+		Name:    "HOST_PATTERN_MISMATCH",
+		EnvVar:  AnsibleHostPatternMismatch,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"warning", "error", "ignore"},
+		Ini:     []ConfigurationSettingIni{{Section: "inventory", Key: "host_pattern_mismatch"}},
+	},
+	AnsibleInjectFactVars: {
+		Name:   "INJECT_FACTS_AS_VARS",
+		EnvVar: AnsibleInjectFactVars,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "inject_facts_as_vars"}},
+	},
+	AnsiblePythonInterpreter: {
+		Name:   "INTERPRETER_PYTHON",
+		EnvVar: AnsiblePythonInterpreter,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "interpreter_python"}},
+	},
+	AnsibleInvalidTaskAttributeFailed: {
+		Name:   "INVALID_TASK_ATTRIBUTE_FAILED",
+		EnvVar: AnsibleInvalidTaskAttributeFailed,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "invalid_task_attribute_failed"}},
+	},
+	AnsibleInventoryAnyUnparsedIsFailed: {
+		Name:   "INVENTORY_ANY_UNPARSED_IS_FAILED",
+		EnvVar: AnsibleInventoryAnyUnparsedIsFailed,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "inventory", Key: "any_unparsed_is_failed"}},
+	},
+	AnsibleInventoryCache: {
+		Name:   "INVENTORY_CACHE_ENABLED",
+		EnvVar: AnsibleInventoryCache,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "inventory", Key: "cache"}},
+	},
+	AnsibleInventoryCachePlugin: {
+		Name:   "INVENTORY_CACHE_PLUGIN",
+		EnvVar: AnsibleInventoryCachePlugin,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "inventory", Key: "cache_plugin"}},
+	},
+	AnsibleInventoryCacheConnection: {
+		Name:   "INVENTORY_CACHE_PLUGIN_CONNECTION",
+		EnvVar: AnsibleInventoryCacheConnection,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "inventory", Key: "cache_connection"}},
+	},
+	AnsibleInventoryCachePluginPrefix: {
+		Name:   "INVENTORY_CACHE_PLUGIN_PREFIX",
+		EnvVar: AnsibleInventoryCachePluginPrefix,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "inventory", Key: "cache_prefix"}},
+	},
+	AnsibleInventoryCacheTimeout: {
+		Name:   "INVENTORY_CACHE_TIMEOUT",
+		EnvVar: AnsibleInventoryCacheTimeout,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "inventory", Key: "cache_timeout"}},
+	},
+	AnsibleInventoryEnabled: {
+		Name:   "INVENTORY_ENABLED",
+		EnvVar: AnsibleInventoryEnabled,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "inventory", Key: "enable_plugins"}},
+	},
+	AnsibleInventoryExport: {
+		Name:   "INVENTORY_EXPORT",
+		EnvVar: AnsibleInventoryExport,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "inventory", Key: "export"}},
+	},
+	AnsibleInventoryIgnore: {
+		Name:   "INVENTORY_IGNORE_EXTS",
+		EnvVar: AnsibleInventoryIgnore,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "inventory_ignore_extensions"}, {Section: "inventory", Key: "ignore_extensions"}},
+	},
+	AnsibleInventoryIgnoreRegex: {
+		Name:   "INVENTORY_IGNORE_PATTERNS",
+		EnvVar: AnsibleInventoryIgnoreRegex,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "inventory_ignore_patterns"}, {Section: "inventory", Key: "ignore_patterns"}},
+	},
+	AnsibleInventoryUnparsedFailed: {
+		Name:   "INVENTORY_UNPARSED_IS_FAILED",
+		EnvVar: AnsibleInventoryUnparsedFailed,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "inventory", Key: "unparsed_is_failed"}},
+	},
+	AnsibleInventoryUnparsedWarning: {
+		Name:   "INVENTORY_UNPARSED_WARNING",
+		EnvVar: AnsibleInventoryUnparsedWarning,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "inventory", Key: "inventory_unparsed_warning"}},
+	},
+	AnsibleJinja2NativeWarning: {
+		Name:   "JINJA2_NATIVE_WARNING",
+		EnvVar: AnsibleJinja2NativeWarning,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "jinja2_native_warning"}},
+	},
+	AnsibleLocalhostWarning: {
+		Name:   "LOCALHOST_WARNING",
+		EnvVar: AnsibleLocalhostWarning,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "localhost_warning"}},
+	},
+	AnsibleMaxDiffSize: {
+		Name:   "MAX_FILE_SIZE_FOR_DIFF",
+		EnvVar: AnsibleMaxDiffSize,
+		Type:   ConfigurationSettingTypeInt,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "max_diff_size"}},
+	},
+	AnsibleModuleIgnoreExts: {
+		Name:   "MODULE_IGNORE_EXTS",
+		EnvVar: AnsibleModuleIgnoreExts,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "module_ignore_exts"}},
+	},
+	AnsibleModuleStrictUtf8Response: {
+		Name:   "MODULE_STRICT_UTF8_RESPONSE",
+		EnvVar: AnsibleModuleStrictUtf8Response,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "module_strict_utf8_response"}},
+	},
+	AnsibleNetconfSshConfig: {
+		Name:   "NETCONF_SSH_CONFIG",
+		EnvVar: AnsibleNetconfSshConfig,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "netconf_connection", Key: "ssh_config"}},
+	},
+	AnsibleNetworkGroupModules: {
+		Name:   "NETWORK_GROUP_MODULES",
+		EnvVar: AnsibleNetworkGroupModules,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "network_group_modules"}},
+	},
+	AnsibleOldPluginCacheClear: {
+		Name:   "OLD_PLUGIN_CACHE_CLEARING",
+		EnvVar: AnsibleOldPluginCacheClear,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "old_plugin_cache_clear"}},
+	},
+	Pager: {
+		Name:   "PAGER",
+		EnvVar: Pager,
+		Type:   ConfigurationSettingTypeString,
+	},
+	AnsibleParamikoHostKeyAutoAdd: {
+		Name:   "PARAMIKO_HOST_KEY_AUTO_ADD",
+		EnvVar: AnsibleParamikoHostKeyAutoAdd,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "paramiko_connection", Key: "host_key_auto_add"}},
+	},
+	AnsibleParamikoLookForKeys: {
+		Name:   "PARAMIKO_LOOK_FOR_KEYS",
+		EnvVar: AnsibleParamikoLookForKeys,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "paramiko_connection", Key: "look_for_keys"}},
+	},
+	AnsiblePersistentCommandTimeout: {
+		Name:   "PERSISTENT_COMMAND_TIMEOUT",
+		EnvVar: AnsiblePersistentCommandTimeout,
+		Type:   ConfigurationSettingTypeInt,
+		Ini:    []ConfigurationSettingIni{{Section: "persistent_connection", Key: "command_timeout"}},
+	},
+	AnsiblePersistentConnectRetryTimeout: {
+		Name:   "PERSISTENT_CONNECT_RETRY_TIMEOUT",
+		EnvVar: AnsiblePersistentConnectRetryTimeout,
+		Type:   ConfigurationSettingTypeInt,
+		Ini:    []ConfigurationSettingIni{{Section: "persistent_connection", Key: "connect_retry_timeout"}},
+	},
+	AnsiblePersistentConnectTimeout: {
+		Name:   "PERSISTENT_CONNECT_TIMEOUT",
+		EnvVar: AnsiblePersistentConnectTimeout,
+		Type:   ConfigurationSettingTypeInt,
+		Ini:    []ConfigurationSettingIni{{Section: "persistent_connection", Key: "connect_timeout"}},
+	},
+	AnsiblePersistentControlPathDir: {
+		Name:   "PERSISTENT_CONTROL_PATH_DIR",
+		EnvVar: AnsiblePersistentControlPathDir,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "persistent_connection", Key: "control_path_dir"}},
+	},
+	AnsiblePlaybookDir: {
+		Name:   "PLAYBOOK_DIR",
+		EnvVar: AnsiblePlaybookDir,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "playbook_dir"}},
+	},
+	AnsiblePlaybookVarsRoot: {
+		Name:   "PLAYBOOK_VARS_ROOT",
+		EnvVar: AnsiblePlaybookVarsRoot,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "playbook_vars_root"}},
+	},
+	AnsiblePythonModuleRlimitNofile: {
+		Name:   "PYTHON_MODULE_RLIMIT_NOFILE",
+		EnvVar: AnsiblePythonModuleRlimitNofile,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "python_module_rlimit_nofile"}},
+	},
+	AnsibleRetryFilesEnabled: {
+		Name:   "RETRY_FILES_ENABLED",
+		EnvVar: AnsibleRetryFilesEnabled,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "retry_files_enabled"}},
+	},
+	AnsibleRetryFilesSavePath: {
+		Name:   "RETRY_FILES_SAVE_PATH",
+		EnvVar: AnsibleRetryFilesSavePath,
+		Type:   ConfigurationSettingTypePath,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "retry_files_save_path"}},
+	},
+	AnsibleRunVarsPlugins: {
+		Name:    "RUN_VARS_PLUGINS",
+		EnvVar:  AnsibleRunVarsPlugins,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"demand", "start"},
+		Ini:     []ConfigurationSettingIni{{Section: "defaults", Key: "run_vars_plugins"}},
+	},
+	AnsibleShowCustomStats: {
+		Name:   "SHOW_CUSTOM_STATS",
+		EnvVar: AnsibleShowCustomStats,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "show_custom_stats"}},
+	},
+	AnsibleStringConversionAction: {
+		Name:    "STRING_CONVERSION_ACTION",
+		EnvVar:  AnsibleStringConversionAction,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"error", "warn", "ignore"},
+		Ini:     []ConfigurationSettingIni{{Section: "defaults", Key: "string_conversion_action"}},
+	},
+	AnsibleStringTypeFilters: {
+		Name:   "STRING_TYPE_FILTERS",
+		EnvVar: AnsibleStringTypeFilters,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "jinja2", Key: "dont_type_filters"}},
+	},
+	AnsibleSystemWarnings: {
+		Name:   "SYSTEM_WARNINGS",
+		EnvVar: AnsibleSystemWarnings,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "system_warnings"}},
+	},
+	AnsibleRunTags: {
+		Name:   "TAGS_RUN",
+		EnvVar: AnsibleRunTags,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "tags", Key: "run"}},
+	},
+	AnsibleSkipTags: {
+		Name:   "TAGS_SKIP",
+		EnvVar: AnsibleSkipTags,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "tags", Key: "skip"}},
+	},
+	AnsibleTaskDebuggerIgnoreErrors: {
+		Name:   "TASK_DEBUGGER_IGNORE_ERRORS",
+		EnvVar: AnsibleTaskDebuggerIgnoreErrors,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "task_debugger_ignore_errors"}},
+	},
+	AnsibleTaskTimeout: {
+		Name:   "TASK_TIMEOUT",
+		EnvVar: AnsibleTaskTimeout,
+		Type:   ConfigurationSettingTypeInt,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "task_timeout"}},
+	},
+	AnsibleTransformInvalidGroupChars: {
+		Name:    "TRANSFORM_INVALID_GROUP_CHARS",
+		EnvVar:  AnsibleTransformInvalidGroupChars,
+		Type:    ConfigurationSettingTypeString,
+		Choices: []string{"always", "never", "ignore", "silently"},
+		Ini:     []ConfigurationSettingIni{{Section: "defaults", Key: "force_valid_group_names"}},
+	},
+	AnsibleUsePersistentConnections: {
+		Name:   "USE_PERSISTENT_CONNECTIONS",
+		EnvVar: AnsibleUsePersistentConnections,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "use_persistent_connections"}},
+	},
+	AnsibleValidateActionGroupMetadata: {
+		Name:   "VALIDATE_ACTION_GROUP_METADATA",
+		EnvVar: AnsibleValidateActionGroupMetadata,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "validate_action_group_metadata"}},
+	},
+	AnsibleVarsEnabled: {
+		Name:   "VARIABLE_PLUGINS_ENABLED",
+		EnvVar: AnsibleVarsEnabled,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "vars_plugins_enabled"}},
+	},
+	AnsiblePrecedence: {
+		Name:    "VARIABLE_PRECEDENCE",
+		EnvVar:  AnsiblePrecedence,
+		Type:    ConfigurationSettingTypeList,
+		Choices: []string{"all_inventory", "groups_inventory", "all_plugins_inventory", "all_plugins_play", "groups_plugins_inventory", "groups_plugins_play"},
+		Ini:     []ConfigurationSettingIni{{Section: "defaults", Key: "precedence"}},
+	},
+	AnsibleVaultEncryptSalt: {
+		Name:   "VAULT_ENCRYPT_SALT",
+		EnvVar: AnsibleVaultEncryptSalt,
+		Type:   ConfigurationSettingTypeString,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "vault_encrypt_salt"}},
+	},
+	AnsibleVerboseToStderr: {
+		Name:   "VERBOSE_TO_STDERR",
+		EnvVar: AnsibleVerboseToStderr,
+		Type:   ConfigurationSettingTypeBool,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "verbose_to_stderr"}},
+	},
+	AnsibleWinAsyncStartupTimeout: {
+		Name:   "WIN_ASYNC_STARTUP_TIMEOUT",
+		EnvVar: AnsibleWinAsyncStartupTimeout,
+		Type:   ConfigurationSettingTypeInt,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "win_async_startup_timeout"}},
+	},
+	AnsibleWorkerShutdownPollCount: {
+		Name:   "WORKER_SHUTDOWN_POLL_COUNT",
+		EnvVar: AnsibleWorkerShutdownPollCount,
+		Type:   ConfigurationSettingTypeInt,
+	},
+	AnsibleWorkerShutdownPollDelay: {
+		Name:   "WORKER_SHUTDOWN_POLL_DELAY",
+		EnvVar: AnsibleWorkerShutdownPollDelay,
+		Type:   ConfigurationSettingTypeFloat,
+	},
+	AnsibleYamlFilenameExt: {
+		Name:   "YAML_FILENAME_EXTENSIONS",
+		EnvVar: AnsibleYamlFilenameExt,
+		Type:   ConfigurationSettingTypeList,
+		Ini:    []ConfigurationSettingIni{{Section: "defaults", Key: "yaml_valid_extensions"}},
+	},
+}
@@ -2,7 +2,9 @@ package configuration
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 )
 
 //type configurationSettings map[string]string
@@ -40,18 +42,42 @@ func (e *AnsibleWithConfigurationSettingsExecute) Execute(ctx context.Context) e
 		return fmt.Errorf("AnsibleWithConfigurationSettingsExecute executor requires an executor")
 	}
 
+	err := e.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid configuration settings: %w", err)
+	}
+
 	for key, value := range e.configurationSettings {
 		e.executor.AddEnvVar(key, value)
 	}
 
-	err := e.executor.Execute(ctx)
+	err = e.executor.Execute(ctx)
 	if err != nil {
-		return fmt.Errorf("error executing command: %s", err.Error())
+		return fmt.Errorf("error executing command: %w", err)
 	}
 
 	return nil
 }
 
+// Validate returns an error when any configuration setting value is not valid for its type or it is not one of the allowed choices. The errors of all the invalid settings are joined, sorted by environment variable
+func (e *AnsibleWithConfigurationSettingsExecute) Validate() error {
+	envVars := make([]string, 0, len(e.configurationSettings))
+	for envVar := range e.configurationSettings {
+		envVars = append(envVars, envVar)
+	}
+	sort.Strings(envVars)
+
+	errs := []error{}
+	for _, envVar := range envVars {
+		err := ValidateConfigurationSetting(envVar, e.configurationSettings[envVar])
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // WithAnsibleActionWarnings sets the option ANSIBLE_ACTION_WARNINGS to true (By default Ansible will issue a warning when received from a task action (module or action plugin) These warnings can be silenced by adjusting this setting to False.)
 func WithAnsibleActionWarnings() ConfigurationSettingsFunc {
 	return func(e *AnsibleWithConfigurationSettingsExecute) {
@@ -1,11 +1,14 @@
 package configuration
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/apenella/go-ansible/v2/pkg/execute"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestWithExecutor(t *testing.T) {
@@ -15,6 +18,66 @@ func TestWithExecutor(t *testing.T) {
 	assert.Equal(t, executor, e.executor)
 }
 
+func TestAnsibleWithConfigurationSettingsExecuteValidation(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options []ConfigurationSettingsFunc
+		envVars map[string]string
+		err     error
+	}{
+		{
+			desc: "Testing execute with valid configuration settings",
+			options: []ConfigurationSettingsFunc{
+				WithAnsibleForks(10),
+				WithAnsibleDiffAlways("yes"),
+				WithAnsibleGathering("explicit"),
+			},
+			envVars: map[string]string{
+				AnsibleForks:      "10",
+				AnsibleDiffAlways: "yes",
+				AnsibleGathering:  "explicit",
+			},
+		},
+		{
+			desc: "Testing execute with invalid configuration settings",
+			options: []ConfigurationSettingsFunc{
+				WithAnsibleMaxDiffSize("big"),
+				WithAnsibleDiffAlways("sometimes"),
+				WithAnsibleGathering("always"),
+				WithAnsibleForks(10),
+			},
+			envVars: map[string]string{},
+			err: errors.New(`invalid configuration settings: invalid value for configuration setting 'ANSIBLE_DIFF_ALWAYS': 'sometimes' is not a boolean, valid values are: y, yes, on, 1, true, t, n, no, off, 0, false, f
+invalid value for configuration setting 'ANSIBLE_GATHERING': 'always' is not a valid choice, valid values are: smart, explicit, implicit
+invalid value for configuration setting 'ANSIBLE_MAX_DIFF_SIZE': 'big' is not an integer`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			envVars := map[string]string{}
+
+			executor := execute.NewMockExecute()
+			executor.On("AddEnvVar", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				envVars[args.String(0)] = args.String(1)
+			})
+			executor.On("Execute", mock.Anything).Return(nil)
+
+			err := NewAnsibleWithConfigurationSettingsExecute(executor, test.options...).Execute(context.TODO())
+			if test.err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+				executor.AssertNotCalled(t, "Execute", mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, test.envVars, envVars)
+		})
+	}
+}
+
 // TestWithAnsibleActionWarnings tests the method that sets ANSIBLE_ACTION_WARNINGS to true
 func TestWithAnsibleActionWarnings(t *testing.T) {
 	exec := NewAnsibleWithConfigurationSettingsExecute(nil,
@@ -1,18 +1,23 @@
 package execute
 
 import (
+	"bytes"
 	"context"
+	goerrors "errors"
 	"fmt"
 	"io"
 	"os"
 	osexec "os/exec"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
 	"github.com/apenella/go-ansible/v2/pkg/execute/result"
 	defaultresults "github.com/apenella/go-ansible/v2/pkg/execute/result/default"
+	jsonresults "github.com/apenella/go-ansible/v2/pkg/execute/result/json"
 	"github.com/apenella/go-ansible/v2/pkg/execute/result/transformer"
+	"github.com/apenella/go-ansible/v2/pkg/redact"
 	errors "github.com/apenella/go-common-utils/error"
 )
 
@@ -60,6 +65,35 @@ func (e EnvVars) Environ() []string {
 	return result
 }
 
+// RedactedEnviron returns a copy of strings representing the custom environment, in the form "key=value", where the values of the variables that hold a secret and the secrets of the redactor are masked. The secrets registered on the redact package are masked when the redactor is nil
+func (e EnvVars) RedactedEnviron(redactor *redact.Redactor) []string {
+	if redactor == nil {
+		redactor = redact.DefaultRedactor()
+	}
+
+	result := make([]string, 0, len(e))
+	for k, v := range e {
+		result = append(result, fmt.Sprintf("%s=%s", k, redactor.RedactEnvVar(k, v)))
+	}
+	return result
+}
+
+// ExecuteError is the error returned by DefaultExecute when the command execution fails. The error returned by the command, once enriched, can be inspected using errors.Is and errors.As
+type ExecuteError struct {
+	err   *errors.Error
+	cause error
+}
+
+// Error returns the error message
+func (e *ExecuteError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the error returned by the command, once enriched
+func (e *ExecuteError) Unwrap() error {
+	return e.cause
+}
+
 // DefaultExecute is a simple definition of an executor
 type DefaultExecute struct {
 	// Cmd is the command generator
@@ -76,6 +110,14 @@ type DefaultExecute struct {
 	Output result.ResultsOutputer
 	// quiet is a flag to set the executor in quiet mode
 	quiet bool
+	// PromptResponder answers the prompts written by the command. When it is defined, the command stdin is only used to write the answers
+	PromptResponder PromptResponder
+	// Redactor holds the secrets masked on the outputs, the error messages and the command of the executions, along with the secrets of the command and the values of the environment variables that hold a secret. The secrets registered on the redact package are masked when it is not defined
+	Redactor *redact.Redactor
+	// result is the report of the last execution
+	result *ExecuteResult
+	// Stdin is the stdin connected to the command. The main process' stdin is used when it is not defined
+	Stdin io.Reader
 	// Transformers is the list of transformers func for the output
 	Transformers []transformer.TransformerFunc
 	// Writer is where is written the command stdout
@@ -161,11 +203,21 @@ func (e *DefaultExecute) Execute(ctx context.Context) (err error) {
 	var errCmd error
 	var cmdStderr, cmdStdout io.ReadCloser
 	var wg sync.WaitGroup
+	var stderrBuff, jsonBuff bytes.Buffer
 
 	errContext := "(execute::DefaultExecute::Execute)"
 
 	defer e.checkCompatibility()
 
+	e.result = &ExecuteResult{
+		ExitCode:  ExitCodeUnknown,
+		StartTime: time.Now(),
+	}
+	defer func() {
+		e.result.EndTime = time.Now()
+		e.result.Duration = e.result.EndTime.Sub(e.result.StartTime)
+	}()
+
 	execErrChan := make(chan error)
 
 	// default stdout and stderr for the main process
@@ -185,6 +237,10 @@ func (e *DefaultExecute) Execute(ctx context.Context) (err error) {
 		return errors.New(errContext, "Command is not defined")
 	}
 
+	redactor := e.executionRedactor()
+
+	e.result.Command = redactor.Redact(e.Cmd.String())
+
 	command, err := e.Cmd.Command()
 	if err != nil {
 		return errors.New(errContext, "Error creating command", err)
@@ -200,18 +256,36 @@ func (e *DefaultExecute) Execute(ctx context.Context) (err error) {
 	cmd := e.Exec.CommandContext(ctx, command[0], command[1:]...)
 
 	// Assert if cmd's type is the Golang's exec.Cmd as set the desired values for that case
-	_, isOsExecCmd := cmd.(*osexec.Cmd)
+	osExecCmd, isOsExecCmd := cmd.(*osexec.Cmd)
 	if isOsExecCmd {
 		if len(e.CmdRunDir) > 0 {
-			cmd.(*osexec.Cmd).Dir = e.CmdRunDir
+			osExecCmd.Dir = e.CmdRunDir
 		}
 
 		if len(e.EnvVars) > 0 {
-			cmd.(*osexec.Cmd).Env = append(os.Environ(), e.EnvVars.Environ()...)
+			osExecCmd.Env = append(os.Environ(), e.EnvVars.Environ()...)
 		}
 
-		// connects the main process' stdin to ansible's stdin
-		cmd.(*osexec.Cmd).Stdin = os.Stdin
+		// connects the main process' stdin, or the custom stdin, to ansible's stdin
+		if e.PromptResponder == nil {
+			osExecCmd.Stdin = e.stdin()
+		}
+	}
+
+	// any other Cmder receives the values through the optional setter interfaces
+	dirSetter, isDirSetter := cmd.(exec.DirSetter)
+	if isDirSetter && len(e.CmdRunDir) > 0 {
+		dirSetter.SetDir(e.CmdRunDir)
+	}
+
+	envSetter, isEnvSetter := cmd.(exec.EnvSetter)
+	if isEnvSetter && len(e.EnvVars) > 0 {
+		envSetter.SetEnv(e.EnvVars.Environ())
+	}
+
+	stdinSetter, isStdinSetter := cmd.(exec.StdinSetter)
+	if isStdinSetter && e.PromptResponder == nil {
+		stdinSetter.SetStdin(e.stdin())
 	}
 
 	trans := make([]transformer.TransformerFunc, 0)
@@ -233,6 +307,18 @@ func (e *DefaultExecute) Execute(ctx context.Context) (err error) {
 		return errors.New(errContext, "Error creating stderr pipe", err)
 	}
 
+	// the prompt responder watches the command output and answers the prompts through the command stdin
+	if e.PromptResponder != nil {
+		cmdStdin, err := cmd.StdinPipe()
+		if err != nil {
+			return errors.New(errContext, "Error creating stdin pipe", err)
+		}
+
+		stdoutWatcher, stderrWatcher := e.PromptResponder.Respond(cmdStdin)
+		cmdStdout = &teeReadCloser{Reader: io.TeeReader(cmdStdout, stdoutWatcher), Closer: cmdStdout}
+		cmdStderr = &teeReadCloser{Reader: io.TeeReader(cmdStderr, stderrWatcher), Closer: cmdStderr}
+	}
+
 	if e.Output == nil {
 
 		e.Output = defaultresults.NewDefaultResults(
@@ -240,8 +326,20 @@ func (e *DefaultExecute) Execute(ctx context.Context) (err error) {
 		)
 	}
 
+	// the outputers receive the redactor of the execution through the context, so the same outputer can be shared by several executions
+	outputCtx := redact.NewContext(ctx, redactor)
+
+	// stdout is kept to be parsed when the output is managed by JSONStdoutCallbackResults
+	stdoutWriter := e.Write
+	_, isJSONOutput := e.Output.(*jsonresults.JSONStdoutCallbackResults)
+	if isJSONOutput {
+		stdoutWriter = io.MultiWriter(e.Write, &jsonBuff)
+	}
+	stderrWriter := io.MultiWriter(e.WriterError, &stderrBuff)
+
 	err = cmd.Start()
 	if err != nil {
+		e.result.Error = err
 		return errors.New(errContext, "Error starting command", err)
 	}
 
@@ -254,7 +352,7 @@ func (e *DefaultExecute) Execute(ctx context.Context) (err error) {
 
 		// when using the default results func DefaultStdoutCallbackResults,
 		// reads from ansible's stdout and writes to main process' stdout
-		e.Output.Print(ctx, cmdStdout, e.Write)
+		e.Output.Print(outputCtx, cmdStdout, stdoutWriter)
 
 		wg.Done()
 		execErrChan <- err
@@ -263,7 +361,7 @@ func (e *DefaultExecute) Execute(ctx context.Context) (err error) {
 	// stderr management
 	go func() {
 		// show stderr messages using default stdout callback results
-		e.Output.Print(ctx, cmdStderr, e.WriterError)
+		e.Output.Print(outputCtx, cmdStderr, stderrWriter)
 		wg.Done()
 	}()
 
@@ -274,33 +372,111 @@ func (e *DefaultExecute) Execute(ctx context.Context) (err error) {
 	}
 
 	err = cmd.Wait()
+
+	// the prompt responder closes the command stdin when it fails to answer a prompt, so its error explains why the command failed
+	var errPrompt error
+	if e.PromptResponder != nil {
+		errPrompt = e.PromptResponder.Err()
+	}
+
+	e.result.Stderr = stderrBuff.String()
+	e.result.ExitCode = exitCode(err)
+	if isJSONOutput {
+		e.result.JSONResults, _ = jsonresults.ParseJSONResultsStream(&jsonBuff)
+	}
+
 	if err != nil {
 
 		if ctx.Err() != nil {
+			e.result.Error = ctx.Err()
 			fmt.Fprintf(e.Write, "%s\n", fmt.Sprintf("\nWhoops! %s\n", ctx.Err()))
 		} else {
 
-			if e.ErrorEnrich != nil {
+			resultsErrorEnrich, isResultsErrorEnrich := e.ErrorEnrich.(ResultsErrorEnricher)
+			if isResultsErrorEnrich && e.result.JSONResults != nil {
+				errCmd = resultsErrorEnrich.EnrichWithResults(err, e.result.JSONResults)
+			} else if e.ErrorEnrich != nil {
 				errCmd = e.ErrorEnrich.Enrich(err)
 			} else {
 				errCmd = err
 			}
 
+			if errPrompt != nil {
+				errCmd = goerrors.Join(errCmd, errPrompt)
+			}
+			e.result.Error = errCmd
+
 			errorMessage := fmt.Sprintf(" Command executed: %s\n", e.Cmd.String())
 			if len(e.EnvVars) > 0 {
-				errorMessage = fmt.Sprintf("%s\n Environment variables:\n%s\n", errorMessage, strings.Join(e.EnvVars.Environ(), "\n"))
+				errorMessage = fmt.Sprintf("%s\n Environment variables:\n%s\n", errorMessage, strings.Join(e.EnvVars.RedactedEnviron(redactor), "\n"))
 			}
 
-			stderrErrorMessage := string(err.(*osexec.ExitError).Stderr)
-			if len(stderrErrorMessage) > 0 {
-				errorMessage = fmt.Sprintf("%s\n'%s'\n", errorMessage, stderrErrorMessage)
+			osExitErr, isOsExitError := err.(*osexec.ExitError)
+			if isOsExitError && len(osExitErr.Stderr) > 0 {
+				errorMessage = fmt.Sprintf("%s\n'%s'\n", errorMessage, string(osExitErr.Stderr))
 			}
+			errorMessage = redactor.Redact(errorMessage)
 
-			return errors.New(errContext, fmt.Sprintf("Error during command execution.\n%s", errorMessage), errCmd)
+			return &ExecuteError{
+				err:   errors.New(errContext, fmt.Sprintf("Error during command execution.\n%s", errorMessage), errCmd),
+				cause: errCmd,
+			}
 		}
 	}
 
+	if errPrompt != nil {
+		e.result.Error = errPrompt
+		return errors.New(errContext, "Error answering the command prompts", errPrompt)
+	}
+
 	return nil
 }
 
 func (e *DefaultExecute) checkCompatibility() {}
+
+// executionRedactor returns a new redactor for the execution, which masks the secrets of the Redactor, or the ones registered on the redact package when it is not defined, the secrets of the command and the values of the environment variables that hold a secret. The secrets of the execution are not kept once it finishes
+func (e *DefaultExecute) executionRedactor() *redact.Redactor {
+	redactor := e.Redactor
+	if redactor == nil {
+		redactor = redact.DefaultRedactor()
+	}
+
+	executionRedactor := redact.NewRedactor(redactor.Secrets()...)
+	executionRedactor.AddEnvVars(e.EnvVars)
+
+	secretsProvider, isSecretsProvider := e.Cmd.(redact.SecretsProvider)
+	if isSecretsProvider {
+		executionRedactor.AddSecret(secretsProvider.Secrets()...)
+	}
+
+	return executionRedactor
+}
+
+// stdin returns the stdin connected to the command, which is the main process' stdin when no custom stdin is defined
+func (e *DefaultExecute) stdin() io.Reader {
+	if e.Stdin != nil {
+		return e.Stdin
+	}
+
+	return os.Stdin
+}
+
+// teeReadCloser is a ReadCloser that reads through a tee reader and closes the original reader
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// exitCode returns the exit code from the error returned by the command. It returns 0 when there is no error and ExitCodeUnknown when the error does not provide an exit code
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitCodeErr exec.ExitCodeErrorer
+	if !goerrors.As(err, &exitCodeErr) {
+		return ExitCodeUnknown
+	}
+
+	return exitCodeErr.ExitCode()
+}
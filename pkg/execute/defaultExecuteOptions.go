@@ -5,6 +5,7 @@ import (
 
 	"github.com/apenella/go-ansible/v2/pkg/execute/result"
 	"github.com/apenella/go-ansible/v2/pkg/execute/result/transformer"
+	"github.com/apenella/go-ansible/v2/pkg/redact"
 )
 
 // ExecuteOptions is a function to set executor options
@@ -74,3 +75,24 @@ func WithErrorEnrich(enricher ErrorEnricher) ExecuteOptions {
 		e.ErrorEnrich = enricher
 	}
 }
+
+// WithStdin sets the stdin connected to the command, instead of the main process' stdin
+func WithStdin(stdin io.Reader) ExecuteOptions {
+	return func(e *DefaultExecute) {
+		e.Stdin = stdin
+	}
+}
+
+// WithRedactor sets the redactor that holds the secrets masked on the executions, instead of the one of the redact package
+func WithRedactor(redactor *redact.Redactor) ExecuteOptions {
+	return func(e *DefaultExecute) {
+		e.Redactor = redactor
+	}
+}
+
+// WithPromptResponder sets the component that answers the prompts written by the command
+func WithPromptResponder(responder PromptResponder) ExecuteOptions {
+	return func(e *DefaultExecute) {
+		e.PromptResponder = responder
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/apenella/go-ansible/v2/mocks"
 	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	"github.com/apenella/go-ansible/v2/pkg/execute/prompt"
 	defaultresults "github.com/apenella/go-ansible/v2/pkg/execute/result/default"
 	"github.com/apenella/go-ansible/v2/pkg/execute/result/transformer"
 	"github.com/stretchr/testify/assert"
@@ -103,3 +104,25 @@ func TestOptionsWithOutput(t *testing.T) {
 
 	assert.Equal(t, execute.Output, output)
 }
+
+// TestOptionsWithStdin tests the function WithStdin
+func TestOptionsWithStdin(t *testing.T) {
+	stdin := os.Stdin
+
+	execute := NewDefaultExecute(
+		WithStdin(stdin),
+	)
+
+	assert.Equal(t, execute.Stdin, stdin)
+}
+
+// TestOptionsWithPromptResponder tests the function WithPromptResponder
+func TestOptionsWithPromptResponder(t *testing.T) {
+	responder := prompt.NewPromptResponder()
+
+	execute := NewDefaultExecute(
+		WithPromptResponder(responder),
+	)
+
+	assert.Equal(t, execute.PromptResponder, responder)
+}
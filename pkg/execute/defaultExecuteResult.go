@@ -0,0 +1,49 @@
+package execute
+
+import (
+	"context"
+	"time"
+
+	jsonresults "github.com/apenella/go-ansible/v2/pkg/execute/result/json"
+)
+
+const (
+	// ExitCodeUnknown is the exit code reported when the command did not finish or its exit code could not be obtained
+	ExitCodeUnknown = -1
+)
+
+// ExecuteResult is the report of a command execution done by DefaultExecute
+type ExecuteResult struct {
+	// Command is the command executed, as it is rendered by the Commander String method
+	Command string
+	// ExitCode is the exit code of the command. It is ExitCodeUnknown when the command did not finish
+	ExitCode int
+	// Error is the error returned by the command. When an ErrorEnricher is defined, it is the enriched error
+	Error error
+	// StartTime is the time when the execution started
+	StartTime time.Time
+	// EndTime is the time when the execution finished
+	EndTime time.Time
+	// Duration is the duration of the execution
+	Duration time.Duration
+	// Stderr is the output written by the command to stderr
+	Stderr string
+	// JSONResults is the parsed output of the command when the output is managed by JSONStdoutCallbackResults. It is nil otherwise or when the output could not be parsed
+	JSONResults *jsonresults.AnsiblePlaybookJSONResults
+}
+
+// Succeeded returns true when the command finished with a zero exit code
+func (r *ExecuteResult) Succeeded() bool {
+	return r.ExitCode == 0 && r.Error == nil
+}
+
+// Result returns the report of the last execution. It returns nil when the executor has not been executed yet
+func (e *DefaultExecute) Result() *ExecuteResult {
+	return e.result
+}
+
+// ExecuteWithResult runs the command, as Execute does, and returns the report of the execution along with the error
+func (e *DefaultExecute) ExecuteWithResult(ctx context.Context) (*ExecuteResult, error) {
+	err := e.Execute(ctx)
+	return e.result, err
+}
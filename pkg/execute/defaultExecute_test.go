@@ -3,14 +3,21 @@ package execute
 import (
 	"bytes"
 	"context"
+	goerrors "errors"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/apenella/go-ansible/v2/mocks"
 	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	"github.com/apenella/go-ansible/v2/pkg/execute/prompt"
 	defaultresults "github.com/apenella/go-ansible/v2/pkg/execute/result/default"
+	jsonresults "github.com/apenella/go-ansible/v2/pkg/execute/result/json"
 	"github.com/apenella/go-ansible/v2/pkg/execute/result/transformer"
+	"github.com/apenella/go-ansible/v2/pkg/redact"
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/stretchr/testify/assert"
 )
@@ -152,6 +159,388 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+func TestExecuteWithResult(t *testing.T) {
+
+	jsonOutput := `{"plays":[],"stats":{"127.0.0.1":{"changed":0,"failures":0,"ignored":0,"ok":1,"rescued":0,"skipped":0,"unreachable":0}}}`
+
+	tests := []struct {
+		desc       string
+		execute    *DefaultExecute
+		stdout     string
+		stderr     string
+		err        error
+		assertFunc func(t *testing.T, res *ExecuteResult)
+	}{
+		{
+			desc: "Testing execute a command and return the execution result",
+			execute: NewDefaultExecute(
+				WithExecutable(exec.NewMockExec()),
+				WithWrite(io.Discard),
+				WithWriteError(io.Discard),
+				WithCmd(
+					mocks.NewMockAnsibleCmd([]string{"ansible-playbook", "site.yml"}, nil),
+				),
+			),
+			stdout: "output",
+			stderr: "warning",
+			assertFunc: func(t *testing.T, res *ExecuteResult) {
+				assert.Equal(t, 0, res.ExitCode)
+				assert.Nil(t, res.Error)
+				assert.True(t, res.Succeeded())
+				assert.Equal(t, "warning\n", res.Stderr)
+				assert.Nil(t, res.JSONResults)
+				assert.False(t, res.StartTime.IsZero())
+				assert.False(t, res.EndTime.Before(res.StartTime))
+				assert.Equal(t, res.EndTime.Sub(res.StartTime), res.Duration)
+			},
+		},
+		{
+			desc: "Testing execute a command using JSONStdoutCallbackResults and return the parsed results",
+			execute: NewDefaultExecute(
+				WithExecutable(exec.NewMockExec()),
+				WithWrite(io.Discard),
+				WithWriteError(io.Discard),
+				WithOutput(jsonresults.NewJSONStdoutCallbackResults()),
+				WithCmd(
+					mocks.NewMockAnsibleCmd([]string{"ansible-playbook", "site.yml"}, nil),
+				),
+			),
+			stdout: jsonOutput,
+			assertFunc: func(t *testing.T, res *ExecuteResult) {
+				assert.Equal(t, 0, res.ExitCode)
+				assert.NotNil(t, res.JSONResults)
+				assert.Equal(t, 1, res.JSONResults.Stats["127.0.0.1"].Ok)
+			},
+		},
+		{
+			desc:    "Testing execute a command when command is not defined",
+			execute: NewDefaultExecute(),
+			err:     errors.New("(execute::DefaultExecute::Execute)", "Command is not defined"),
+			assertFunc: func(t *testing.T, res *ExecuteResult) {
+				assert.Equal(t, ExitCodeUnknown, res.ExitCode)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.execute.Exec != nil {
+				cmd := exec.NewMockCmd()
+				cmd.On("StdoutPipe").Return(io.NopCloser(bytes.NewBufferString(test.stdout)), nil)
+				cmd.On("StderrPipe").Return(io.NopCloser(bytes.NewBufferString(test.stderr)), nil)
+				cmd.On("Start").Return(nil)
+				cmd.On("Wait").Return(nil)
+
+				test.execute.Exec.(*exec.MockExec).On("CommandContext", context.TODO(), "ansible-playbook", []string{"site.yml"}).Return(cmd)
+			}
+
+			res, err := test.execute.ExecuteWithResult(context.TODO())
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Nil(t, err)
+			}
+
+			assert.Equal(t, res, test.execute.Result())
+			test.assertFunc(t, res)
+		})
+	}
+}
+
+func TestExecuteWithContainerExec(t *testing.T) {
+
+	// fake container command-line tool that prints its arguments and the custom environment variable
+	shim := filepath.Join(t.TempDir(), "docker")
+	err := os.WriteFile(shim, []byte("#!/bin/sh\necho \"$@\"\necho \"ANSIBLE_FORCE_COLOR=$ANSIBLE_FORCE_COLOR\"\nexit ${SHIM_EXIT_CODE:-0}\n"), 0755)
+	if err != nil {
+		t.Fatalf("error creating container runtime shim: %s", err)
+	}
+
+	t.Run("Testing execute a command inside a container", func(t *testing.T) {
+		var stdout bytes.Buffer
+		runDir := t.TempDir()
+
+		e := NewDefaultExecute(
+			WithCmd(mocks.NewMockAnsibleCmd([]string{"ansible-playbook", "site.yml"}, nil)),
+			WithExecutable(exec.NewContainerExec("image", exec.WithRuntime(shim))),
+			WithCmdRunDir(runDir),
+			WithEnvVars(map[string]string{"ANSIBLE_FORCE_COLOR": "true"}),
+			WithWrite(&stdout),
+			WithWriteError(io.Discard),
+		)
+
+		err := e.Execute(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("run --rm --interactive --volume %[1]s:%[1]s --workdir %[1]s --env ANSIBLE_FORCE_COLOR image ansible-playbook site.yml\nANSIBLE_FORCE_COLOR=true\n", runDir), stdout.String())
+	})
+
+	t.Run("Testing execute a failing command inside a container", func(t *testing.T) {
+		t.Setenv("SHIM_EXIT_CODE", "2")
+
+		e := NewDefaultExecute(
+			WithCmd(mocks.NewMockAnsibleCmd([]string{"ansible-playbook", "site.yml"}, nil)),
+			WithExecutable(exec.NewContainerExec("image", exec.WithRuntime(shim))),
+			WithErrorEnrich(NewExitCodeErrorEnrich("ansible-playbook")),
+			WithWrite(io.Discard),
+			WithWriteError(io.Discard),
+		)
+
+		res, err := e.ExecuteWithResult(context.TODO())
+		assert.True(t, goerrors.Is(err, ErrHostFailed))
+		assert.Equal(t, 2, res.ExitCode)
+	})
+}
+
+// setterMockCmd is a custom Cmder that implements the optional setter interfaces
+type setterMockCmd struct {
+	*exec.MockCmd
+	dir   string
+	env   []string
+	stdin io.Reader
+}
+
+func (c *setterMockCmd) SetDir(dir string)        { c.dir = dir }
+func (c *setterMockCmd) SetEnv(env []string)      { c.env = env }
+func (c *setterMockCmd) SetStdin(stdin io.Reader) { c.stdin = stdin }
+
+func TestExecuteWithCustomCmder(t *testing.T) {
+
+	tests := []struct {
+		desc       string
+		waitErr    error
+		assertFunc func(t *testing.T, cmd *setterMockCmd, res *ExecuteResult, err error)
+	}{
+		{
+			desc: "Testing execute a custom Cmder that receives the working directory, the environment variables and the stdin",
+			assertFunc: func(t *testing.T, cmd *setterMockCmd, res *ExecuteResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "/project", cmd.dir)
+				assert.Equal(t, []string{"ANSIBLE_FORCE_COLOR=true"}, cmd.env)
+				assert.Equal(t, os.Stdin, cmd.stdin)
+			},
+		},
+		{
+			desc:    "Testing execute a custom Cmder that fails with an error that provides the exit code",
+			waitErr: &mocks.MockExitCodeErr{Code: AnsiblePlaybookErrorCodeOneOrMoreHostUnreachable, Message: "unreachable"},
+			assertFunc: func(t *testing.T, cmd *setterMockCmd, res *ExecuteResult, err error) {
+				assert.True(t, goerrors.Is(err, ErrHostUnreachable))
+				assert.Equal(t, AnsiblePlaybookErrorCodeOneOrMoreHostUnreachable, res.ExitCode)
+			},
+		},
+		{
+			desc:    "Testing execute a custom Cmder that fails with an error that does not provide the exit code",
+			waitErr: fmt.Errorf("connection lost"),
+			assertFunc: func(t *testing.T, cmd *setterMockCmd, res *ExecuteResult, err error) {
+				assert.ErrorContains(t, err, "connection lost")
+				assert.Equal(t, ExitCodeUnknown, res.ExitCode)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			cmd := &setterMockCmd{MockCmd: exec.NewMockCmd()}
+			cmd.On("StdoutPipe").Return(io.NopCloser(bytes.NewBufferString("")), nil)
+			cmd.On("StderrPipe").Return(io.NopCloser(bytes.NewBufferString("")), nil)
+			cmd.On("Start").Return(nil)
+			cmd.On("Wait").Return(test.waitErr)
+
+			executable := exec.NewMockExec()
+			executable.On("CommandContext", context.TODO(), "ansible-playbook", []string{"site.yml"}).Return(cmd)
+
+			e := NewDefaultExecute(
+				WithCmd(mocks.NewMockAnsibleCmd([]string{"ansible-playbook", "site.yml"}, nil)),
+				WithExecutable(executable),
+				WithCmdRunDir("/project"),
+				WithEnvVars(map[string]string{"ANSIBLE_FORCE_COLOR": "true"}),
+				WithErrorEnrich(NewExitCodeErrorEnrich("ansible-playbook")),
+				WithWrite(io.Discard),
+				WithWriteError(io.Discard),
+			)
+
+			res, err := e.ExecuteWithResult(context.TODO())
+			test.assertFunc(t, cmd, res, err)
+		})
+	}
+}
+
+func TestExecuteRedactSecrets(t *testing.T) {
+
+	t.Run("Testing execute a command that fails masking the secrets in the output and the error message", func(t *testing.T) {
+		var stdout bytes.Buffer
+
+		cmd := &setterMockCmd{MockCmd: exec.NewMockCmd()}
+		cmd.On("StdoutPipe").Return(io.NopCloser(bytes.NewBufferString("become password is b3c0m3-p4ss\n")), nil)
+		cmd.On("StderrPipe").Return(io.NopCloser(bytes.NewBufferString("")), nil)
+		cmd.On("Start").Return(nil)
+		cmd.On("Wait").Return(fmt.Errorf("connection lost"))
+
+		executable := exec.NewMockExec()
+		executable.On("CommandContext", context.TODO(), "ansible-playbook", []string{"site.yml"}).Return(cmd)
+
+		e := NewDefaultExecute(
+			WithCmd(mocks.NewMockAnsibleCmd([]string{"ansible-playbook", "site.yml"}, nil)),
+			WithExecutable(executable),
+			WithEnvVars(map[string]string{
+				"ANSIBLE_BECOME_PASS": "b3c0m3-p4ss",
+				"ANSIBLE_FORCE_COLOR": "true",
+			}),
+			WithWrite(&stdout),
+			WithWriteError(io.Discard),
+		)
+
+		err := e.Execute(context.TODO())
+
+		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), "b3c0m3-p4ss")
+		assert.Contains(t, err.Error(), "ANSIBLE_BECOME_PASS="+redact.RedactedMask)
+		assert.Contains(t, err.Error(), "ANSIBLE_FORCE_COLOR=true")
+		assert.Contains(t, stdout.String(), "become password is "+redact.RedactedMask)
+		assert.NotContains(t, stdout.String(), "b3c0m3-p4ss")
+		// the secrets of the execution are not registered on the redact package
+		assert.NotContains(t, redact.Secrets(), "b3c0m3-p4ss")
+	})
+
+	t.Run("Testing execute a command masking the secrets of the redactor and the command", func(t *testing.T) {
+		var stdout bytes.Buffer
+
+		redactor := redact.NewRedactor("t0k3n")
+
+		e := NewDefaultExecute(
+			WithCmd(&secretsMockCmd{
+				MockAnsibleCmd: mocks.NewMockAnsibleCmd([]string{"echo", "token t0k3n and password s3cr3t"}, nil),
+				secrets:        []string{"s3cr3t"},
+			}),
+			WithRedactor(redactor),
+			WithWrite(&stdout),
+			WithWriteError(io.Discard),
+		)
+
+		err := e.Execute(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("token %s and password %s\n", redact.RedactedMask, redact.RedactedMask), stdout.String())
+		// the secrets of the command are not registered on the redactor
+		assert.Equal(t, []string{"t0k3n"}, redactor.Secrets())
+	})
+
+	t.Run("Testing execute a command masking the secrets of the execution on the command of the result", func(t *testing.T) {
+		e := NewDefaultExecute(
+			WithCmd(&stringMockCmd{
+				MockAnsibleCmd: mocks.NewMockAnsibleCmd([]string{"echo"}, nil),
+				str:            "echo --token t0k3n --password b3c0m3-p4ss",
+			}),
+			WithRedactor(redact.NewRedactor("t0k3n")),
+			WithEnvVars(map[string]string{
+				"ANSIBLE_BECOME_PASS": "b3c0m3-p4ss",
+			}),
+			WithWrite(io.Discard),
+			WithWriteError(io.Discard),
+		)
+
+		res, err := e.ExecuteWithResult(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("echo --token %s --password %s", redact.RedactedMask, redact.RedactedMask), res.Command)
+	})
+}
+
+// secretsMockCmd is a Commander that provides the secrets it holds
+type secretsMockCmd struct {
+	*mocks.MockAnsibleCmd
+	secrets []string
+}
+
+func (c *secretsMockCmd) Secrets() []string { return c.secrets }
+
+// stringMockCmd is a Commander that returns the given command string, which holds no secrets of its own
+type stringMockCmd struct {
+	*mocks.MockAnsibleCmd
+	str string
+}
+
+func (c *stringMockCmd) String() string { return c.str }
+
+func TestExecuteWithStdin(t *testing.T) {
+
+	t.Run("Testing execute a command with a custom stdin", func(t *testing.T) {
+		var stdout bytes.Buffer
+
+		e := NewDefaultExecute(
+			WithCmd(mocks.NewMockAnsibleCmd([]string{"sh", "-c", "read answer; echo \"answer: $answer\""}, nil)),
+			WithStdin(strings.NewReader("yes\n")),
+			WithWrite(&stdout),
+			WithWriteError(io.Discard),
+		)
+
+		err := e.Execute(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, "answer: yes\n", stdout.String())
+	})
+
+	t.Run("Testing execute a command answering its prompts", func(t *testing.T) {
+		var stdout bytes.Buffer
+
+		e := NewDefaultExecute(
+			WithCmd(mocks.NewMockAnsibleCmd([]string{"sh", "-c", "printf 'BECOME password: '; read become; printf 'Vault password: ' >&2; read vault; echo \"\nbecome: $become, vault: $vault\""}, nil)),
+			WithPromptResponder(prompt.NewPromptResponder(
+				prompt.WithBecomePassword("become-secret"),
+				prompt.WithVaultPassword("vault-secret"),
+			)),
+			WithStdin(strings.NewReader("ignored\n")),
+			WithWrite(&stdout),
+			WithWriteError(io.Discard),
+		)
+
+		err := e.Execute(context.TODO())
+		assert.NoError(t, err)
+		assert.Contains(t, stdout.String(), "become: become-secret, vault: vault-secret\n")
+	})
+
+	t.Run("Testing execute a command that fails when its prompt can not be answered", func(t *testing.T) {
+		errAnswer := goerrors.New("password not available")
+
+		e := NewDefaultExecute(
+			WithCmd(mocks.NewMockAnsibleCmd([]string{"sh", "-c", "printf 'Password: '; read password || exit 3"}, nil)),
+			WithPromptResponder(prompt.NewPromptResponder(
+				prompt.WithPromptFunc(`^Password: $`, func(string) (string, error) {
+					return "", errAnswer
+				}),
+			)),
+			WithWrite(io.Discard),
+			WithWriteError(io.Discard),
+		)
+
+		err := e.Execute(context.TODO())
+		assert.Error(t, err)
+		assert.True(t, goerrors.Is(err, errAnswer))
+		assert.Contains(t, err.Error(), "error answering prompt 'Password: '")
+		assert.Equal(t, 3, e.Result().ExitCode)
+	})
+
+	t.Run("Testing execute a command that succeeds although its prompt can not be answered", func(t *testing.T) {
+		errAnswer := goerrors.New("password not available")
+
+		e := NewDefaultExecute(
+			WithCmd(mocks.NewMockAnsibleCmd([]string{"sh", "-c", "printf 'Password: '; read password; echo done"}, nil)),
+			WithPromptResponder(prompt.NewPromptResponder(
+				prompt.WithPromptFunc(`^Password: $`, func(string) (string, error) {
+					return "", errAnswer
+				}),
+			)),
+			WithWrite(io.Discard),
+			WithWriteError(io.Discard),
+		)
+
+		err := e.Execute(context.TODO())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Error answering the command prompts")
+		assert.Contains(t, err.Error(), "password not available")
+	})
+}
+
 // func TestExecuteFunctional(t *testing.T) {
 
 // 	var stdout, stderr bytes.Buffer
@@ -314,6 +703,35 @@ func TestEnviron(t *testing.T) {
 	}
 }
 
+func TestRedactedEnviron(t *testing.T) {
+	tests := []struct {
+		desc           string
+		envvars        EnvVars
+		expectedResult []string
+	}{
+		{
+			desc: "Testing masking the value of an environment variable that holds a secret",
+			envvars: EnvVars{
+				"ANSIBLE_BECOME_PASS": "b3c0m3-p4ss",
+			},
+			expectedResult: []string{"ANSIBLE_BECOME_PASS=" + redact.RedactedMask},
+		},
+		{
+			desc: "Testing keeping the value of an environment variable that does not hold a secret",
+			envvars: EnvVars{
+				"ANSIBLE_ASK_PASS": "true",
+			},
+			expectedResult: []string{"ANSIBLE_ASK_PASS=true"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expectedResult, test.envvars.RedactedEnviron(nil))
+		})
+	}
+}
+
 func TestAddEnvVar(t *testing.T) {
 	tests := []struct {
 		desc     string
@@ -2,7 +2,10 @@ package exec
 
 import (
 	"io"
+	"os"
 	"os/exec"
+
+	"github.com/apenella/go-ansible/v2/pkg/redact"
 )
 
 // Cmd struct is a wrapper of exec.Cmd
@@ -40,6 +43,21 @@ func (c *Cmd) Start() error {
 	return c.cmd.Start()
 }
 
+// SetDir sets the exec.Cmd Dir attribute
+func (c *Cmd) SetDir(dir string) {
+	c.cmd.Dir = dir
+}
+
+// SetEnv sets the exec.Cmd Env attribute, appending the environment variables to the main process' environment
+func (c *Cmd) SetEnv(env []string) {
+	c.cmd.Env = append(os.Environ(), env...)
+}
+
+// SetStdin sets the exec.Cmd Stdin attribute
+func (c *Cmd) SetStdin(stdin io.Reader) {
+	c.cmd.Stdin = stdin
+}
+
 // StderrPipe is a wrapper of exec.Cmd StderrPipe method
 func (c *Cmd) StderrPipe() (io.ReadCloser, error) {
 	return c.cmd.StderrPipe()
@@ -55,9 +73,9 @@ func (c *Cmd) StdoutPipe() (io.ReadCloser, error) {
 	return c.cmd.StdoutPipe()
 }
 
-// String is a wrapper of exec.Cmd String method
+// String is a wrapper of exec.Cmd String method. The secrets registered on the redact package are masked
 func (c *Cmd) String() string {
-	return c.cmd.String()
+	return redact.Redact(c.cmd.String())
 }
 
 // Wait is a wrapper of exec.Cmd Wait method
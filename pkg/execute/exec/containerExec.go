@@ -0,0 +1,248 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/apenella/go-ansible/v2/pkg/redact"
+)
+
+const (
+	// DockerContainerRuntime is the docker command-line tool. It is the default container runtime
+	DockerContainerRuntime = "docker"
+	// PodmanContainerRuntime is the podman command-line tool
+	PodmanContainerRuntime = "podman"
+
+	// DefaultContainerStopTimeout is the time to wait for the container to finish once it has been interrupted, before killing the container runtime process
+	DefaultContainerStopTimeout = 10 * time.Second
+)
+
+// ContainerExecOptionsFunc is a function to set the ContainerExec options
+type ContainerExecOptionsFunc func(*ContainerExec)
+
+// ContainerExec is an executabler that runs the commands inside a container, using the docker or podman command-line tool
+type ContainerExec struct {
+	// Image is the container image where the commands run
+	Image string
+	// Runtime is the container command-line tool, such as docker or podman. It could be a path to the binary
+	Runtime string
+	// RunArgs are extra arguments for the container run command, such as --network=host
+	RunArgs []string
+	// Volumes are extra volumes mounted into the container, in the form "host-path:container-path[:options]"
+	Volumes []string
+	// StopTimeout is the time to wait for the container to finish once the context is cancelled, before killing the container runtime process
+	StopTimeout time.Duration
+}
+
+// NewContainerExec creates a new ContainerExec to run the commands inside the provided image
+func NewContainerExec(image string, options ...ContainerExecOptionsFunc) *ContainerExec {
+	e := &ContainerExec{
+		Image:       image,
+		Runtime:     DockerContainerRuntime,
+		StopTimeout: DefaultContainerStopTimeout,
+	}
+
+	for _, option := range options {
+		option(e)
+	}
+
+	return e
+}
+
+// WithRuntime sets the container command-line tool, such as docker or podman
+func WithRuntime(runtime string) ContainerExecOptionsFunc {
+	return func(e *ContainerExec) {
+		e.Runtime = runtime
+	}
+}
+
+// WithRunArgs appends extra arguments to the container run command
+func WithRunArgs(args ...string) ContainerExecOptionsFunc {
+	return func(e *ContainerExec) {
+		e.RunArgs = append(e.RunArgs, args...)
+	}
+}
+
+// WithVolumes appends extra volumes to mount into the container, in the form "host-path:container-path[:options]"
+func WithVolumes(volumes ...string) ContainerExecOptionsFunc {
+	return func(e *ContainerExec) {
+		e.Volumes = append(e.Volumes, volumes...)
+	}
+}
+
+// WithStopTimeout sets the time to wait for the container to finish once the context is cancelled
+func WithStopTimeout(timeout time.Duration) ContainerExecOptionsFunc {
+	return func(e *ContainerExec) {
+		e.StopTimeout = timeout
+	}
+}
+
+// Command returns a ContainerCmd that runs the command inside the container
+func (e *ContainerExec) Command(name string, arg ...string) Cmder {
+	return e.CommandContext(context.Background(), name, arg...)
+}
+
+// CommandContext returns a ContainerCmd that runs the command inside the container. When the context is done, the container runtime process is interrupted, which stops the container, and it is killed after the StopTimeout
+func (e *ContainerExec) CommandContext(ctx context.Context, name string, arg ...string) Cmder {
+	cmd := exec.CommandContext(ctx, e.Runtime)
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = e.StopTimeout
+
+	return &ContainerCmd{
+		cmd:       cmd,
+		container: e,
+		name:      name,
+		args:      arg,
+	}
+}
+
+// ContainerCmd is a command that runs inside a container. Its working directory is mounted into the container at the same path, and it is used as the container working directory
+type ContainerCmd struct {
+	// Dir is the host working directory of the command. The current directory is used when it is empty
+	Dir string
+	// Env are the environment variables, in the form "key=value", set into the container
+	Env []string
+	// Stdin is the standard input of the command. The container is run in interactive mode when it is defined
+	Stdin io.Reader
+
+	cmd       *exec.Cmd
+	container *ContainerExec
+	name      string
+	args      []string
+}
+
+// prepare sets the container runtime arguments, environment and standard input from the command attributes
+func (c *ContainerCmd) prepare() error {
+	dir := c.Dir
+	if dir == "" {
+		var err error
+		dir, err = os.Getwd()
+		if err != nil {
+			return fmt.Errorf("container working directory could not be defined: %w", err)
+		}
+	}
+
+	if c.Stdin != nil {
+		c.cmd.Stdin = c.Stdin
+	}
+
+	args := []string{c.container.Runtime, "run", "--rm"}
+	// the stdin is also defined when StdinPipe is called
+	if c.cmd.Stdin != nil {
+		args = append(args, "--interactive")
+	}
+
+	args = append(args, "--volume", fmt.Sprintf("%s:%s", dir, dir), "--workdir", dir)
+	for _, volume := range c.container.Volumes {
+		args = append(args, "--volume", volume)
+	}
+
+	// only the variable names are passed as arguments, the values are taken from the runtime process environment to keep them out of the command line
+	for _, env := range c.Env {
+		name, _, _ := strings.Cut(env, "=")
+		args = append(args, "--env", name)
+	}
+
+	args = append(args, c.container.RunArgs...)
+	args = append(args, c.container.Image, c.name)
+	args = append(args, c.args...)
+
+	c.cmd.Args = args
+	c.cmd.Dir = dir
+	c.cmd.Env = nil
+	if len(c.Env) > 0 {
+		c.cmd.Env = append(os.Environ(), c.Env...)
+	}
+
+	return nil
+}
+
+// CombinedOutput runs the command inside the container and returns its combined stdout and stderr
+func (c *ContainerCmd) CombinedOutput() ([]byte, error) {
+	err := c.prepare()
+	if err != nil {
+		return nil, err
+	}
+	return c.cmd.CombinedOutput()
+}
+
+// Environ returns the environment of the container runtime process
+func (c *ContainerCmd) Environ() []string {
+	_ = c.prepare()
+	return c.cmd.Environ()
+}
+
+// Output runs the command inside the container and returns its stdout
+func (c *ContainerCmd) Output() ([]byte, error) {
+	err := c.prepare()
+	if err != nil {
+		return nil, err
+	}
+	return c.cmd.Output()
+}
+
+// Run runs the command inside the container and waits for it to finish
+func (c *ContainerCmd) Run() error {
+	err := c.prepare()
+	if err != nil {
+		return err
+	}
+	return c.cmd.Run()
+}
+
+// Start starts the command inside the container
+func (c *ContainerCmd) Start() error {
+	err := c.prepare()
+	if err != nil {
+		return err
+	}
+	return c.cmd.Start()
+}
+
+// SetDir sets the host working directory of the command, which is mounted into the container
+func (c *ContainerCmd) SetDir(dir string) {
+	c.Dir = dir
+}
+
+// SetEnv sets the environment variables set into the container. The environment of the main process is not set into the container
+func (c *ContainerCmd) SetEnv(env []string) {
+	c.Env = env
+}
+
+// SetStdin sets the standard input of the command
+func (c *ContainerCmd) SetStdin(stdin io.Reader) {
+	c.Stdin = stdin
+}
+
+// StderrPipe returns a pipe connected to the command stderr
+func (c *ContainerCmd) StderrPipe() (io.ReadCloser, error) {
+	return c.cmd.StderrPipe()
+}
+
+// StdinPipe returns a pipe connected to the command stdin
+func (c *ContainerCmd) StdinPipe() (io.WriteCloser, error) {
+	return c.cmd.StdinPipe()
+}
+
+// StdoutPipe returns a pipe connected to the command stdout
+func (c *ContainerCmd) StdoutPipe() (io.ReadCloser, error) {
+	return c.cmd.StdoutPipe()
+}
+
+// String returns the container runtime command line. The secrets registered on the redact package are masked
+func (c *ContainerCmd) String() string {
+	_ = c.prepare()
+	return redact.Redact(c.cmd.String())
+}
+
+// Wait waits for the command to finish. The error is an *exec.ExitError with the exit code of the command when it fails inside the container
+func (c *ContainerCmd) Wait() error {
+	return c.cmd.Wait()
+}
@@ -0,0 +1,174 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// containerRuntimeShim is a fake container command-line tool that prints its arguments, the value of ANSIBLE_FORCE_COLOR and the stdin, and exits with the code set in SHIM_EXIT_CODE
+const containerRuntimeShim = `#!/bin/sh
+for arg in "$@"; do
+	echo "arg: $arg"
+done
+echo "env: ANSIBLE_FORCE_COLOR=$ANSIBLE_FORCE_COLOR"
+if [ "$SHIM_STDIN" = "true" ]; then
+	echo "stdin: $(cat)"
+fi
+if [ "$SHIM_SLEEP" = "true" ]; then
+	trap 'kill $!; echo interrupted; exit 130' INT
+	sleep 5 &
+	wait
+fi
+exit ${SHIM_EXIT_CODE:-0}
+`
+
+func newContainerRuntimeShim(t *testing.T) string {
+	t.Helper()
+
+	shim := filepath.Join(t.TempDir(), "docker")
+	err := os.WriteFile(shim, []byte(containerRuntimeShim), 0755)
+	if err != nil {
+		t.Fatalf("error creating container runtime shim: %s", err)
+	}
+
+	return shim
+}
+
+func TestNewContainerExec(t *testing.T) {
+	expected := &ContainerExec{
+		Image:       "quay.io/ansible/creator-ee",
+		Runtime:     PodmanContainerRuntime,
+		RunArgs:     []string{"--network=host"},
+		Volumes:     []string{"/etc/ansible:/etc/ansible:ro"},
+		StopTimeout: time.Second,
+	}
+
+	e := NewContainerExec("quay.io/ansible/creator-ee",
+		WithRuntime(PodmanContainerRuntime),
+		WithRunArgs("--network=host"),
+		WithVolumes("/etc/ansible:/etc/ansible:ro"),
+		WithStopTimeout(time.Second),
+	)
+
+	assert.Equal(t, expected, e)
+	assert.Equal(t, DockerContainerRuntime, NewContainerExec("image").Runtime)
+}
+
+func TestContainerCmdString(t *testing.T) {
+	tests := []struct {
+		desc     string
+		exec     *ContainerExec
+		cmd      func(cmd *ContainerCmd)
+		expected string
+	}{
+		{
+			desc: "Testing container command with the working directory",
+			exec: NewContainerExec("image"),
+			cmd: func(cmd *ContainerCmd) {
+				cmd.Dir = "/project"
+			},
+			expected: "run --rm --volume /project:/project --workdir /project image ansible-playbook site.yml",
+		},
+		{
+			desc: "Testing container command with environment variables, stdin, volumes and run arguments",
+			exec: NewContainerExec("image", WithVolumes("/keys:/keys:ro"), WithRunArgs("--network=host")),
+			cmd: func(cmd *ContainerCmd) {
+				cmd.Dir = "/project"
+				cmd.Env = []string{"ANSIBLE_FORCE_COLOR=true"}
+				cmd.Stdin = strings.NewReader("")
+			},
+			expected: "run --rm --interactive --volume /project:/project --workdir /project --volume /keys:/keys:ro --env ANSIBLE_FORCE_COLOR --network=host image ansible-playbook site.yml",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			cmd := test.exec.Command("ansible-playbook", "site.yml").(*ContainerCmd)
+			test.cmd(cmd)
+
+			// the runtime path depends on the host, so only the arguments are compared
+			_, args, _ := strings.Cut(cmd.String(), " ")
+			assert.Equal(t, test.expected, args)
+		})
+	}
+}
+
+func TestContainerCmdRun(t *testing.T) {
+	shim := newContainerRuntimeShim(t)
+	dir := t.TempDir()
+
+	t.Run("Testing run a command inside the container", func(t *testing.T) {
+		var stdout bytes.Buffer
+
+		t.Setenv("SHIM_STDIN", "true")
+
+		cmd := NewContainerExec("image", WithRuntime(shim)).
+			CommandContext(context.TODO(), "ansible-playbook", "site.yml").(*ContainerCmd)
+		cmd.Dir = dir
+		cmd.Env = []string{"ANSIBLE_FORCE_COLOR=true"}
+		cmd.Stdin = strings.NewReader("password")
+
+		pipe, err := cmd.StdoutPipe()
+		assert.NoError(t, err)
+
+		err = cmd.Start()
+		assert.NoError(t, err)
+		_, _ = stdout.ReadFrom(pipe)
+		err = cmd.Wait()
+		assert.NoError(t, err)
+
+		assert.Equal(t, strings.Join([]string{
+			"arg: run",
+			"arg: --rm",
+			"arg: --interactive",
+			"arg: --volume",
+			"arg: " + dir + ":" + dir,
+			"arg: --workdir",
+			"arg: " + dir,
+			"arg: --env",
+			"arg: ANSIBLE_FORCE_COLOR",
+			"arg: image",
+			"arg: ansible-playbook",
+			"arg: site.yml",
+			"env: ANSIBLE_FORCE_COLOR=true",
+			"stdin: password",
+			"",
+		}, "\n"), stdout.String())
+	})
+
+	t.Run("Testing run a failing command inside the container", func(t *testing.T) {
+		t.Setenv("SHIM_EXIT_CODE", "4")
+
+		cmd := NewContainerExec("image", WithRuntime(shim)).Command("ansible-playbook", "site.yml")
+		err := cmd.Run()
+
+		var exitErr *exec.ExitError
+		assert.True(t, errors.As(err, &exitErr))
+		assert.Equal(t, 4, exitErr.ExitCode())
+	})
+
+	t.Run("Testing interrupt the container when the context is cancelled", func(t *testing.T) {
+		t.Setenv("SHIM_SLEEP", "true")
+
+		ctx, cancel := context.WithTimeout(context.TODO(), 200*time.Millisecond)
+		defer cancel()
+
+		output, err := NewContainerExec("image", WithRuntime(shim)).
+			CommandContext(ctx, "ansible-playbook", "site.yml").
+			Output()
+
+		var exitErr *exec.ExitError
+		assert.True(t, errors.As(err, &exitErr))
+		assert.Equal(t, 130, exitErr.ExitCode())
+		assert.Contains(t, string(output), "interrupted")
+	})
+}
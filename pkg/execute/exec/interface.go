@@ -15,3 +15,23 @@ type Cmder interface {
 	String() string
 	Wait() error
 }
+
+// DirSetter is an optional interface for the Cmder that accept a working directory. DefaultExecute uses it to set the CmdRunDir
+type DirSetter interface {
+	SetDir(dir string)
+}
+
+// EnvSetter is an optional interface for the Cmder that accept environment variables. DefaultExecute uses it to set the EnvVars, in the form "key=value". Whether the environment of the main process is also set depends on the implementation
+type EnvSetter interface {
+	SetEnv(env []string)
+}
+
+// StdinSetter is an optional interface for the Cmder that accept a standard input. DefaultExecute uses it to connect the main process' stdin to the command
+type StdinSetter interface {
+	SetStdin(stdin io.Reader)
+}
+
+// ExitCodeErrorer is the interface for the errors that provide the exit code of a finished command, such as *os/exec.ExitError. DefaultExecute uses it to get the exit code of any Cmder
+type ExitCodeErrorer interface {
+	ExitCode() int
+}
@@ -19,11 +19,11 @@ func NewMockExec() *MockExec {
 // Command is a wrapper of exec.Command
 func (e *MockExec) Command(name string, arg ...string) Cmder {
 	ret := e.Mock.Called(name, append([]string{}, arg...))
-	return ret.Get(0).(*MockCmd)
+	return ret.Get(0).(Cmder)
 }
 
 // CommandContext is a wrapper of exec.CommandContext
 func (e *MockExec) CommandContext(ctx context.Context, name string, arg ...string) Cmder {
 	ret := e.Mock.Called(ctx, name, append([]string{}, arg...))
-	return ret.Get(0).(*MockCmd)
+	return ret.Get(0).(Cmder)
 }
@@ -0,0 +1,462 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apenella/go-ansible/v2/pkg/redact"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// DefaultSSHStopTimeout is the time to wait for the remote command to finish once it has been signaled, before closing the session
+	DefaultSSHStopTimeout = 10 * time.Second
+)
+
+// envNameRegexp is the pattern of the environment variable names accepted by the remote command
+var envNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// envLoader is the shell program that exports the environment variables read from stdin, one per line until an empty line, and then runs the command received as arguments. The values are quoted and the newlines are replaced by the nl variable, so each variable fits in a single line. The read builtin does not read beyond the line, so the rest of stdin is left to the command
+const envLoader = `nl=$(printf "\nx"); nl=${nl%x}; while IFS= read -r line && [ -n "$line" ]; do eval "export $line"; done; exec "$@"`
+
+// SSHExecOptionsFunc is a function to set the SSHExec options
+type SSHExecOptionsFunc func(*SSHExec)
+
+// SSHExec is an executabler that runs the commands on a remote host through SSH, such as a bastion host used as Ansible control node
+type SSHExec struct {
+	// Address is the remote host address, in the form "host:port"
+	Address string
+	// Config is the SSH client configuration, which defines the user, the authentication methods and the host key validation
+	Config *ssh.ClientConfig
+	// Client is an established SSH client used to run the commands. A new connection is established for each command when it is not defined
+	Client *ssh.Client
+	// CancelSignal is the signal sent to the remote command when the context is done. It is SIGINT by default
+	CancelSignal ssh.Signal
+	// StopTimeout is the time to wait for the remote command to finish once it has been signaled, before closing the session
+	StopTimeout time.Duration
+}
+
+// NewSSHExec creates a new SSHExec to run the commands on the remote host
+func NewSSHExec(address string, config *ssh.ClientConfig, options ...SSHExecOptionsFunc) *SSHExec {
+	e := &SSHExec{
+		Address:      address,
+		Config:       config,
+		CancelSignal: ssh.SIGINT,
+		StopTimeout:  DefaultSSHStopTimeout,
+	}
+
+	for _, option := range options {
+		option(e)
+	}
+
+	return e
+}
+
+// WithSSHClient sets an established SSH client to run the commands. The client is not closed once the commands finish
+func WithSSHClient(client *ssh.Client) SSHExecOptionsFunc {
+	return func(e *SSHExec) {
+		e.Client = client
+	}
+}
+
+// WithCancelSignal sets the signal sent to the remote command when the context is done
+func WithCancelSignal(signal ssh.Signal) SSHExecOptionsFunc {
+	return func(e *SSHExec) {
+		e.CancelSignal = signal
+	}
+}
+
+// WithSSHStopTimeout sets the time to wait for the remote command to finish once it has been signaled
+func WithSSHStopTimeout(timeout time.Duration) SSHExecOptionsFunc {
+	return func(e *SSHExec) {
+		e.StopTimeout = timeout
+	}
+}
+
+// Command returns a SSHCmd that runs the command on the remote host
+func (e *SSHExec) Command(name string, arg ...string) Cmder {
+	return e.CommandContext(context.Background(), name, arg...)
+}
+
+// CommandContext returns a SSHCmd that runs the command on the remote host. When the context is done, the CancelSignal is sent to the remote command, and the session is closed after the StopTimeout
+func (e *SSHExec) CommandContext(ctx context.Context, name string, arg ...string) Cmder {
+	return &SSHCmd{
+		ctx:  ctx,
+		exec: e,
+		name: name,
+		args: arg,
+	}
+}
+
+// SSHExitError is the error returned when the remote command finishes with a non-zero exit status
+type SSHExitError struct {
+	*ssh.ExitError
+}
+
+// ExitCode returns the exit status of the remote command
+func (e *SSHExitError) ExitCode() int {
+	return e.ExitStatus()
+}
+
+// Unwrap returns the ssh.ExitError
+func (e *SSHExitError) Unwrap() error {
+	return e.ExitError
+}
+
+// SSHCmd is a command that runs on a remote host through SSH
+type SSHCmd struct {
+	// Dir is the working directory of the command on the remote host. The remote user home directory is used when it is empty
+	Dir string
+	// Env are the environment variables, in the form "key=value", set to the remote command. They are sent through the session stdin, so their values are not part of the remote command line
+	Env []string
+	// Stdin is the standard input of the command
+	Stdin io.Reader
+
+	ctx     context.Context
+	exec    *SSHExec
+	name    string
+	args    []string
+	client  *ssh.Client
+	session *ssh.Session
+	// stdinPipe is the pipe returned by StdinPipe, which is closed once the command finishes
+	stdinPipe *io.PipeReader
+	done      chan struct{}
+	// closeOnce ensures that the session and the connection are closed once, either by Wait or by watch when the stop timeout is reached
+	closeOnce sync.Once
+	stdout    io.Writer
+	stderr    io.Writer
+}
+
+// connect opens the session where the command runs, establishing a new connection when the SSHExec does not define a client
+func (c *SSHCmd) connect() error {
+	if c.session != nil {
+		return nil
+	}
+
+	client := c.exec.Client
+	if client == nil {
+		if c.exec.Config == nil {
+			return errors.New("ssh client configuration is not defined")
+		}
+
+		dialer := net.Dialer{Timeout: c.exec.Config.Timeout}
+		conn, err := dialer.DialContext(c.ctx, "tcp", c.exec.Address)
+		if err != nil {
+			return fmt.Errorf("error connecting to '%s': %w", c.exec.Address, err)
+		}
+
+		sshConn, chans, reqs, err := ssh.NewClientConn(conn, c.exec.Address, c.exec.Config)
+		if err != nil {
+			_ = conn.Close()
+			return fmt.Errorf("error establishing ssh connection to '%s': %w", c.exec.Address, err)
+		}
+		client = ssh.NewClient(sshConn, chans, reqs)
+		c.client = client
+	}
+
+	session, err := client.NewSession()
+	if err != nil {
+		c.close()
+		return fmt.Errorf("error creating ssh session on '%s': %w", c.exec.Address, err)
+	}
+	c.session = session
+
+	return nil
+}
+
+// close closes the session and the connection established by the command. It is safe to call it more than once and concurrently
+func (c *SSHCmd) close() {
+	c.closeOnce.Do(func() {
+		if c.session != nil {
+			_ = c.session.Close()
+		}
+
+		if c.client != nil {
+			_ = c.client.Close()
+		}
+	})
+}
+
+// remoteCommand returns the command line run by the remote shell, including the working directory. When there are environment variables, the command is run by the envLoader, which reads them from stdin
+func (c *SSHCmd) remoteCommand() string {
+	var command strings.Builder
+
+	if c.Dir != "" {
+		fmt.Fprintf(&command, "cd %s && ", shellQuote(c.Dir))
+	}
+
+	if len(c.Env) > 0 {
+		fmt.Fprintf(&command, "sh -c %s sh ", shellQuote(envLoader))
+	}
+
+	command.WriteString(c.commandLine())
+
+	return command.String()
+}
+
+// commandLine returns the quoted command and arguments
+func (c *SSHCmd) commandLine() string {
+	var command strings.Builder
+
+	command.WriteString(shellQuote(c.name))
+	for _, arg := range c.args {
+		command.WriteString(" ")
+		command.WriteString(shellQuote(arg))
+	}
+
+	return command.String()
+}
+
+// envScript returns the lines read by the envLoader to export the environment variables. It returns an error when a variable name is not valid, since it would be evaluated by the remote shell
+func (c *SSHCmd) envScript() (string, error) {
+	var script strings.Builder
+
+	for _, env := range c.Env {
+		name, value, _ := strings.Cut(env, "=")
+		if !envNameRegexp.MatchString(name) {
+			return "", fmt.Errorf("invalid environment variable name '%s'", name)
+		}
+
+		fmt.Fprintf(&script, "%s=%s\n", name, strings.ReplaceAll(shellQuote(value), "\n", `'"$nl"'`))
+	}
+	script.WriteString("\n")
+
+	return script.String(), nil
+}
+
+// shellQuote quotes the value to be used as a single word in a POSIX shell
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// CombinedOutput runs the command on the remote host and returns its combined stdout and stderr
+func (c *SSHCmd) CombinedOutput() ([]byte, error) {
+	var output bytes.Buffer
+	writer := &syncWriter{writer: &output}
+
+	c.stdout, c.stderr = writer, writer
+	err := c.Run()
+
+	return output.Bytes(), err
+}
+
+// Environ returns the environment variables set to the remote command
+func (c *SSHCmd) Environ() []string {
+	return c.Env
+}
+
+// Output runs the command on the remote host and returns its stdout
+func (c *SSHCmd) Output() ([]byte, error) {
+	var output bytes.Buffer
+
+	c.stdout = &output
+	err := c.Run()
+
+	return output.Bytes(), err
+}
+
+// Run runs the command on the remote host and waits for it to finish
+func (c *SSHCmd) Run() error {
+	err := c.Start()
+	if err != nil {
+		return err
+	}
+	return c.Wait()
+}
+
+// Start starts the command on the remote host
+func (c *SSHCmd) Start() error {
+	if c.done != nil {
+		return errors.New("ssh command already started")
+	}
+
+	// the session and connection may have been opened by StdoutPipe or StderrPipe, so they are closed on any error since Wait is not called after a failed start
+	if c.ctx.Err() != nil {
+		c.close()
+		return c.ctx.Err()
+	}
+
+	stdin := c.Stdin
+	if len(c.Env) > 0 {
+		script, err := c.envScript()
+		if err != nil {
+			c.close()
+			return err
+		}
+
+		if stdin == nil {
+			stdin = strings.NewReader(script)
+		} else {
+			stdin = io.MultiReader(strings.NewReader(script), stdin)
+		}
+	}
+
+	err := c.connect()
+	if err != nil {
+		return err
+	}
+
+	if stdin != nil {
+		c.session.Stdin = stdin
+	}
+	if c.stdout != nil {
+		c.session.Stdout = c.stdout
+	}
+	if c.stderr != nil {
+		c.session.Stderr = c.stderr
+	}
+
+	err = c.session.Start(c.remoteCommand())
+	if err != nil {
+		c.close()
+		return fmt.Errorf("error starting command on '%s': %w", c.exec.Address, err)
+	}
+
+	c.done = make(chan struct{})
+	go c.watch()
+
+	return nil
+}
+
+// watch sends the cancel signal to the remote command when the context is done, and closes the session when the command does not finish within the stop timeout
+func (c *SSHCmd) watch() {
+	select {
+	case <-c.done:
+		return
+	case <-c.ctx.Done():
+	}
+
+	_ = c.session.Signal(c.exec.CancelSignal)
+
+	select {
+	case <-c.done:
+	case <-time.After(c.exec.StopTimeout):
+		c.close()
+	}
+}
+
+// SetDir sets the working directory of the command on the remote host
+func (c *SSHCmd) SetDir(dir string) {
+	c.Dir = dir
+}
+
+// SetEnv sets the environment variables of the remote command. The environment of the main process is not set to the remote command
+func (c *SSHCmd) SetEnv(env []string) {
+	c.Env = env
+}
+
+// SetStdin sets the standard input of the remote command. The main process' stdin is ignored, since it would be read in the background even after the command finishes
+func (c *SSHCmd) SetStdin(stdin io.Reader) {
+	if stdin == os.Stdin {
+		return
+	}
+	c.Stdin = stdin
+}
+
+// StderrPipe returns a pipe connected to the command stderr
+func (c *SSHCmd) StderrPipe() (io.ReadCloser, error) {
+	err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe, err := c.session.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(pipe), nil
+}
+
+// StdinPipe returns a pipe connected to the command stdin. The data written to the pipe is sent once the environment variables have been sent
+func (c *SSHCmd) StdinPipe() (io.WriteCloser, error) {
+	if c.Stdin != nil {
+		return nil, errors.New("stdin already set")
+	}
+
+	reader, writer := io.Pipe()
+	c.Stdin = reader
+	c.stdinPipe = reader
+
+	return writer, nil
+}
+
+// StdoutPipe returns a pipe connected to the command stdout
+func (c *SSHCmd) StdoutPipe() (io.ReadCloser, error) {
+	err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe, err := c.session.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(pipe), nil
+}
+
+// String returns the remote host and the command run on it, preceded by the working directory and the names of the environment variables. The values of the environment variables are not shown and the secrets registered on the redact package are masked
+func (c *SSHCmd) String() string {
+	var command strings.Builder
+
+	fmt.Fprintf(&command, "ssh %s ", c.exec.Address)
+
+	if c.Dir != "" {
+		fmt.Fprintf(&command, "cd %s && ", shellQuote(c.Dir))
+	}
+
+	for _, env := range c.Env {
+		name, _, _ := strings.Cut(env, "=")
+		fmt.Fprintf(&command, "%s='***' ", name)
+	}
+
+	command.WriteString(c.commandLine())
+
+	return redact.Redact(command.String())
+}
+
+// Wait waits for the command to finish and closes the session. The error is a SSHExitError with the exit status of the command when it fails on the remote host
+func (c *SSHCmd) Wait() error {
+	if c.done == nil {
+		return errors.New("ssh command not started")
+	}
+
+	err := c.session.Wait()
+	close(c.done)
+	c.close()
+
+	// the pipe reader is closed to release the session goroutine that copies the stdin, when the pipe writer is not closed
+	if c.stdinPipe != nil {
+		_ = c.stdinPipe.Close()
+	}
+
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		return &SSHExitError{exitErr}
+	}
+
+	return err
+}
+
+// syncWriter is a writer safe for concurrent use, used to combine the stdout and stderr of the remote command
+type syncWriter struct {
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+// Write writes to the underlying writer
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.writer.Write(p)
+}
@@ -0,0 +1,441 @@
+package exec
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+// fakeSSHServer is an SSH server that, instead of running the commands, writes the command to stdout. When the command is run by the envLoader, the environment variables read from stdin are also written to stdout. The command behaviour depends on its content:
+//   - "stdin": writes the received stdin to stdout
+//   - "fail": exits with status 2
+//   - "sleep": waits until a signal is received, and exits with status 130
+//   - "hang": ignores the signals and waits until the session is closed
+type fakeSSHServer struct {
+	listener net.Listener
+	config   *ssh.ServerConfig
+
+	mutex   sync.Mutex
+	signals []string
+}
+
+func newFakeSSHServer(t *testing.T) *fakeSSHServer {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating host key: %s", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatalf("error creating host key signer: %s", err)
+	}
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "ansible" && string(password) == "secret" {
+				return nil, nil
+			}
+			return nil, errors.New("access denied")
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %s", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	server := &fakeSSHServer{
+		listener: listener,
+		config:   config,
+	}
+	go server.serve()
+
+	return server
+}
+
+func (s *fakeSSHServer) address() string {
+	return s.listener.Addr().String()
+}
+
+func (s *fakeSSHServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			_, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+			if err != nil {
+				return
+			}
+			go ssh.DiscardRequests(reqs)
+
+			for newChannel := range chans {
+				if newChannel.ChannelType() != "session" {
+					_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+					continue
+				}
+
+				channel, requests, err := newChannel.Accept()
+				if err != nil {
+					continue
+				}
+				go s.session(channel, requests)
+			}
+		}()
+	}
+}
+
+func (s *fakeSSHServer) session(channel ssh.Channel, requests <-chan *ssh.Request) {
+	signaled := make(chan struct{})
+	defer channel.Close()
+
+	for req := range requests {
+		switch req.Type {
+		case "exec":
+			length := binary.BigEndian.Uint32(req.Payload)
+			command := string(req.Payload[4 : 4+length])
+			_ = req.Reply(true, nil)
+			go s.exec(channel, command, signaled)
+		case "signal":
+			length := binary.BigEndian.Uint32(req.Payload)
+			s.mutex.Lock()
+			s.signals = append(s.signals, string(req.Payload[4:4+length]))
+			s.mutex.Unlock()
+			close(signaled)
+		default:
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+func (s *fakeSSHServer) exec(channel ssh.Channel, command string, signaled chan struct{}) {
+	status := 0
+
+	fmt.Fprintf(channel, "command: %s\n", command)
+	fmt.Fprintf(channel.Stderr(), "stderr output\n")
+
+	if strings.Contains(command, envLoader) {
+		for {
+			line := readLine(channel)
+			if line == "" {
+				break
+			}
+			fmt.Fprintf(channel, "env: %s\n", line)
+		}
+	}
+
+	switch {
+	case strings.Contains(command, "stdin"):
+		input, _ := io.ReadAll(channel)
+		fmt.Fprintf(channel, "stdin: %s\n", input)
+	case strings.Contains(command, "fail"):
+		status = 2
+	case strings.Contains(command, "sleep"):
+		<-signaled
+		status = 130
+	case strings.Contains(command, "hang"):
+		_, _ = io.Copy(io.Discard, channel)
+		return
+	}
+
+	_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{uint32(status)}))
+	_ = channel.Close()
+}
+
+// readLine reads a line byte by byte, as the read shell builtin does, so the rest of the input is left unread
+func readLine(reader io.Reader) string {
+	var line strings.Builder
+	b := make([]byte, 1)
+
+	for {
+		_, err := reader.Read(b)
+		if err != nil || b[0] == '\n' {
+			return line.String()
+		}
+		line.WriteByte(b[0])
+	}
+}
+
+func (s *fakeSSHServer) receivedSignals() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.signals
+}
+
+func TestNewSSHExec(t *testing.T) {
+	config := &ssh.ClientConfig{User: "ansible"}
+
+	e := NewSSHExec("bastion:22", config,
+		WithCancelSignal(ssh.SIGTERM),
+		WithSSHStopTimeout(time.Second),
+	)
+
+	assert.Equal(t, &SSHExec{
+		Address:      "bastion:22",
+		Config:       config,
+		CancelSignal: ssh.SIGTERM,
+		StopTimeout:  time.Second,
+	}, e)
+	assert.Equal(t, ssh.SIGINT, NewSSHExec("bastion:22", config).CancelSignal)
+}
+
+func TestSSHCmdString(t *testing.T) {
+	cmd := NewSSHExec("bastion:22", nil).Command("ansible-playbook", "site.yml", "--extra-vars", "msg='hello world'").(*SSHCmd)
+	cmd.Dir = "/opt/ansible"
+	cmd.Env = []string{"ANSIBLE_VAULT_PASSWORD=secret"}
+
+	assert.Equal(t, `ssh bastion:22 cd '/opt/ansible' && ANSIBLE_VAULT_PASSWORD='***' 'ansible-playbook' 'site.yml' '--extra-vars' 'msg='\''hello world'\'''`, cmd.String())
+	assert.Equal(t, `cd '/opt/ansible' && sh -c '`+envLoader+`' sh 'ansible-playbook' 'site.yml' '--extra-vars' 'msg='\''hello world'\'''`, cmd.remoteCommand())
+	assert.NotContains(t, cmd.remoteCommand(), "secret")
+}
+
+func TestSSHCmdEnvScript(t *testing.T) {
+	tests := []struct {
+		desc   string
+		env    []string
+		script string
+		err    error
+	}{
+		{
+			desc:   "Testing the script to export the environment variables",
+			env:    []string{"ANSIBLE_VAULT_PASSWORD=it's a secret", "ANSIBLE_EMPTY="},
+			script: "ANSIBLE_VAULT_PASSWORD='it'\\''s a secret'\nANSIBLE_EMPTY=''\n\n",
+		},
+		{
+			desc:   "Testing the script to export an environment variable with multiple lines",
+			env:    []string{"ANSIBLE_PRIVATE_KEY=line1\nline2"},
+			script: "ANSIBLE_PRIVATE_KEY='line1'\"$nl\"'line2'\n\n",
+		},
+		{
+			desc: "Testing error when an environment variable name is not valid",
+			env:  []string{"ANSIBLE; rm -rf /=value"},
+			err:  errors.New("invalid environment variable name 'ANSIBLE; rm -rf /'"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			cmd := NewSSHExec("bastion:22", nil).Command("ansible-playbook").(*SSHCmd)
+			cmd.Env = test.env
+
+			script, err := cmd.envScript()
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.script, script)
+		})
+	}
+}
+
+func TestSSHCmdRun(t *testing.T) {
+	server := newFakeSSHServer(t)
+
+	config := &ssh.ClientConfig{
+		User:            "ansible",
+		Auth:            []ssh.AuthMethod{ssh.Password("secret")},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         5 * time.Second,
+	}
+
+	t.Run("Testing run a command on the remote host streaming stdout and stderr", func(t *testing.T) {
+		cmd := NewSSHExec(server.address(), config).CommandContext(context.TODO(), "ansible-playbook", "site.yml").(*SSHCmd)
+		cmd.Dir = "/opt/ansible"
+		cmd.Env = []string{"ANSIBLE_FORCE_COLOR=true"}
+
+		stdout, err := cmd.StdoutPipe()
+		assert.NoError(t, err)
+		stderr, err := cmd.StderrPipe()
+		assert.NoError(t, err)
+
+		err = cmd.Start()
+		assert.NoError(t, err)
+
+		var stderrOutput []byte
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stderrOutput, _ = io.ReadAll(stderr)
+		}()
+		stdoutOutput, _ := io.ReadAll(stdout)
+		wg.Wait()
+
+		err = cmd.Wait()
+		assert.NoError(t, err)
+		assert.Equal(t, "command: cd '/opt/ansible' && sh -c '"+envLoader+"' sh 'ansible-playbook' 'site.yml'\nenv: ANSIBLE_FORCE_COLOR='true'\n", string(stdoutOutput))
+		assert.Equal(t, "stderr output\n", string(stderrOutput))
+	})
+
+	t.Run("Testing run a command on the remote host with stdin", func(t *testing.T) {
+		cmd := NewSSHExec(server.address(), config).Command("stdin").(*SSHCmd)
+		cmd.Stdin = strings.NewReader("password")
+
+		output, err := cmd.Output()
+		assert.NoError(t, err)
+		assert.Equal(t, "command: 'stdin'\nstdin: password\n", string(output))
+	})
+
+	t.Run("Testing run a command on the remote host sending the environment variables before stdin", func(t *testing.T) {
+		cmd := NewSSHExec(server.address(), config).Command("stdin").(*SSHCmd)
+		cmd.Env = []string{"ANSIBLE_VAULT_PASSWORD=secret"}
+
+		stdin, err := cmd.StdinPipe()
+		assert.NoError(t, err)
+
+		go func() {
+			defer stdin.Close()
+			_, _ = io.WriteString(stdin, "password")
+		}()
+
+		output, err := cmd.Output()
+		assert.NoError(t, err)
+		assert.Equal(t, "command: sh -c '"+envLoader+"' sh 'stdin'\nenv: ANSIBLE_VAULT_PASSWORD='secret'\nstdin: password\n", string(output))
+	})
+
+	t.Run("Testing error when running a command with an invalid environment variable name", func(t *testing.T) {
+		cmd := NewSSHExec(server.address(), config).Command("ansible-playbook").(*SSHCmd)
+		cmd.Env = []string{"ANSIBLE_FORKS;id=10"}
+
+		err := cmd.Run()
+		assert.EqualError(t, err, "invalid environment variable name 'ANSIBLE_FORKS;id'")
+	})
+
+	t.Run("Testing close the session and connection when the context is cancelled after creating the pipes", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+
+		cmd := NewSSHExec(server.address(), config).CommandContext(ctx, "ansible-playbook").(*SSHCmd)
+		stdout, err := cmd.StdoutPipe()
+		assert.NoError(t, err)
+		_, err = cmd.StderrPipe()
+		assert.NoError(t, err)
+
+		cancel()
+		err = cmd.Start()
+		assert.ErrorIs(t, err, context.Canceled)
+
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			_, _ = io.ReadAll(stdout)
+			_ = cmd.client.Wait()
+		}()
+
+		select {
+		case <-closed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the ssh session and connection have not been closed")
+		}
+	})
+
+	t.Run("Testing close the session and connection when an environment variable name is not valid after creating the pipes", func(t *testing.T) {
+		cmd := NewSSHExec(server.address(), config).Command("ansible-playbook").(*SSHCmd)
+		cmd.Env = []string{"ANSIBLE_FORKS;id=10"}
+
+		stdout, err := cmd.StdoutPipe()
+		assert.NoError(t, err)
+
+		err = cmd.Start()
+		assert.EqualError(t, err, "invalid environment variable name 'ANSIBLE_FORKS;id'")
+
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			_, _ = io.ReadAll(stdout)
+			_ = cmd.client.Wait()
+		}()
+
+		select {
+		case <-closed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the ssh session and connection have not been closed")
+		}
+	})
+
+	t.Run("Testing run a failing command on the remote host", func(t *testing.T) {
+		output, err := NewSSHExec(server.address(), config).Command("fail").CombinedOutput()
+
+		var exitErr *SSHExitError
+		assert.True(t, errors.As(err, &exitErr))
+		assert.Equal(t, 2, exitErr.ExitCode())
+		assert.Contains(t, string(output), "stderr output\n")
+	})
+
+	t.Run("Testing signal the remote command when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 200*time.Millisecond)
+		defer cancel()
+
+		err := NewSSHExec(server.address(), config).CommandContext(ctx, "sleep").Run()
+
+		var exitErr *SSHExitError
+		assert.True(t, errors.As(err, &exitErr))
+		assert.Equal(t, 130, exitErr.ExitCode())
+		assert.Equal(t, []string{string(ssh.SIGINT)}, server.receivedSignals())
+	})
+
+	t.Run("Testing close the session when the remote command does not finish within the stop timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+		defer cancel()
+
+		err := NewSSHExec(server.address(), config, WithSSHStopTimeout(50*time.Millisecond)).CommandContext(ctx, "hang").Run()
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Testing run a command using an established client", func(t *testing.T) {
+		client, err := ssh.Dial("tcp", server.address(), config)
+		assert.NoError(t, err)
+		defer client.Close()
+
+		e := NewSSHExec(server.address(), nil, WithSSHClient(client))
+		for i := 0; i < 2; i++ {
+			output, err := e.Command("ansible", "--version").Output()
+			assert.NoError(t, err)
+			assert.Equal(t, "command: 'ansible' '--version'\n", string(output))
+		}
+	})
+
+	t.Run("Testing run a command when the authentication fails", func(t *testing.T) {
+		err := NewSSHExec(server.address(), &ssh.ClientConfig{
+			User:            "ansible",
+			Auth:            []ssh.AuthMethod{ssh.Password("wrong")},
+			HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		}).Command("ansible", "--version").Run()
+
+		assert.ErrorContains(t, err, fmt.Sprintf("error establishing ssh connection to '%s'", server.address()))
+	})
+}
+
+func TestSSHCmdSetStdin(t *testing.T) {
+	cmd := NewSSHExec("bastion:22", nil).Command("ansible-playbook").(*SSHCmd)
+
+	cmd.SetStdin(os.Stdin)
+	assert.Nil(t, cmd.Stdin)
+
+	stdin := strings.NewReader("password")
+	cmd.SetStdin(stdin)
+	assert.Equal(t, stdin, cmd.Stdin)
+}
@@ -0,0 +1,54 @@
+package execute
+
+import (
+	jsonresults "github.com/apenella/go-ansible/v2/pkg/execute/result/json"
+)
+
+// ResultsErrorEnricher is an ErrorEnricher that also uses the parsed results of the execution to enrich the errors. DefaultExecute uses it when the results are available
+type ResultsErrorEnricher interface {
+	ErrorEnricher
+	EnrichWithResults(err error, results *jsonresults.AnsiblePlaybookJSONResults) error
+}
+
+// ExitCodeErrorEnrich is an error enricher that converts the errors returned by the ansible commands into the typed errors defined by the exit code, such as HostFailedError or ParserError
+type ExitCodeErrorEnrich struct {
+	// Command is the name of the ansible command, such as ansible-playbook
+	Command string
+}
+
+// NewExitCodeErrorEnrich creates a new ExitCodeErrorEnrich instance for the command
+func NewExitCodeErrorEnrich(command string) *ExitCodeErrorEnrich {
+	return &ExitCodeErrorEnrich{
+		Command: command,
+	}
+}
+
+// Enrich returns the typed error that corresponds to the exit code. The error is returned as it is when it does not provide an exit code
+func (e *ExitCodeErrorEnrich) Enrich(err error) error {
+	return e.EnrichWithResults(err, nil)
+}
+
+// EnrichWithResults returns the typed error that corresponds to the exit code, including the failed or unreachable hosts found in the results stats. The error is returned as it is when it does not provide an exit code
+func (e *ExitCodeErrorEnrich) EnrichWithResults(err error, results *jsonresults.AnsiblePlaybookJSONResults) error {
+	var hosts []string
+
+	if err == nil {
+		return nil
+	}
+
+	code := exitCode(err)
+	if code == ExitCodeUnknown {
+		return err
+	}
+
+	if results != nil {
+		switch code {
+		case AnsiblePlaybookErrorCodeOneOrMoreHostFailed:
+			hosts = results.FailedHosts()
+		case AnsiblePlaybookErrorCodeOneOrMoreHostUnreachable:
+			hosts = results.UnreachableHosts()
+		}
+	}
+
+	return NewExitError(e.Command, code, hosts, err)
+}
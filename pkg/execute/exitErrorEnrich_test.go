@@ -0,0 +1,92 @@
+package execute
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/mocks"
+	jsonresults "github.com/apenella/go-ansible/v2/pkg/execute/result/json"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCodeErrorEnrich(t *testing.T) {
+
+	results := &jsonresults.AnsiblePlaybookJSONResults{
+		Stats: map[string]*jsonresults.AnsiblePlaybookJSONResultsStats{
+			"host1": {Failures: 1},
+			"host2": {Ok: 1},
+			"host3": {Unreachable: 1},
+		},
+	}
+
+	tests := []struct {
+		desc     string
+		err      error
+		results  *jsonresults.AnsiblePlaybookJSONResults
+		expected string
+		sentinel error
+		hosts    []string
+	}{
+		{
+			desc:     "Testing enrich an error without exit code",
+			err:      fmt.Errorf("error cause"),
+			expected: "error cause",
+		},
+		{
+			desc:     "Testing enrich a general error",
+			err:      &mocks.MockExitCodeErr{Code: AnsiblePlaybookErrorCodeGeneralError, Message: "error cause"},
+			expected: "ansible error: general error: error cause",
+			sentinel: ErrGeneral,
+		},
+		{
+			desc:     "Testing enrich a host failed error without results",
+			err:      &mocks.MockExitCodeErr{Code: AnsiblePlaybookErrorCodeOneOrMoreHostFailed, Message: "error cause"},
+			expected: "ansible error: one or more host failed: error cause",
+			sentinel: ErrHostFailed,
+		},
+		{
+			desc:     "Testing enrich an error with an exit code",
+			err:      &mocks.MockExitCodeErr{Code: AnsiblePlaybookErrorCodeBadOrIncompleteOptions, Message: "error cause"},
+			expected: "ansible error: bad or incomplete options: error cause",
+			sentinel: ErrBadOptions,
+		},
+		{
+			desc:     "Testing enrich a host failed error with results",
+			err:      &mocks.MockExitCodeErr{Code: AnsiblePlaybookErrorCodeOneOrMoreHostFailed, Message: "error cause"},
+			results:  results,
+			expected: "ansible error: one or more host failed [host1]: error cause",
+			sentinel: ErrHostFailed,
+			hosts:    []string{"host1"},
+		},
+		{
+			desc:     "Testing enrich a host unreachable error with results",
+			err:      &mocks.MockExitCodeErr{Code: AnsiblePlaybookErrorCodeOneOrMoreHostUnreachable, Message: "error cause"},
+			results:  results,
+			expected: "ansible error: one or more host unreachable [host3]: error cause",
+			sentinel: ErrHostUnreachable,
+			hosts:    []string{"host3"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := NewExitCodeErrorEnrich("ansible").EnrichWithResults(test.err, test.results)
+			assert.Equal(t, test.expected, err.Error())
+
+			if test.sentinel != nil {
+				var exitErr *ExitError
+				assert.True(t, errors.Is(err, test.sentinel))
+				assert.True(t, errors.As(err, &exitErr))
+				assert.Equal(t, test.hosts, exitErr.Hosts)
+				assert.Equal(t, "ansible", exitErr.Command)
+			}
+		})
+	}
+
+	t.Run("Testing enrich a nil error", func(t *testing.T) {
+		assert.Nil(t, NewExitCodeErrorEnrich("ansible").Enrich(nil))
+	})
+}
@@ -0,0 +1,137 @@
+package execute
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrGeneral is the error matched by errors.Is for a GeneralError
+	ErrGeneral = errors.New("general error")
+	// ErrHostFailed is the error matched by errors.Is for a HostFailedError
+	ErrHostFailed = errors.New("one or more host failed")
+	// ErrHostUnreachable is the error matched by errors.Is for a HostUnreachableError
+	ErrHostUnreachable = errors.New("one or more host unreachable")
+	// ErrParser is the error matched by errors.Is for a ParserError
+	ErrParser = errors.New("parser error")
+	// ErrBadOptions is the error matched by errors.Is for a BadOptionsError
+	ErrBadOptions = errors.New("bad or incomplete options")
+	// ErrInterrupted is the error matched by errors.Is for an InterruptedError
+	ErrInterrupted = errors.New("user interrupted execution")
+	// ErrUnexpected is the error matched by errors.Is for an UnexpectedError
+	ErrUnexpected = errors.New("unexpected error")
+)
+
+// ExitError is an error returned by an ansible command that finished with a non-zero exit code
+type ExitError struct {
+	// Command is the name of the ansible command, such as ansible-playbook
+	Command string
+	// Code is the exit code of the command
+	Code int
+	// Hosts is the list of hosts that caused the error, when it is known
+	Hosts []string
+	// Err is the original error returned by the command
+	Err error
+	// kind is the sentinel error that classifies the error
+	kind error
+}
+
+// Error returns the error message
+func (e *ExitError) Error() string {
+	message := fmt.Sprintf("%s error: %s", e.Command, e.kind)
+	if len(e.Hosts) > 0 {
+		message = fmt.Sprintf("%s [%s]", message, strings.Join(e.Hosts, ", "))
+	}
+
+	if e.Err != nil {
+		message = fmt.Sprintf("%s: %s", message, e.Err)
+	}
+
+	return message
+}
+
+// ExitCode returns the exit code of the command
+func (e *ExitError) ExitCode() int {
+	return e.Code
+}
+
+// Unwrap returns the original error returned by the command
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is the sentinel error that classifies the error
+func (e *ExitError) Is(target error) bool {
+	return e.kind != nil && target == e.kind
+}
+
+// As allows errors.As to find the ExitError of any of the typed errors, such as HostFailedError
+func (e *ExitError) As(target any) bool {
+	exitErr, isExitError := target.(**ExitError)
+	if !isExitError {
+		return false
+	}
+
+	*exitErr = e
+	return true
+}
+
+// GeneralError is returned when the command finishes with a general error
+type GeneralError struct{ ExitError }
+
+// HostFailedError is returned when one or more hosts failed
+type HostFailedError struct{ ExitError }
+
+// HostUnreachableError is returned when one or more hosts are unreachable
+type HostUnreachableError struct{ ExitError }
+
+// ParserError is returned when the command finishes with a parser error
+type ParserError struct{ ExitError }
+
+// BadOptionsError is returned when the command receives bad or incomplete options
+type BadOptionsError struct{ ExitError }
+
+// InterruptedError is returned when the user interrupts the execution
+type InterruptedError struct{ ExitError }
+
+// UnexpectedError is returned when the command finishes with an unexpected error
+type UnexpectedError struct{ ExitError }
+
+// NewExitError returns the typed error that corresponds to the exit code of the command. It returns an ExitError when the exit code is not known
+func NewExitError(command string, code int, hosts []string, err error) error {
+
+	exitErr := ExitError{
+		Command: command,
+		Code:    code,
+		Hosts:   hosts,
+		Err:     err,
+	}
+
+	switch code {
+	case AnsiblePlaybookErrorCodeGeneralError:
+		exitErr.kind = ErrGeneral
+		return &GeneralError{exitErr}
+	case AnsiblePlaybookErrorCodeOneOrMoreHostFailed:
+		exitErr.kind = ErrHostFailed
+		return &HostFailedError{exitErr}
+	case AnsiblePlaybookErrorCodeOneOrMoreHostUnreachable:
+		exitErr.kind = ErrHostUnreachable
+		return &HostUnreachableError{exitErr}
+	case AnsiblePlaybookErrorCodeParserError:
+		exitErr.kind = ErrParser
+		return &ParserError{exitErr}
+	case AnsiblePlaybookErrorCodeBadOrIncompleteOptions:
+		exitErr.kind = ErrBadOptions
+		return &BadOptionsError{exitErr}
+	case AnsiblePlaybookErrorCodeUserInterruptedExecution:
+		exitErr.kind = ErrInterrupted
+		return &InterruptedError{exitErr}
+	case AnsiblePlaybookErrorCodeUnexpectedError:
+		exitErr.kind = ErrUnexpected
+		return &UnexpectedError{exitErr}
+	default:
+		exitErr.kind = fmt.Errorf("exit code %d", code)
+		return &exitErr
+	}
+}
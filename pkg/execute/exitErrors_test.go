@@ -0,0 +1,125 @@
+package execute
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExitError(t *testing.T) {
+
+	cause := fmt.Errorf("exit status")
+
+	tests := []struct {
+		desc       string
+		code       int
+		hosts      []string
+		sentinel   error
+		message    string
+		assertFunc func(t *testing.T, err error)
+	}{
+		{
+			desc:     "Testing new exit error for a general error",
+			code:     AnsiblePlaybookErrorCodeGeneralError,
+			sentinel: ErrGeneral,
+			message:  "ansible-playbook error: general error: exit status",
+			assertFunc: func(t *testing.T, err error) {
+				var target *GeneralError
+				assert.True(t, errors.As(err, &target))
+			},
+		},
+		{
+			desc:     "Testing new exit error for a host failed error",
+			code:     AnsiblePlaybookErrorCodeOneOrMoreHostFailed,
+			hosts:    []string{"host1", "host2"},
+			sentinel: ErrHostFailed,
+			message:  "ansible-playbook error: one or more host failed [host1, host2]: exit status",
+			assertFunc: func(t *testing.T, err error) {
+				var target *HostFailedError
+				assert.True(t, errors.As(err, &target))
+				assert.Equal(t, []string{"host1", "host2"}, target.Hosts)
+				assert.Equal(t, AnsiblePlaybookErrorCodeOneOrMoreHostFailed, target.ExitCode())
+			},
+		},
+		{
+			desc:     "Testing new exit error for a host unreachable error",
+			code:     AnsiblePlaybookErrorCodeOneOrMoreHostUnreachable,
+			hosts:    []string{"host1"},
+			sentinel: ErrHostUnreachable,
+			message:  "ansible-playbook error: one or more host unreachable [host1]: exit status",
+			assertFunc: func(t *testing.T, err error) {
+				var target *HostUnreachableError
+				assert.True(t, errors.As(err, &target))
+			},
+		},
+		{
+			desc:     "Testing new exit error for a parser error",
+			code:     AnsiblePlaybookErrorCodeParserError,
+			sentinel: ErrParser,
+			message:  AnsiblePlaybookErrorMessageParserError + ": exit status",
+			assertFunc: func(t *testing.T, err error) {
+				var target *ParserError
+				assert.True(t, errors.As(err, &target))
+			},
+		},
+		{
+			desc:     "Testing new exit error for a bad options error",
+			code:     AnsiblePlaybookErrorCodeBadOrIncompleteOptions,
+			sentinel: ErrBadOptions,
+			message:  AnsiblePlaybookErrorMessageBadOrIncompleteOptions + ": exit status",
+			assertFunc: func(t *testing.T, err error) {
+				var target *BadOptionsError
+				assert.True(t, errors.As(err, &target))
+			},
+		},
+		{
+			desc:     "Testing new exit error for an interrupted error",
+			code:     AnsiblePlaybookErrorCodeUserInterruptedExecution,
+			sentinel: ErrInterrupted,
+			message:  AnsiblePlaybookErrorMessageUserInterruptedExecution + ": exit status",
+			assertFunc: func(t *testing.T, err error) {
+				var target *InterruptedError
+				assert.True(t, errors.As(err, &target))
+			},
+		},
+		{
+			desc:     "Testing new exit error for an unexpected error",
+			code:     AnsiblePlaybookErrorCodeUnexpectedError,
+			sentinel: ErrUnexpected,
+			message:  AnsiblePlaybookErrorMessageUnexpectedError + ": exit status",
+			assertFunc: func(t *testing.T, err error) {
+				var target *UnexpectedError
+				assert.True(t, errors.As(err, &target))
+			},
+		},
+		{
+			desc:    "Testing new exit error for an unknown exit code",
+			code:    7,
+			message: "ansible-playbook error: exit code 7: exit status",
+			assertFunc: func(t *testing.T, err error) {
+				var target *ExitError
+				assert.True(t, errors.As(err, &target))
+				assert.False(t, errors.Is(err, ErrGeneral))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := NewExitError("ansible-playbook", test.code, test.hosts, cause)
+
+			assert.Equal(t, test.message, err.Error())
+			assert.True(t, errors.Is(err, cause))
+			if test.sentinel != nil {
+				assert.True(t, errors.Is(err, test.sentinel))
+			}
+
+			wrapped := &ExecuteError{cause: fmt.Errorf("error executing command: %w", err)}
+			test.assertFunc(t, wrapped)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package execute
 
 import (
 	"context"
+	"io"
 
 	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
 )
@@ -27,3 +28,9 @@ type Commander interface {
 type ErrorEnricher interface {
 	Enrich(err error) error
 }
+
+// PromptResponder answers the prompts written by the command. DefaultExecute copies the command stdout and stderr to the writers returned by Respond, and the answers are written to the command stdin. The error returned by Err, raised while answering a prompt, is joined to the execution error
+type PromptResponder interface {
+	Respond(stdin io.WriteCloser) (stdout io.Writer, stderr io.Writer)
+	Err() error
+}
@@ -45,12 +45,8 @@ func (e *ExecutorTimeMeasurement) Execute(ctx context.Context) error {
 		e.duration = time.Since(timeInit)
 	}()
 
-	err := e.executor.Execute(ctx)
-	if err != nil {
-		return errors.New("(ExecutorTimeMeasurement::Execute)", err.Error())
-	}
-
-	return nil
+	// the error is returned as it is to keep it reachable by errors.Is and errors.As
+	return e.executor.Execute(ctx)
 }
 
 // Duration returns the duration of the command
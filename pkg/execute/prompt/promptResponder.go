@@ -0,0 +1,182 @@
+package prompt
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+)
+
+const (
+	// BecomePasswordPattern matches the privilege escalation password prompt, requested by --ask-become-pass
+	BecomePasswordPattern = `(?i)BECOME password[^:\n]*: ?$`
+	// ConnectionPasswordPattern matches the connection password prompt, requested by --ask-pass
+	ConnectionPasswordPattern = `(?i)SSH password: ?$`
+	// VaultPasswordPattern matches the vault password prompt, requested by --ask-vault-password, including the prompts for a vault id such as "Vault password (dev): "
+	VaultPasswordPattern = `(?i)Vault password[^:\n]*: ?$`
+	// StepPattern matches the step confirmation prompt, requested by --step
+	StepPattern = `\(N\)o/\(y\)es/\(c\)ontinue: ?$`
+
+	// StepYes runs the task
+	StepYes = "y"
+	// StepNo skips the task
+	StepNo = "n"
+	// StepContinue runs the task and the remaining tasks without asking again
+	StepContinue = "c"
+
+	// maxPendingOutput is the maximum size of the output kept while waiting for a prompt
+	maxPendingOutput = 4096
+)
+
+// PromptAnswerFunc returns the answer to the prompt. The prompt is the text of the last line written by the command
+type PromptAnswerFunc func(prompt string) (string, error)
+
+// Prompt is a prompt answered by the PromptResponder
+type Prompt struct {
+	// Pattern is the regular expression matched against the last line written by the command, which is not finished by a new line
+	Pattern *regexp.Regexp
+	// Answer returns the answer to the prompt
+	Answer PromptAnswerFunc
+}
+
+// PromptResponderOptionsFunc is a function to set the PromptResponder options
+type PromptResponderOptionsFunc func(*PromptResponder)
+
+// PromptResponder answers the prompts written by the command to its stdout or stderr, expect-style. The prompts are answered in the order they are defined, using the first one that matches
+type PromptResponder struct {
+	// Prompts is the list of prompts to answer
+	Prompts []*Prompt
+
+	mutex sync.Mutex
+	stdin io.WriteCloser
+	err   error
+}
+
+// NewPromptResponder creates a new PromptResponder
+func NewPromptResponder(options ...PromptResponderOptionsFunc) *PromptResponder {
+	responder := &PromptResponder{}
+
+	for _, option := range options {
+		option(responder)
+	}
+
+	return responder
+}
+
+// WithPromptFunc answers the prompts that match the pattern using the answer function
+func WithPromptFunc(pattern string, answer PromptAnswerFunc) PromptResponderOptionsFunc {
+	return func(r *PromptResponder) {
+		r.Prompts = append(r.Prompts, &Prompt{
+			Pattern: regexp.MustCompile(pattern),
+			Answer:  answer,
+		})
+	}
+}
+
+// WithPrompt answers the prompts that match the pattern with the value
+func WithPrompt(pattern, value string) PromptResponderOptionsFunc {
+	return WithPromptFunc(pattern, func(string) (string, error) {
+		return value, nil
+	})
+}
+
+// WithBecomePassword answers the privilege escalation password prompt
+func WithBecomePassword(password string) PromptResponderOptionsFunc {
+	return WithPrompt(BecomePasswordPattern, password)
+}
+
+// WithConnectionPassword answers the connection password prompt
+func WithConnectionPassword(password string) PromptResponderOptionsFunc {
+	return WithPrompt(ConnectionPasswordPattern, password)
+}
+
+// WithVaultPassword answers the vault password prompt
+func WithVaultPassword(password string) PromptResponderOptionsFunc {
+	return WithPrompt(VaultPasswordPattern, password)
+}
+
+// WithStepAnswer answers the step confirmation prompt. The answer should be StepYes, StepNo or StepContinue
+func WithStepAnswer(answer string) PromptResponderOptionsFunc {
+	return WithPrompt(StepPattern, answer)
+}
+
+// WithVarsPrompt answers the vars_prompt prompt, including its confirmation prompt
+func WithVarsPrompt(prompt, value string) PromptResponderOptionsFunc {
+	return WithPrompt(fmt.Sprintf(`^(confirm )?%s: ?$`, regexp.QuoteMeta(prompt)), value)
+}
+
+// Respond starts answering the prompts written to the returned writers, one for the command stdout and another for the command stderr. The answers are written to stdin
+func (r *PromptResponder) Respond(stdin io.WriteCloser) (io.Writer, io.Writer) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.stdin = stdin
+	r.err = nil
+
+	return &promptWatcher{responder: r}, &promptWatcher{responder: r}
+}
+
+// Err returns the error raised while answering a prompt. The command stdin is closed when an answer can not be provided
+func (r *PromptResponder) Err() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.err
+}
+
+// answer answers the prompt when it matches any of the defined prompts. It returns true when the prompt has been answered
+func (r *PromptResponder) answer(prompt string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.stdin == nil || r.err != nil {
+		return false
+	}
+
+	for _, p := range r.Prompts {
+		if !p.Pattern.MatchString(prompt) {
+			continue
+		}
+
+		answer, err := p.Answer(prompt)
+		if err == nil {
+			_, err = fmt.Fprintln(r.stdin, answer)
+		}
+
+		if err != nil {
+			r.err = fmt.Errorf("error answering prompt '%s': %w", prompt, err)
+			_ = r.stdin.Close()
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// promptWatcher is the writer that receives the output of the command, keeping the last unfinished line to be matched against the prompts
+type promptWatcher struct {
+	responder *PromptResponder
+	pending   []byte
+}
+
+// Write keeps the last unfinished line of the output and answers it when it is a prompt
+func (w *promptWatcher) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+
+	for i := len(w.pending) - 1; i >= 0; i-- {
+		if w.pending[i] == '\n' {
+			w.pending = w.pending[i+1:]
+			break
+		}
+	}
+
+	if len(w.pending) > maxPendingOutput {
+		w.pending = w.pending[len(w.pending)-maxPendingOutput:]
+	}
+
+	if len(w.pending) > 0 && w.responder.answer(string(w.pending)) {
+		w.pending = w.pending[:0]
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,120 @@
+package prompt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stdinBuffer is a WriteCloser that records the answers written to the command stdin
+type stdinBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *stdinBuffer) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestNewPromptResponder(t *testing.T) {
+	responder := NewPromptResponder(
+		WithBecomePassword("become"),
+		WithVaultPassword("vault"),
+	)
+
+	assert.Len(t, responder.Prompts, 2)
+	assert.Equal(t, BecomePasswordPattern, responder.Prompts[0].Pattern.String())
+	assert.Equal(t, VaultPasswordPattern, responder.Prompts[1].Pattern.String())
+}
+
+func TestRespond(t *testing.T) {
+
+	tests := []struct {
+		desc      string
+		responder *PromptResponder
+		stdout    []string
+		stderr    []string
+		answers   string
+		err       error
+	}{
+		{
+			desc:      "Testing answer the become password prompt",
+			responder: NewPromptResponder(WithBecomePassword("become")),
+			stdout:    []string{"PLAY [all] ****\n", "BECOME password: "},
+			answers:   "become\n",
+		},
+		{
+			desc:      "Testing answer the connection and vault password prompts written to stderr",
+			responder: NewPromptResponder(WithConnectionPassword("ssh"), WithVaultPassword("vault")),
+			stderr:    []string{"SSH password: ", "\nVault password (dev): "},
+			answers:   "ssh\nvault\n",
+		},
+		{
+			desc:      "Testing answer a prompt written in several chunks",
+			responder: NewPromptResponder(WithBecomePassword("become")),
+			stdout:    []string{"BECOME ", "pass", "word: "},
+			answers:   "become\n",
+		},
+		{
+			desc:      "Testing answer the step confirmation prompts",
+			responder: NewPromptResponder(WithStepAnswer(StepYes)),
+			stdout:    []string{"Perform task: TASK: ping (N)o/(y)es/(c)ontinue: ", "\n\nPerform task: TASK: debug (N)o/(y)es/(c)ontinue: "},
+			answers:   "y\ny\n",
+		},
+		{
+			desc:      "Testing answer a vars_prompt and its confirmation",
+			responder: NewPromptResponder(WithVarsPrompt("What is your name?", "ansible")),
+			stdout:    []string{"What is your name?: ", "\nconfirm What is your name?: "},
+			answers:   "ansible\nansible\n",
+		},
+		{
+			desc: "Testing answer a prompt using a callback",
+			responder: NewPromptResponder(WithPromptFunc(`Vault password \((\w+)\): $`, func(prompt string) (string, error) {
+				return fmt.Sprintf("password for %s", prompt), nil
+			})),
+			stdout:  []string{"Vault password (prod): "},
+			answers: "password for Vault password (prod): \n",
+		},
+		{
+			desc:      "Testing do not answer the lines that are not prompts",
+			responder: NewPromptResponder(WithBecomePassword("become")),
+			stdout:    []string{"BECOME password: is asked when using --ask-become-pass\n"},
+			answers:   "",
+		},
+		{
+			desc: "Testing close the stdin when the answer callback fails",
+			responder: NewPromptResponder(WithPromptFunc(VaultPasswordPattern, func(prompt string) (string, error) {
+				return "", errors.New("password not found")
+			})),
+			stdout:  []string{"Vault password: "},
+			answers: "",
+			err:     errors.New("error answering prompt 'Vault password: ': password not found"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			stdin := &stdinBuffer{}
+
+			stdout, stderr := test.responder.Respond(stdin)
+			for _, chunk := range test.stdout {
+				_, _ = stdout.Write([]byte(chunk))
+			}
+			for _, chunk := range test.stderr {
+				_, _ = stderr.Write([]byte(chunk))
+			}
+
+			assert.Equal(t, test.answers, stdin.String())
+			if test.err != nil {
+				assert.Equal(t, test.err.Error(), test.responder.Err().Error())
+				assert.True(t, stdin.closed)
+			} else {
+				assert.NoError(t, test.responder.Err())
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/result"
+	defaultresults "github.com/apenella/go-ansible/v2/pkg/execute/result/default"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// CaptureResults is a results outputer that keeps the command stdout as it is, without masking the secrets nor applying any transformer, so it can be parsed once the execution finishes. The rest of outputs, such as stderr, are printed by DefaultResults
+type CaptureResults struct {
+	stdout bytes.Buffer
+}
+
+// NewCaptureResults returns a CaptureResults
+func NewCaptureResults() *CaptureResults {
+	return &CaptureResults{}
+}
+
+// Writer returns the writer that must be set to the executor to write the command stdout, which is the only output captured
+func (r *CaptureResults) Writer() io.Writer {
+	return &r.stdout
+}
+
+// Stdout returns the command stdout captured
+func (r *CaptureResults) Stdout() []byte {
+	return r.stdout.Bytes()
+}
+
+// Print captures the output when the writer is the one returned by the Writer method, and prints the rest of outputs using DefaultResults, which masks the secrets of the redactor carried by the context
+func (r *CaptureResults) Print(ctx context.Context, reader io.Reader, writer io.Writer, options ...result.OptionsFunc) error {
+	errContext := "(capture::CaptureResults::Print)"
+
+	if reader == nil {
+		return errors.New(errContext, "CaptureResults requires a reader to print the output of the execution")
+	}
+
+	if writer != &r.stdout {
+		return defaultresults.NewDefaultResults().Print(ctx, reader, writer, options...)
+	}
+
+	_, err := io.Copy(writer, reader)
+	if err != nil {
+		return errors.New(errContext, "Error capturing the output of the execution", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/redact"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureResultsPrint(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		stdout   bool
+		redactor *redact.Redactor
+		res      string
+		captured string
+	}{
+		{
+			desc:     "Testing capture the stdout as it is",
+			input:    "{\n  \"password\": \"s3cr3t\"\n}\n",
+			stdout:   true,
+			redactor: redact.NewRedactor("s3cr3t"),
+			captured: "{\n  \"password\": \"s3cr3t\"\n}\n",
+		},
+		{
+			desc:     "Testing print the rest of outputs using DefaultResults",
+			input:    "[WARNING]: password s3cr3t",
+			redactor: redact.NewRedactor("s3cr3t"),
+			res:      "[WARNING]: password " + redact.RedactedMask + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			var writer bytes.Buffer
+
+			results := NewCaptureResults()
+
+			w := io.Writer(&writer)
+			if test.stdout {
+				w = results.Writer()
+			}
+
+			err := results.Print(redact.NewContext(context.TODO(), test.redactor), strings.NewReader(test.input), w)
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, writer.String())
+			assert.Equal(t, test.captured, string(results.Stdout()))
+		})
+	}
+
+	t.Run("Testing error printing the output when the reader is not defined", func(t *testing.T) {
+		err := NewCaptureResults().Print(context.TODO(), nil, io.Discard)
+		assert.Equal(t, errors.New("(capture::CaptureResults::Print)", "CaptureResults requires a reader to print the output of the execution"), err)
+	})
+}
@@ -43,7 +43,7 @@ func (r *DefaultResults) Options(options ...result.OptionsFunc) {
 	}
 }
 
-// Print method prints the to the DefaultResults writer the date received as input
+// Print method prints the to the DefaultResults writer the date received as input. The secrets of the redactor carried by the context are masked, or the ones registered on the redact package when it does not carry any
 func (r *DefaultResults) Print(ctx context.Context, reader io.Reader, writer io.Writer, options ...result.OptionsFunc) error {
 	var transformers []transformer.TransformerFunc
 
@@ -51,6 +51,9 @@ func (r *DefaultResults) Print(ctx context.Context, reader io.Reader, writer io.
 
 	r.Options(options...)
 
+	// the secrets are masked before applying the transformers, so they can not split them
+	transformers = append(transformers, transformer.Redact(ctx))
+
 	if len(r.trans) > 0 {
 		transformers = append(transformers, transformer.Prepend(PrefixTokenSeparator))
 	}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/apenella/go-ansible/v2/mocks"
 	"github.com/apenella/go-ansible/v2/pkg/execute/result/transformer"
+	"github.com/apenella/go-ansible/v2/pkg/redact"
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -113,6 +114,22 @@ Playbook run took 0 days, 0 hours, 0 minutes, 0 seconds
 	}
 }
 
+func TestPrintRedact(t *testing.T) {
+	t.Run("Testing print masking the secrets of the redactor carried by the context of each call", func(t *testing.T) {
+		results := NewDefaultResults()
+
+		first := &bytes.Buffer{}
+		err := results.Print(redact.NewContext(context.TODO(), redact.NewRedactor("s3cr3t")), strings.NewReader("s3cr3t t0k3n"), first)
+		assert.NoError(t, err)
+		assert.Equal(t, redact.RedactedMask+" t0k3n\n", first.String())
+
+		second := &bytes.Buffer{}
+		err = results.Print(redact.NewContext(context.TODO(), redact.NewRedactor("t0k3n")), strings.NewReader("s3cr3t t0k3n"), second)
+		assert.NoError(t, err)
+		assert.Equal(t, "s3cr3t "+redact.RedactedMask+"\n", second.String())
+	})
+}
+
 func TestOutput(t *testing.T) {
 
 	buff := bytes.Buffer{}
@@ -0,0 +1,38 @@
+package json
+
+import (
+	"context"
+)
+
+// JSONLEventHandler is the interface to receive the typed events decoded from the ansible.posix.jsonl callback plugin output as they happen
+type JSONLEventHandler interface {
+	HandleEvent(ctx context.Context, event JSONLEvent) error
+}
+
+// JSONLEventHandlerFunc is an adapter to use ordinary functions as JSONLEventHandler
+type JSONLEventHandlerFunc func(ctx context.Context, event JSONLEvent) error
+
+// HandleEvent calls f(ctx, event)
+func (f JSONLEventHandlerFunc) HandleEvent(ctx context.Context, event JSONLEvent) error {
+	return f(ctx, event)
+}
+
+// JSONLEventChannelHandler is a JSONLEventHandler that sends the events to a channel. The channel is not closed by the handler.
+type JSONLEventChannelHandler struct {
+	events chan<- JSONLEvent
+}
+
+// NewJSONLEventChannelHandler returns a JSONLEventChannelHandler that sends the events to the provided channel
+func NewJSONLEventChannelHandler(events chan<- JSONLEvent) *JSONLEventChannelHandler {
+	return &JSONLEventChannelHandler{events: events}
+}
+
+// HandleEvent sends the event to the channel. It waits until the event is received or the context is done
+func (h *JSONLEventChannelHandler) HandleEvent(ctx context.Context, event JSONLEvent) error {
+	select {
+	case h.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -15,7 +15,8 @@ import (
 
 // JSONLEventStdoutCallbackResults handles the ansible.posix.jsonl callback plugin output
 type JSONLEventStdoutCallbackResults struct {
-	trans []transformer.TransformerFunc
+	trans    []transformer.TransformerFunc
+	handlers []JSONLEventHandler
 }
 
 // NewJSONLEventStdoutCallbackResults creates a new JSONLEventStdoutCallbackResults instance
@@ -32,6 +33,13 @@ func WithJSONLEventTransformers(trans ...transformer.TransformerFunc) result.Opt
 	}
 }
 
+// WithJSONLEventHandlers sets a list of handlers that receive the decoded events as they happen
+func WithJSONLEventHandlers(handlers ...JSONLEventHandler) result.OptionsFunc {
+	return func(r result.ResultsOutputer) {
+		r.(*JSONLEventStdoutCallbackResults).handlers = append(r.(*JSONLEventStdoutCallbackResults).handlers, handlers...)
+	}
+}
+
 // Options executes the options functions received as a parameters to set the JSONLEventStdoutCallbackResults attributes
 func (r *JSONLEventStdoutCallbackResults) Options(options ...result.OptionsFunc) {
 	for _, opt := range options {
@@ -39,11 +47,22 @@ func (r *JSONLEventStdoutCallbackResults) Options(options ...result.OptionsFunc)
 	}
 }
 
-// Print handles the ansible.posix.jsonl callback plugin output
+// jsonlEventLine is a line read from the ansible.posix.jsonl callback plugin output
+type jsonlEventLine struct {
+	// data is the line to be written, once the transformers are applied
+	data []byte
+	// event is the decoded event. It is only set when there are handlers defined
+	event JSONLEvent
+}
+
+// Print handles the ansible.posix.jsonl callback plugin output. When handlers are defined, each line is decoded and the resulting event is delivered to the handlers after writing the line to the writer. The secrets of the redactor carried by the context are masked, or the ones registered on the redact package when it does not carry any
 func (r *JSONLEventStdoutCallbackResults) Print(ctx context.Context, reader io.Reader, writer io.Writer, options ...result.OptionsFunc) error {
-	printChan := make(chan []byte)
+	printChan := make(chan jsonlEventLine)
 	errChan := make(chan error)
 	done := make(chan struct{})
+	// stop is closed when Print returns, so the reading goroutine does not block sending lines that are no longer received
+	stop := make(chan struct{})
+	defer close(stop)
 
 	errContext := "(result::json::JSONLEventStdoutCallbackResults::Print)"
 
@@ -57,45 +76,88 @@ func (r *JSONLEventStdoutCallbackResults) Print(ctx context.Context, reader io.R
 
 	r.Options(options...)
 
+	redactTransformer := transformer.Redact(ctx)
+
 	go func() {
 		defer close(printChan)
 		defer close(errChan)
 		defer close(done)
 
 		errs := []error{}
+		stopped := false
 
 		for data, err := range readResultsStream(reader) {
+			// once Print has returned, the output is still read to the end so the command does not block writing to its stdout
+			if stopped {
+				continue
+			}
+
 			if err != nil {
 				errs = append(errs, err)
 				continue
 			}
 
-			// TransformerFunc expects and returns a string so we need to convert the byte array to a string and back
-			if len(r.trans) > 0 {
-				dataString := string(data)
-				for _, t := range r.trans {
-					dataString = t(dataString)
+			line := jsonlEventLine{}
+
+			// the secrets are masked before decoding the event, so the handlers do not receive them either
+			dataString := redactTransformer(string(data))
+
+			if len(r.handlers) > 0 {
+				line.event, err = ParseJSONLEvent([]byte(dataString))
+				if err != nil {
+					errs = append(errs, err)
+					continue
 				}
-				data = []byte(dataString)
 			}
 
-			printChan <- data
+			// TransformerFunc expects and returns a string so we need to convert the byte array to a string and back
+			for _, t := range r.trans {
+				dataString = t(dataString)
+			}
+			data = []byte(dataString)
+
+			line.data = data
+			select {
+			case printChan <- line:
+			case <-stop:
+				stopped = true
+			}
+		}
+
+		if stopped {
+			return
 		}
 
 		if len(errs) > 0 {
-			errChan <- errors.New(errContext, "Error processing the execution output", errs...)
+			select {
+			case errChan <- errors.New(errContext, "Error processing the execution output", errs...):
+			case <-stop:
+				return
+			}
 		}
 
-		done <- struct{}{}
+		select {
+		case done <- struct{}{}:
+		case <-stop:
+		}
 	}()
 
 	for {
 		select {
-		case data := <-printChan:
-			_, err := writer.Write(data)
+		case line := <-printChan:
+			_, err := writer.Write(line.data)
 			if err != nil {
 				return errors.New(errContext, "Error writing to writer", err)
 			}
+
+			if line.event != nil {
+				for _, handler := range r.handlers {
+					err = handler.HandleEvent(ctx, line.event)
+					if err != nil {
+						return errors.New(errContext, fmt.Sprintf("Error handling event '%s'", line.event.Name()), err)
+					}
+				}
+			}
 		case err := <-errChan:
 			if err != nil {
 				return errors.New(errContext, "Error reading the results stream", err)
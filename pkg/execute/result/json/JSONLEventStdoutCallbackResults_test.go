@@ -6,7 +6,9 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/apenella/go-ansible/v2/pkg/redact"
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -115,3 +117,118 @@ func TestJSONLEventStdoutCallbackResults_Print(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONLEventStdoutCallbackResults_PrintWithHandlers(t *testing.T) {
+
+	t.Run("Testing JSONLEventStdoutCallbackResults Print method delivers the decoded events to the handlers", func(t *testing.T) {
+		received := []JSONLEvent{}
+		writer := &strings.Builder{}
+
+		results := NewJSONLEventStdoutCallbackResults(
+			WithJSONLEventHandlers(JSONLEventHandlerFunc(func(ctx context.Context, event JSONLEvent) error {
+				received = append(received, event)
+				return nil
+			})),
+		)
+
+		err := results.Print(context.TODO(), strings.NewReader(events), writer)
+		assert.Nil(t, err)
+		assert.Equal(t, events, writer.String())
+		assert.Len(t, received, 1)
+		assert.IsType(t, &JSONLPlayStartEvent{}, received[0])
+	})
+
+	t.Run("Testing JSONLEventStdoutCallbackResults Print method delivers the decoded events to the handlers with the secrets masked", func(t *testing.T) {
+		received := []JSONLEvent{}
+		writer := &strings.Builder{}
+
+		results := NewJSONLEventStdoutCallbackResults(
+			WithJSONLEventHandlers(JSONLEventHandlerFunc(func(ctx context.Context, event JSONLEvent) error {
+				received = append(received, event)
+				return nil
+			})),
+		)
+
+		err := results.Print(redact.NewContext(context.TODO(), redact.NewRedactor("all")), strings.NewReader(events), writer)
+		assert.Nil(t, err)
+		assert.Equal(t, strings.Replace(events, `"name":"all"`, `"name":"`+redact.RedactedMask+`"`, 1), writer.String())
+		assert.Len(t, received, 1)
+		assert.Equal(t, redact.RedactedMask, received[0].(*JSONLPlayStartEvent).Play.Name)
+	})
+
+	t.Run("Testing JSONLEventStdoutCallbackResults Print method masks the secrets escaped on the events", func(t *testing.T) {
+		received := []JSONLEvent{}
+		writer := &strings.Builder{}
+
+		results := NewJSONLEventStdoutCallbackResults(
+			WithJSONLEventHandlers(JSONLEventHandlerFunc(func(ctx context.Context, event JSONLEvent) error {
+				received = append(received, event)
+				return nil
+			})),
+		)
+
+		escapedEvents := strings.Replace(events, `"name":"all"`, `"name":"a\"l&l"`, 1)
+		err := results.Print(redact.NewContext(context.TODO(), redact.NewRedactor(`a"l&l`)), strings.NewReader(escapedEvents), writer)
+		assert.Nil(t, err)
+		assert.Equal(t, strings.Replace(events, `"name":"all"`, `"name":"`+redact.RedactedMask+`"`, 1), writer.String())
+		assert.Len(t, received, 1)
+		assert.Equal(t, redact.RedactedMask, received[0].(*JSONLPlayStartEvent).Play.Name)
+	})
+
+	t.Run("Testing JSONLEventStdoutCallbackResults Print method delivers the decoded events to a channel", func(t *testing.T) {
+		eventsChan := make(chan JSONLEvent, 1)
+
+		results := NewJSONLEventStdoutCallbackResults(
+			WithJSONLEventHandlers(NewJSONLEventChannelHandler(eventsChan)),
+		)
+
+		err := results.Print(context.TODO(), strings.NewReader(events), io.Discard)
+		assert.Nil(t, err)
+
+		event := <-eventsChan
+		assert.Equal(t, JSONLEventPlaybookOnPlayStart, event.Name())
+	})
+
+	t.Run("Testing error in JSONLEventStdoutCallbackResults Print method when a handler returns an error", func(t *testing.T) {
+		results := NewJSONLEventStdoutCallbackResults(
+			WithJSONLEventHandlers(JSONLEventHandlerFunc(func(ctx context.Context, event JSONLEvent) error {
+				return fmt.Errorf("error from handler")
+			})),
+		)
+
+		err := results.Print(context.TODO(), strings.NewReader(events), io.Discard)
+		assert.Equal(t, errors.New("(result::json::JSONLEventStdoutCallbackResults::Print)", "Error handling event 'v2_playbook_on_play_start'", fmt.Errorf("error from handler")).Error(), err.Error())
+	})
+
+	t.Run("Testing JSONLEventStdoutCallbackResults Print method keeps reading the output after a handler returns an error", func(t *testing.T) {
+		reader, writer := io.Pipe()
+		written := make(chan struct{})
+
+		go func() {
+			defer close(written)
+			defer writer.Close()
+
+			for i := 0; i < 5; i++ {
+				_, err := io.WriteString(writer, events+"\n")
+				if err != nil {
+					return
+				}
+			}
+		}()
+
+		results := NewJSONLEventStdoutCallbackResults(
+			WithJSONLEventHandlers(JSONLEventHandlerFunc(func(ctx context.Context, event JSONLEvent) error {
+				return fmt.Errorf("error from handler")
+			})),
+		)
+
+		err := results.Print(context.TODO(), reader, io.Discard)
+		assert.Error(t, err)
+
+		select {
+		case <-written:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the command output is not read after Print returns")
+		}
+	})
+}
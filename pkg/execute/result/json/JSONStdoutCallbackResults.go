@@ -41,7 +41,7 @@ func (r *JSONStdoutCallbackResults) Options(options ...result.OptionsFunc) {
 	}
 }
 
-// Print method manges the ansible' JSON stdout callback and print the result stats
+// Print method manges the ansible' JSON stdout callback and print the result stats. The secrets of the redactor carried by the context are masked, or the ones registered on the redact package when it does not carry any
 func (r *JSONStdoutCallbackResults) Print(ctx context.Context, reader io.Reader, writer io.Writer, options ...result.OptionsFunc) error {
 	var transformers []transformer.TransformerFunc
 
@@ -62,6 +62,9 @@ func (r *JSONStdoutCallbackResults) Print(ctx context.Context, reader io.Reader,
 
 	r.Options(options...)
 
+	// the secrets are masked before applying the transformers, so they can not split them
+	transformers = append(transformers, transformer.Redact(ctx))
+
 	if len(r.trans) > 0 {
 		transformers = append(transformers, transformer.Prepend(PrefixTokenSeparator))
 	}
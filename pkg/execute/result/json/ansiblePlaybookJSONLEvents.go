@@ -0,0 +1,177 @@
+package json
+
+import (
+	"encoding/json"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// JSONLEventPlaybookOnPlayStart is the event emitted by ansible.posix.jsonl when a play starts
+	JSONLEventPlaybookOnPlayStart = "v2_playbook_on_play_start"
+	// JSONLEventPlaybookOnTaskStart is the event emitted by ansible.posix.jsonl when a task starts
+	JSONLEventPlaybookOnTaskStart = "v2_playbook_on_task_start"
+	// JSONLEventPlaybookOnHandlerTaskStart is the event emitted by ansible.posix.jsonl when a handler task starts
+	JSONLEventPlaybookOnHandlerTaskStart = "v2_playbook_on_handler_task_start"
+	// JSONLEventRunnerOnStart is the event emitted by ansible.posix.jsonl when a task starts on a host
+	JSONLEventRunnerOnStart = "v2_runner_on_start"
+	// JSONLEventRunnerOnOk is the event emitted by ansible.posix.jsonl when a task succeeds on a host
+	JSONLEventRunnerOnOk = "v2_runner_on_ok"
+	// JSONLEventRunnerOnFailed is the event emitted by ansible.posix.jsonl when a task fails on a host
+	JSONLEventRunnerOnFailed = "v2_runner_on_failed"
+	// JSONLEventRunnerOnSkipped is the event emitted by ansible.posix.jsonl when a task is skipped on a host
+	JSONLEventRunnerOnSkipped = "v2_runner_on_skipped"
+	// JSONLEventRunnerOnUnreachable is the event emitted by ansible.posix.jsonl when a host is unreachable
+	JSONLEventRunnerOnUnreachable = "v2_runner_on_unreachable"
+	// JSONLEventPlaybookOnStats is the event emitted by ansible.posix.jsonl when the playbook finishes
+	JSONLEventPlaybookOnStats = "v2_playbook_on_stats"
+)
+
+// JSONLEvent is the interface implemented by every typed event decoded from the ansible.posix.jsonl callback plugin output
+type JSONLEvent interface {
+	// Name returns the ansible callback event name, such as v2_runner_on_ok
+	Name() string
+	// Time returns the timestamp reported by the callback plugin
+	Time() string
+	// Raw returns the event as it was decoded from the callback plugin output
+	Raw() *AnsiblePlaybookJSONLEventResults
+}
+
+// JSONLEventBase holds the attributes shared by all the ansible.posix.jsonl events
+type JSONLEventBase struct {
+	raw *AnsiblePlaybookJSONLEventResults
+}
+
+// Name returns the ansible callback event name
+func (e *JSONLEventBase) Name() string {
+	return e.raw.Event
+}
+
+// Time returns the timestamp reported by the callback plugin
+func (e *JSONLEventBase) Time() string {
+	return e.raw.Timestamp
+}
+
+// Raw returns the event as it was decoded from the callback plugin output
+func (e *JSONLEventBase) Raw() *AnsiblePlaybookJSONLEventResults {
+	return e.raw
+}
+
+// JSONLPlayStartEvent represents a v2_playbook_on_play_start event
+type JSONLPlayStartEvent struct {
+	JSONLEventBase
+	Play *AnsiblePlaybookJSONResultsPlaysPlay
+}
+
+// JSONLTaskStartEvent represents a v2_playbook_on_task_start or a v2_playbook_on_handler_task_start event
+type JSONLTaskStartEvent struct {
+	JSONLEventBase
+	Task *AnsiblePlaybookJSONResultsPlayTaskItem
+	// Handler is true when the task is a handler
+	Handler bool
+}
+
+// JSONLRunnerEvent holds the attributes of the events related to a task running on a host
+type JSONLRunnerEvent struct {
+	JSONLEventBase
+	Task  *AnsiblePlaybookJSONResultsPlayTaskItem
+	Hosts map[string]*AnsiblePlaybookJSONResultsPlayTaskHostsItem
+}
+
+// HostNames returns the names of the hosts reported in the event
+func (e *JSONLRunnerEvent) HostNames() []string {
+	hosts := make([]string, 0, len(e.Hosts))
+	for host := range e.Hosts {
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// JSONLRunnerStartEvent represents a v2_runner_on_start event
+type JSONLRunnerStartEvent struct {
+	JSONLRunnerEvent
+}
+
+// JSONLRunnerOkEvent represents a v2_runner_on_ok event
+type JSONLRunnerOkEvent struct {
+	JSONLRunnerEvent
+}
+
+// JSONLRunnerFailedEvent represents a v2_runner_on_failed event
+type JSONLRunnerFailedEvent struct {
+	JSONLRunnerEvent
+}
+
+// JSONLRunnerSkippedEvent represents a v2_runner_on_skipped event
+type JSONLRunnerSkippedEvent struct {
+	JSONLRunnerEvent
+}
+
+// JSONLRunnerUnreachableEvent represents a v2_runner_on_unreachable event
+type JSONLRunnerUnreachableEvent struct {
+	JSONLRunnerEvent
+}
+
+// JSONLStatsEvent represents a v2_playbook_on_stats event
+type JSONLStatsEvent struct {
+	JSONLEventBase
+	Stats             map[string]*AnsiblePlaybookJSONResultsStats
+	CustomStats       interface{}
+	GlobalCustomStats interface{}
+}
+
+// JSONLGenericEvent represents any ansible.posix.jsonl event that does not have a specific type
+type JSONLGenericEvent struct {
+	JSONLEventBase
+}
+
+// NewJSONLEvent returns the typed event that corresponds to the AnsiblePlaybookJSONLEventResults
+func NewJSONLEvent(raw *AnsiblePlaybookJSONLEventResults) JSONLEvent {
+
+	base := JSONLEventBase{raw: raw}
+	runner := JSONLRunnerEvent{
+		JSONLEventBase: base,
+		Task:           raw.Task,
+		Hosts:          raw.Hosts,
+	}
+
+	switch raw.Event {
+	case JSONLEventPlaybookOnPlayStart:
+		return &JSONLPlayStartEvent{JSONLEventBase: base, Play: raw.Play}
+	case JSONLEventPlaybookOnTaskStart:
+		return &JSONLTaskStartEvent{JSONLEventBase: base, Task: raw.Task}
+	case JSONLEventPlaybookOnHandlerTaskStart:
+		return &JSONLTaskStartEvent{JSONLEventBase: base, Task: raw.Task, Handler: true}
+	case JSONLEventRunnerOnStart:
+		return &JSONLRunnerStartEvent{runner}
+	case JSONLEventRunnerOnOk:
+		return &JSONLRunnerOkEvent{runner}
+	case JSONLEventRunnerOnFailed:
+		return &JSONLRunnerFailedEvent{runner}
+	case JSONLEventRunnerOnSkipped:
+		return &JSONLRunnerSkippedEvent{runner}
+	case JSONLEventRunnerOnUnreachable:
+		return &JSONLRunnerUnreachableEvent{runner}
+	case JSONLEventPlaybookOnStats:
+		return &JSONLStatsEvent{
+			JSONLEventBase:    base,
+			Stats:             raw.Stats,
+			CustomStats:       raw.CustomStats,
+			GlobalCustomStats: raw.GlobalCustomStats,
+		}
+	default:
+		return &JSONLGenericEvent{JSONLEventBase: base}
+	}
+}
+
+// ParseJSONLEvent decodes a line generated by the ansible.posix.jsonl callback plugin into a typed event
+func ParseJSONLEvent(data []byte) (JSONLEvent, error) {
+	raw := &AnsiblePlaybookJSONLEventResults{}
+
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return nil, errors.New("(results::ParseJSONLEvent)", "Unmarshall error", err)
+	}
+
+	return NewJSONLEvent(raw), nil
+}
@@ -0,0 +1,138 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJSONLEvent(t *testing.T) {
+
+	tests := []struct {
+		desc       string
+		input      string
+		err        error
+		assertFunc func(t *testing.T, event JSONLEvent)
+	}{
+		{
+			desc:  "Testing parse a v2_playbook_on_play_start event",
+			input: `{"_event":"v2_playbook_on_play_start","_timestamp":"2025-04-01T05:17:36.646328Z","play":{"duration":{"start":"2025-04-01T05:17:36.646322Z"},"id":"play-id","name":"all","path":"site.yml:3"},"tasks":[]}`,
+			assertFunc: func(t *testing.T, event JSONLEvent) {
+				e, ok := event.(*JSONLPlayStartEvent)
+				assert.True(t, ok)
+				assert.Equal(t, JSONLEventPlaybookOnPlayStart, e.Name())
+				assert.Equal(t, "2025-04-01T05:17:36.646328Z", e.Time())
+				assert.Equal(t, "all", e.Play.Name)
+				assert.Equal(t, "play-id", e.Play.Id)
+			},
+		},
+		{
+			desc:  "Testing parse a v2_playbook_on_task_start event",
+			input: `{"_event":"v2_playbook_on_task_start","_timestamp":"2025-04-01T05:17:36.646328Z","task":{"id":"task-id","name":"task-name","path":"site.yml:7"}}`,
+			assertFunc: func(t *testing.T, event JSONLEvent) {
+				e, ok := event.(*JSONLTaskStartEvent)
+				assert.True(t, ok)
+				assert.False(t, e.Handler)
+				assert.Equal(t, "task-name", e.Task.Name)
+			},
+		},
+		{
+			desc:  "Testing parse a v2_playbook_on_handler_task_start event",
+			input: `{"_event":"v2_playbook_on_handler_task_start","_timestamp":"2025-04-01T05:17:36.646328Z","task":{"id":"task-id","name":"handler-name","path":"site.yml:7"}}`,
+			assertFunc: func(t *testing.T, event JSONLEvent) {
+				e, ok := event.(*JSONLTaskStartEvent)
+				assert.True(t, ok)
+				assert.True(t, e.Handler)
+				assert.Equal(t, "handler-name", e.Task.Name)
+			},
+		},
+		{
+			desc:  "Testing parse a v2_runner_on_start event",
+			input: `{"_event":"v2_runner_on_start","_timestamp":"2025-04-01T05:17:36.646328Z","hosts":{"127.0.0.1":{}},"task":{"id":"task-id","name":"task-name"}}`,
+			assertFunc: func(t *testing.T, event JSONLEvent) {
+				e, ok := event.(*JSONLRunnerStartEvent)
+				assert.True(t, ok)
+				assert.Equal(t, []string{"127.0.0.1"}, e.HostNames())
+			},
+		},
+		{
+			desc:  "Testing parse a v2_runner_on_ok event",
+			input: `{"_event":"v2_runner_on_ok","_timestamp":"2025-04-01T05:17:36.646328Z","hosts":{"127.0.0.1":{"action":"debug","changed":false,"msg":"Hello"}},"task":{"id":"task-id","name":"task-name"}}`,
+			assertFunc: func(t *testing.T, event JSONLEvent) {
+				e, ok := event.(*JSONLRunnerOkEvent)
+				assert.True(t, ok)
+				assert.Equal(t, "task-name", e.Task.Name)
+				assert.Equal(t, "Hello", e.Hosts["127.0.0.1"].Msg)
+			},
+		},
+		{
+			desc:  "Testing parse a v2_runner_on_failed event",
+			input: `{"_event":"v2_runner_on_failed","_timestamp":"2025-04-01T05:17:36.646328Z","hosts":{"127.0.0.1":{"action":"fail","failed":true,"msg":"Failed"}},"task":{"id":"task-id","name":"task-name"}}`,
+			assertFunc: func(t *testing.T, event JSONLEvent) {
+				e, ok := event.(*JSONLRunnerFailedEvent)
+				assert.True(t, ok)
+				assert.True(t, e.Hosts["127.0.0.1"].Failed)
+			},
+		},
+		{
+			desc:  "Testing parse a v2_runner_on_skipped event",
+			input: `{"_event":"v2_runner_on_skipped","_timestamp":"2025-04-01T05:17:36.646328Z","hosts":{"127.0.0.1":{"skipped":true,"skip_reason":"Conditional result was False"}},"task":{"id":"task-id","name":"task-name"}}`,
+			assertFunc: func(t *testing.T, event JSONLEvent) {
+				e, ok := event.(*JSONLRunnerSkippedEvent)
+				assert.True(t, ok)
+				assert.Equal(t, "Conditional result was False", e.Hosts["127.0.0.1"].SkipReason)
+			},
+		},
+		{
+			desc:  "Testing parse a v2_runner_on_unreachable event",
+			input: `{"_event":"v2_runner_on_unreachable","_timestamp":"2025-04-01T05:17:36.646328Z","hosts":{"127.0.0.1":{"unreachable":true}},"task":{"id":"task-id","name":"task-name"}}`,
+			assertFunc: func(t *testing.T, event JSONLEvent) {
+				e, ok := event.(*JSONLRunnerUnreachableEvent)
+				assert.True(t, ok)
+				assert.True(t, e.Hosts["127.0.0.1"].Unreachable)
+			},
+		},
+		{
+			desc:  "Testing parse a v2_playbook_on_stats event",
+			input: `{"_event":"v2_playbook_on_stats","_timestamp":"2025-04-01T05:17:36.646328Z","stats":{"127.0.0.1":{"changed":1,"failures":0,"ignored":0,"ok":2,"rescued":0,"skipped":0,"unreachable":0}},"custom_stats":{},"global_custom_stats":{}}`,
+			assertFunc: func(t *testing.T, event JSONLEvent) {
+				e, ok := event.(*JSONLStatsEvent)
+				assert.True(t, ok)
+				assert.Equal(t, 2, e.Stats["127.0.0.1"].Ok)
+				assert.Equal(t, 1, e.Stats["127.0.0.1"].Changed)
+			},
+		},
+		{
+			desc:  "Testing parse an event without specific type",
+			input: `{"_event":"v2_runner_item_on_ok","_timestamp":"2025-04-01T05:17:36.646328Z"}`,
+			assertFunc: func(t *testing.T, event JSONLEvent) {
+				e, ok := event.(*JSONLGenericEvent)
+				assert.True(t, ok)
+				assert.Equal(t, "v2_runner_item_on_ok", e.Name())
+				assert.Equal(t, "v2_runner_item_on_ok", e.Raw().Event)
+			},
+		},
+		{
+			desc:  "Testing error parsing an invalid event",
+			input: `{"_event":"v2_playbook_on_play_start",`,
+			err:   assert.AnError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			event, err := ParseJSONLEvent([]byte(test.input))
+			if test.err != nil {
+				assert.Error(t, err)
+				assert.Nil(t, event)
+				return
+			}
+
+			assert.Nil(t, err)
+			test.assertFunc(t, event)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 
 	errors "github.com/apenella/go-common-utils/error"
@@ -59,6 +60,32 @@ func (r *AnsiblePlaybookJSONResults) CheckStats() error {
 	return nil
 }
 
+// FailedHosts returns the sorted list of hosts that finished with failures
+func (r *AnsiblePlaybookJSONResults) FailedHosts() []string {
+	hosts := []string{}
+	for host, stats := range r.Stats {
+		if stats.Failures > 0 {
+			hosts = append(hosts, host)
+		}
+	}
+	sort.Strings(hosts)
+
+	return hosts
+}
+
+// UnreachableHosts returns the sorted list of hosts that were unreachable
+func (r *AnsiblePlaybookJSONResults) UnreachableHosts() []string {
+	hosts := []string{}
+	for host, stats := range r.Stats {
+		if stats.Unreachable > 0 {
+			hosts = append(hosts, host)
+		}
+	}
+	sort.Strings(hosts)
+
+	return hosts
+}
+
 // AnsiblePlaybookJSONResultsPlay represents the structure of the JSON generated by an Ansible playbook execution using the JSON callback plugin
 //
 // https://github.com/ansible-collections/ansible.posix/blob/main/plugins/callback/json.py#L80
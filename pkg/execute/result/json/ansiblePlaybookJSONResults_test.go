@@ -51,6 +51,21 @@ Host: 127.0.0.1
 	}
 }
 
+func TestAnsiblePlaybookJSONResultsFailedAndUnreachableHosts(t *testing.T) {
+	t.Log("Testing FailedHosts and UnreachableHosts methods")
+
+	results := &AnsiblePlaybookJSONResults{
+		Stats: map[string]*AnsiblePlaybookJSONResultsStats{
+			"host3": {Failures: 2},
+			"host1": {Failures: 1, Unreachable: 1},
+			"host2": {Ok: 1},
+		},
+	}
+
+	assert.Equal(t, []string{"host1", "host3"}, results.FailedHosts())
+	assert.Equal(t, []string{"host1"}, results.UnreachableHosts())
+}
+
 func TestAnsiblePlaybookJSONResultsStatsString(t *testing.T) {
 	tests := []struct {
 		desc  string
@@ -1,9 +1,12 @@
 package transformer
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"time"
+
+	"github.com/apenella/go-ansible/v2/pkg/redact"
 )
 
 const (
@@ -54,6 +57,15 @@ func IgnoreMessage(skipPatterns []string) TransformerFunc {
 	}
 }
 
+// Redact is a transformer function that masks the secrets of the redactor carried by the context, which is the redactor of the execution on the context received by the outputers Print method. The secrets registered on the redact package are masked when the context does not carry any redactor
+func Redact(ctx context.Context) TransformerFunc {
+	redactor := redact.FromContext(ctx)
+
+	return func(message string) string {
+		return redactor.Redact(message)
+	}
+}
+
 // Now returns a time value according to layout
 func Now(layout string) string {
 	return time.Now().Format(layout)
@@ -1,9 +1,11 @@
 package transformer
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/apenella/go-ansible/v2/pkg/redact"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -50,6 +52,33 @@ func TestLogFormat(t *testing.T) {
 	})
 }
 
+func TestRedact(t *testing.T) {
+	desc := "Testing redact transformer"
+	t.Run(desc, func(t *testing.T) {
+		t.Log(desc)
+
+		redact.AddSecret("s3cr3t")
+		defer redact.RemoveSecret("s3cr3t")
+
+		message := "my s3cr3t message"
+		trans := Redact(context.TODO())
+		message = trans(message)
+
+		assert.Equal(t, "my "+redact.RedactedMask+" message", message)
+	})
+
+	desc = "Testing redact transformer using the redactor carried by the context"
+	t.Run(desc, func(t *testing.T) {
+		t.Log(desc)
+
+		message := "my t0k3n message"
+		trans := Redact(redact.NewContext(context.TODO(), redact.NewRedactor("t0k3n")))
+		message = trans(message)
+
+		assert.Equal(t, "my "+redact.RedactedMask+" message", message)
+	})
+}
+
 func TestIgnoreMessage(t *testing.T) {
 
 	tests := []struct {
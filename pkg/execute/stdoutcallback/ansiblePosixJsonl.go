@@ -16,6 +16,8 @@ const (
 // AnsiblePosixJsonlStdoutCallbackExecute defines an executor to run an ansible command with a ansible posix jsonl stdout callback
 type AnsiblePosixJsonlStdoutCallbackExecute struct {
 	executor ExecutorQuietStdoutCallbackSetter
+	// handlers receive the decoded events as they happen
+	handlers []jsonresults.JSONLEventHandler
 }
 
 // NewAnsiblePosixJsonlStdoutCallbackExecute creates a AnsiblePosixJsonlStdoutCallbackExecute
@@ -29,6 +31,17 @@ func (e *AnsiblePosixJsonlStdoutCallbackExecute) WithExecutor(exec ExecutorQuiet
 	return e
 }
 
+// WithEventHandlers sets the handlers that receive the typed events decoded from the ansible.posix.jsonl output as they happen
+func (e *AnsiblePosixJsonlStdoutCallbackExecute) WithEventHandlers(handlers ...jsonresults.JSONLEventHandler) *AnsiblePosixJsonlStdoutCallbackExecute {
+	e.handlers = append(e.handlers, handlers...)
+	return e
+}
+
+// WithEventChannel sets a channel that receives the typed events decoded from the ansible.posix.jsonl output as they happen. The channel is not closed once the execution finishes
+func (e *AnsiblePosixJsonlStdoutCallbackExecute) WithEventChannel(events chan<- jsonresults.JSONLEvent) *AnsiblePosixJsonlStdoutCallbackExecute {
+	return e.WithEventHandlers(jsonresults.NewJSONLEventChannelHandler(events))
+}
+
 // Execute takes a command and args and runs it, streaming output to stdout
 func (e *AnsiblePosixJsonlStdoutCallbackExecute) Execute(ctx context.Context) error {
 
@@ -37,7 +50,9 @@ func (e *AnsiblePosixJsonlStdoutCallbackExecute) Execute(ctx context.Context) er
 	}
 
 	e.executor.Quiet()
-	e.executor.WithOutput(jsonresults.NewJSONLEventStdoutCallbackResults())
+	e.executor.WithOutput(jsonresults.NewJSONLEventStdoutCallbackResults(
+		jsonresults.WithJSONLEventHandlers(e.handlers...),
+	))
 
 	return configuration.NewAnsibleWithConfigurationSettingsExecute(e.executor,
 		configuration.WithAnsibleStdoutCallback(AnsiblePosixJsonlStdoutCallback),
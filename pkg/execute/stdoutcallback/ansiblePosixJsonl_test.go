@@ -7,6 +7,7 @@ import (
 
 	"github.com/apenella/go-ansible/v2/pkg/execute"
 	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	jsonresults "github.com/apenella/go-ansible/v2/pkg/execute/result/json"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -28,6 +29,26 @@ func TestAnsiblePosixJsonlStdoutCallbackExecute(t *testing.T) {
 		exec.AssertExpectations(t)
 	})
 
+	t.Run("Testing AnsiblePosixJsonl stdout callback execution with event handlers", func(t *testing.T) {
+		exec := execute.NewMockExecute()
+
+		exec.On("Quiet")
+		exec.On("WithOutput", mock.AnythingOfType("*json.JSONLEventStdoutCallbackResults")).Return(exec)
+		exec.On("AddEnvVar", configuration.AnsibleStdoutCallback, AnsiblePosixJsonlStdoutCallback)
+		exec.On("Execute", mock.Anything).Return(nil)
+
+		e := NewAnsiblePosixJsonlStdoutCallbackExecute(exec).
+			WithEventChannel(make(chan jsonresults.JSONLEvent)).
+			WithEventHandlers(jsonresults.JSONLEventHandlerFunc(func(ctx context.Context, event jsonresults.JSONLEvent) error {
+				return nil
+			}))
+		err := e.Execute(context.TODO())
+
+		assert.Nil(t, err)
+		assert.Len(t, e.handlers, 2)
+		exec.AssertExpectations(t)
+	})
+
 	t.Run("Testing error on AnsiblePosixJsonl stdout callback when execute function returns an error", func(t *testing.T) {
 		exec := execute.NewMockExecute()
 
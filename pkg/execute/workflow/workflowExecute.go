@@ -3,18 +3,29 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/apenella/go-ansible/v2/pkg/execute"
-	"github.com/fatih/color"
+	errors "github.com/apenella/go-common-utils/error"
 )
 
 type WorkflowExecute struct {
-	// ExecutorList is a list of executors
+	// ExecutorList is a list of executors. They are run sequentially, before the steps
 	ExecutorList []execute.Executor
+	// Steps is the list of steps. Steps that do not depend on each other run concurrently
+	Steps []*WorkflowStep
+	// Concurrency is the maximum number of steps running at the same time. There is no limit when it is zero or lower
+	Concurrency int
 	// ContinueOnError is a flag to continue on error
 	ContinueOnError bool
-	// Trace is a flag to trace the execution
+	// Trace is a flag to trace the execution. It writes the progress to the standard output using a TraceReporter, unless a Reporter is defined
 	Trace bool
+	// Reporter is notified about the progress of the execution
+	Reporter WorkflowReporter
+	// result is the report of the last execution
+	result *WorkflowResult
 }
 
 // NewWorkflowExecute creates a new WorkflowExecute
@@ -30,47 +41,258 @@ func (e *WorkflowExecute) AppendExecutor(executor execute.Executor) *WorkflowExe
 	return e
 }
 
+// AppendStep appends a step to the workflow
+func (e *WorkflowExecute) AppendStep(step ...*WorkflowStep) *WorkflowExecute {
+	e.Steps = append(e.Steps, step...)
+	return e
+}
+
+// WithConcurrency sets the maximum number of steps running at the same time
+func (e *WorkflowExecute) WithConcurrency(concurrency int) *WorkflowExecute {
+	e.Concurrency = concurrency
+	return e
+}
+
 // WithContinueOnError sets the continue on error flag to true
 func (e *WorkflowExecute) WithContinueOnError() *WorkflowExecute {
 	e.ContinueOnError = true
 	return e
 }
 
+// WithReporter sets the reporter notified about the progress of the execution
+func (e *WorkflowExecute) WithReporter(reporter WorkflowReporter) *WorkflowExecute {
+	e.Reporter = reporter
+	return e
+}
+
 // WithTrace sets the trace flag to true
 func (e *WorkflowExecute) WithTrace() *WorkflowExecute {
 	e.Trace = true
 	return e
 }
 
-// Execute runs the executors
-func (e *WorkflowExecute) Execute(ctx context.Context) error {
-	var errList []error = make([]error, 0)
+// steps returns the steps to run. The executors from ExecutorList are converted to steps where each one depends on the previous one
+func (e *WorkflowExecute) steps() []*WorkflowStep {
+	steps := make([]*WorkflowStep, 0, len(e.ExecutorList)+len(e.Steps))
+
+	for i, executor := range e.ExecutorList {
+		step := NewWorkflowStep(fmt.Sprintf("task-%d", i+1), executor)
+		if i > 0 {
+			step.DependsOn = []string{steps[i-1].Name}
+		}
+		steps = append(steps, step)
+	}
+
+	return append(steps, e.Steps...)
+}
+
+// validateSteps checks that the step names are unique, that the retries are not negative, that the steps running on failure have dependencies, that the dependencies exist and that there are no dependency cycles
+func validateSteps(steps []*WorkflowStep) error {
+
+	errContext := "(workflow::validateSteps)"
+
+	pending := make(map[string]int, len(steps))
+	dependants := make(map[string][]string, len(steps))
 
-	for executionNum, executor := range e.ExecutorList {
+	for _, step := range steps {
+		if step == nil || step.Executor == nil {
+			return errors.New(errContext, "Workflow steps require an executor")
+		}
+
+		if _, exists := pending[step.Name]; exists {
+			return errors.New(errContext, fmt.Sprintf("Step '%s' is defined more than once", step.Name))
+		}
 
-		if e.Trace {
-			color.Blue(fmt.Sprintf("\n\u2022 executing task %d out of %d\n", executionNum+1, len(e.ExecutorList)))
+		if step.Retries < 0 {
+			return errors.New(errContext, fmt.Sprintf("Step '%s' has a negative number of retries", step.Name))
 		}
+		pending[step.Name] = len(step.DependsOn)
 
-		err := executor.Execute(ctx)
-		if err != nil {
-			errList = append(errList, err)
+		if step.condition() == OnFailure && len(step.DependsOn) == 0 {
+			return errors.New(errContext, fmt.Sprintf("Step '%s' runs on failure but it does not depend on any step", step.Name))
+		}
+	}
 
-			if !e.ContinueOnError {
-				// leave the loop when the continue on error flag is false which is the default behaviour
-				break
+	for _, step := range steps {
+		for _, dependency := range step.DependsOn {
+			if _, exists := pending[dependency]; !exists {
+				return errors.New(errContext, fmt.Sprintf("Step '%s' depends on the undefined step '%s'", step.Name, dependency))
 			}
+			dependants[dependency] = append(dependants[dependency], step.Name)
 		}
 	}
 
-	if len(errList) > 0 {
-		errs := errList[0]
-		for _, err := range errList[1:] {
-			errs = fmt.Errorf("%s\n%s", errs, err)
+	// Kahn's algorithm: when there is a cycle, some steps are never released
+	released := make([]string, 0, len(steps))
+	for name, count := range pending {
+		if count == 0 {
+			released = append(released, name)
+		}
+	}
+
+	visited := 0
+	for len(released) > 0 {
+		name := released[0]
+		released = released[1:]
+		visited++
+
+		for _, dependant := range dependants[name] {
+			pending[dependant]--
+			if pending[dependant] == 0 {
+				released = append(released, dependant)
+			}
 		}
+	}
 
-		return errs
+	if visited != len(steps) {
+		return errors.New(errContext, "Workflow steps have a dependency cycle")
 	}
 
 	return nil
 }
+
+// reporter returns the reporter to notify the workflow progress
+func (e *WorkflowExecute) reporter() WorkflowReporter {
+	if e.Reporter != nil {
+		return e.Reporter
+	}
+
+	if e.Trace {
+		return NewTraceReporter(os.Stdout)
+	}
+
+	return nopReporter{}
+}
+
+// Result returns the report of the last execution. It returns nil when the workflow has not been executed yet
+func (e *WorkflowExecute) Result() *WorkflowResult {
+	return e.result
+}
+
+// ExecuteWithResult runs the executors, as Execute does, and returns the report of the execution along with the error
+func (e *WorkflowExecute) ExecuteWithResult(ctx context.Context) (*WorkflowResult, error) {
+	err := e.Execute(ctx)
+	return e.result, err
+}
+
+// Execute runs the executors. The returned error joins the errors of the failed steps, using errors.Join
+func (e *WorkflowExecute) Execute(ctx context.Context) error {
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+
+	steps := e.steps()
+
+	err := validateSteps(steps)
+	if err != nil {
+		return err
+	}
+
+	reporter := e.reporter()
+	timeInit := time.Now()
+
+	e.result = &WorkflowResult{
+		Steps: make([]*WorkflowStepResult, len(steps)),
+	}
+	defer func() {
+		e.result.Duration = time.Since(timeInit)
+	}()
+
+	concurrency := e.Concurrency
+	if concurrency <= 0 {
+		concurrency = len(steps)
+	}
+	semaphore := make(chan struct{}, concurrency)
+
+	// done is closed once the step finishes and its result is available
+	done := make(map[string]chan struct{}, len(steps))
+	results := make(map[string]*WorkflowStepResult, len(steps))
+	// failures holds whether the step failed or it was not executed because of a failure of the steps it depends on, directly or indirectly
+	failures := make(map[string]bool, len(steps))
+
+	for stepNum, step := range steps {
+		done[step.Name] = make(chan struct{})
+		e.result.Steps[stepNum] = &WorkflowStepResult{Name: step.Name}
+		results[step.Name] = e.result.Steps[stepNum]
+	}
+
+	for _, step := range steps {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer close(done[step.Name])
+
+			result := results[step.Name]
+			defer func() {
+				reporter.StepFinished(result)
+			}()
+
+			unsucceeded, failed := 0, 0
+			for _, dependency := range step.DependsOn {
+				<-done[dependency]
+
+				mutex.Lock()
+				if results[dependency].Status != StepSucceeded {
+					unsucceeded++
+				}
+				if failures[dependency] {
+					failed++
+				}
+				mutex.Unlock()
+			}
+
+			// the failure is propagated through the steps that are not executed, so an on-failure step runs when any step it depends on indirectly fails
+			defer func() {
+				mutex.Lock()
+				defer mutex.Unlock()
+				switch result.Status {
+				case StepFailed:
+					failures[step.Name] = true
+				case StepSkipped, StepCancelled:
+					failures[step.Name] = failed > 0
+				}
+			}()
+
+			// by default, the dependent subtree is not executed when a step fails or is not executed
+			if !step.shouldRun(unsucceeded, failed, e.ContinueOnError) {
+				mutex.Lock()
+				result.Status = StepSkipped
+				mutex.Unlock()
+				return
+			}
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			if ctx.Err() != nil {
+				mutex.Lock()
+				result.Status = StepCancelled
+				mutex.Unlock()
+				return
+			}
+
+			startTime := time.Now()
+			attempts, err := step.run(ctx, reporter)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			result.StartTime = startTime
+			result.Duration = time.Since(startTime)
+			result.Attempts = attempts
+			result.Error = err
+
+			switch {
+			case err == nil:
+				result.Status = StepSucceeded
+			case ctx.Err() != nil:
+				result.Status = StepCancelled
+			default:
+				result.Status = StepFailed
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return e.result.Err()
+}
@@ -3,7 +3,10 @@ package workflow
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/apenella/go-ansible/v2/pkg/execute"
 	"github.com/stretchr/testify/assert"
@@ -153,7 +156,7 @@ func TestExecute(t *testing.T) {
 			err := test.workflow.Execute(context.TODO())
 
 			if err != nil {
-				assert.Equal(t, test.expectedError, err)
+				assert.EqualError(t, err, test.expectedError.Error())
 
 				if test.assertFunc != nil {
 					test.assertFunc(t, test.workflow)
@@ -170,3 +173,200 @@ func TestExecute(t *testing.T) {
 		})
 	}
 }
+
+// executorFunc is an executor that runs a function
+type executorFunc func(ctx context.Context) error
+
+func (f executorFunc) Execute(ctx context.Context) error {
+	return f(ctx)
+}
+
+func TestExecuteSteps(t *testing.T) {
+
+	t.Run("Testing execute workflow steps respecting the dependencies", func(t *testing.T) {
+		var mutex sync.Mutex
+		order := []string{}
+
+		record := func(name string) execute.Executor {
+			return executorFunc(func(ctx context.Context) error {
+				mutex.Lock()
+				defer mutex.Unlock()
+				order = append(order, name)
+				return nil
+			})
+		}
+
+		err := NewWorkflowExecute().
+			AppendStep(
+				NewWorkflowStep("playbook", record("playbook"), WithDependsOn("roles", "collections")),
+				NewWorkflowStep("roles", record("roles")),
+				NewWorkflowStep("collections", record("collections")),
+			).
+			Execute(context.TODO())
+
+		assert.Nil(t, err)
+		assert.Len(t, order, 3)
+		assert.Equal(t, "playbook", order[2])
+	})
+
+	t.Run("Testing execute independent workflow steps concurrently up to the concurrency limit", func(t *testing.T) {
+		var running, maxRunning int32
+
+		executor := executorFunc(func(ctx context.Context) error {
+			current := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			return nil
+		})
+
+		err := NewWorkflowExecute().
+			WithConcurrency(2).
+			AppendStep(
+				NewWorkflowStep("step1", executor),
+				NewWorkflowStep("step2", executor),
+				NewWorkflowStep("step3", executor),
+				NewWorkflowStep("step4", executor),
+			).
+			Execute(context.TODO())
+
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), maxRunning)
+	})
+
+	t.Run("Testing a failing step only cancels its dependent subtree", func(t *testing.T) {
+		executor1 := execute.NewMockExecute()
+		executor2 := execute.NewMockExecute()
+		executor3 := execute.NewMockExecute()
+		// That will not be executed because it depends on executor1
+		executor4 := execute.NewMockExecute()
+
+		executor1.On("Execute", context.TODO()).Return(errors.New("some error"))
+		executor2.On("Execute", context.TODO()).Return(nil)
+		executor3.On("Execute", context.TODO()).Return(nil)
+
+		err := NewWorkflowExecute().
+			AppendStep(
+				NewWorkflowStep("step1", executor1),
+				NewWorkflowStep("step2", executor2),
+				NewWorkflowStep("step3", executor3, WithDependsOn("step2")),
+				NewWorkflowStep("step4", executor4, WithDependsOn("step1", "step3")),
+			).
+			Execute(context.TODO())
+
+		assert.EqualError(t, err, "some error")
+		executor1.AssertExpectations(t)
+		executor2.AssertExpectations(t)
+		executor3.AssertExpectations(t)
+		executor4.AssertNotCalled(t, "Execute", context.TODO())
+	})
+
+	t.Run("Testing error when a step depends on an undefined step", func(t *testing.T) {
+		err := NewWorkflowExecute().
+			AppendStep(NewWorkflowStep("step1", execute.NewMockExecute(), WithDependsOn("undefined"))).
+			Execute(context.TODO())
+
+		assert.EqualError(t, err, "Step 'step1' depends on the undefined step 'undefined'")
+	})
+
+	t.Run("Testing error when a step is defined more than once", func(t *testing.T) {
+		err := NewWorkflowExecute().
+			AppendStep(
+				NewWorkflowStep("step1", execute.NewMockExecute()),
+				NewWorkflowStep("step1", execute.NewMockExecute()),
+			).
+			Execute(context.TODO())
+
+		assert.EqualError(t, err, "Step 'step1' is defined more than once")
+	})
+
+	t.Run("Testing error when a step has a negative number of retries", func(t *testing.T) {
+		executor := execute.NewMockExecute()
+
+		err := NewWorkflowExecute().
+			AppendStep(NewWorkflowStep("step1", executor, WithRetries(-1, time.Millisecond))).
+			Execute(context.TODO())
+
+		assert.EqualError(t, err, "Step 'step1' has a negative number of retries")
+		executor.AssertNotCalled(t, "Execute", context.TODO())
+	})
+
+	t.Run("Testing error when steps have a dependency cycle", func(t *testing.T) {
+		err := NewWorkflowExecute().
+			AppendStep(
+				NewWorkflowStep("step1", execute.NewMockExecute(), WithDependsOn("step2")),
+				NewWorkflowStep("step2", execute.NewMockExecute(), WithDependsOn("step1")),
+			).
+			Execute(context.TODO())
+
+		assert.EqualError(t, err, "Workflow steps have a dependency cycle")
+	})
+}
+
+// recorderReporter is a WorkflowReporter that records the finished steps
+type recorderReporter struct {
+	mutex    sync.Mutex
+	finished []string
+}
+
+func (r *recorderReporter) StepAttemptStarted(*WorkflowStep, int, int)       {}
+func (r *recorderReporter) StepAttemptFailed(*WorkflowStep, int, int, error) {}
+func (r *recorderReporter) StepFinished(result *WorkflowStepResult) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.finished = append(r.finished, result.Name+":"+string(result.Status))
+}
+
+func TestExecuteWithResult(t *testing.T) {
+
+	t.Run("Testing execute workflow and return the result of each step", func(t *testing.T) {
+		stepErr := &execute.ExitError{Command: "ansible-playbook", Code: 2}
+		reporter := &recorderReporter{}
+
+		e := NewWorkflowExecute().
+			WithReporter(reporter).
+			AppendStep(
+				NewWorkflowStep("step1", executorFunc(func(ctx context.Context) error { return nil })),
+				NewWorkflowStep("step2", executorFunc(func(ctx context.Context) error { return stepErr }), WithDependsOn("step1")),
+				NewWorkflowStep("step3", executorFunc(func(ctx context.Context) error { return nil }), WithDependsOn("step2")),
+			)
+
+		res, err := e.ExecuteWithResult(context.TODO())
+
+		var exitErr *execute.ExitError
+		assert.True(t, errors.As(err, &exitErr))
+		assert.Equal(t, res, e.Result())
+		assert.Equal(t, StepSucceeded, res.Step("step1").Status)
+		assert.Equal(t, 1, res.Step("step1").Attempts)
+		assert.Equal(t, StepFailed, res.Step("step2").Status)
+		assert.Equal(t, stepErr, res.Step("step2").Error)
+		assert.Equal(t, StepSkipped, res.Step("step3").Status)
+		assert.Equal(t, 0, res.Step("step3").Attempts)
+		assert.Equal(t, []string{"step1:succeeded", "step2:failed", "step3:skipped"}, reporter.finished)
+	})
+
+	t.Run("Testing execute workflow with a cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+
+		e := NewWorkflowExecute().
+			AppendStep(
+				NewWorkflowStep("step1", executorFunc(func(ctx context.Context) error {
+					cancel()
+					return ctx.Err()
+				})),
+				NewWorkflowStep("step2", executorFunc(func(ctx context.Context) error { return nil }), WithDependsOn("step1"), WithCondition(Always)),
+			)
+
+		res, err := e.ExecuteWithResult(ctx)
+
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, StepCancelled, res.Step("step1").Status)
+		assert.Equal(t, StepCancelled, res.Step("step2").Status)
+	})
+}
@@ -0,0 +1,63 @@
+package workflow
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/fatih/color"
+)
+
+// WorkflowReporter is the interface to follow the progress of a workflow execution. The steps run concurrently, so the implementations must be safe for concurrent use
+type WorkflowReporter interface {
+	// StepAttemptStarted is called before each attempt of a step
+	StepAttemptStarted(step *WorkflowStep, attempt, attempts int)
+	// StepAttemptFailed is called when an attempt of a step fails
+	StepAttemptFailed(step *WorkflowStep, attempt, attempts int, err error)
+	// StepFinished is called once a step finishes, including the steps that do not run
+	StepFinished(result *WorkflowStepResult)
+}
+
+// nopReporter is a WorkflowReporter that does nothing
+type nopReporter struct{}
+
+func (nopReporter) StepAttemptStarted(*WorkflowStep, int, int)       {}
+func (nopReporter) StepAttemptFailed(*WorkflowStep, int, int, error) {}
+func (nopReporter) StepFinished(*WorkflowStepResult)                 {}
+
+// TraceReporter is a WorkflowReporter that writes the progress of the workflow in colored messages
+type TraceReporter struct {
+	writer io.Writer
+	mutex  sync.Mutex
+}
+
+// NewTraceReporter creates a new TraceReporter that writes to the provided writer. It writes to the standard output when the writer is nil
+func NewTraceReporter(writer io.Writer) *TraceReporter {
+	if writer == nil {
+		writer = os.Stdout
+	}
+
+	return &TraceReporter{writer: writer}
+}
+
+// StepAttemptStarted writes a message when an attempt of a step starts
+func (r *TraceReporter) StepAttemptStarted(step *WorkflowStep, attempt, attempts int) {
+	r.write(color.FgBlue, "\n\u2022 executing step '%s', attempt %d out of %d\n", step.Name, attempt, attempts)
+}
+
+// StepAttemptFailed writes a message when an attempt of a step fails
+func (r *TraceReporter) StepAttemptFailed(step *WorkflowStep, attempt, attempts int, err error) {
+	r.write(color.FgRed, "\n\u2022 step '%s' failed on attempt %d out of %d: %s\n", step.Name, attempt, attempts, err)
+}
+
+// StepFinished writes a message when a step finishes
+func (r *TraceReporter) StepFinished(result *WorkflowStepResult) {
+	r.write(color.FgBlue, "\n\u2022 step '%s' %s in %s\n", result.Name, result.Status, result.Duration)
+}
+
+func (r *TraceReporter) write(attribute color.Attribute, format string, a ...interface{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, _ = color.New(attribute).Fprintf(r.writer, format, a...)
+}
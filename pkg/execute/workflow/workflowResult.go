@@ -0,0 +1,67 @@
+package workflow
+
+import (
+	"errors"
+	"time"
+)
+
+// WorkflowStepStatus is the final status of a workflow step
+type WorkflowStepStatus string
+
+const (
+	// StepSucceeded is the status of a step that finished without error
+	StepSucceeded WorkflowStepStatus = "succeeded"
+	// StepFailed is the status of a step that finished with an error
+	StepFailed WorkflowStepStatus = "failed"
+	// StepSkipped is the status of a step that did not run because its condition was not met
+	StepSkipped WorkflowStepStatus = "skipped"
+	// StepCancelled is the status of a step that did not run or was interrupted because the workflow context is done
+	StepCancelled WorkflowStepStatus = "cancelled"
+)
+
+// WorkflowStepResult is the report of a workflow step execution
+type WorkflowStepResult struct {
+	// Name is the name of the step
+	Name string
+	// Status is the final status of the step
+	Status WorkflowStepStatus
+	// Error is the error returned by the last attempt of the step
+	Error error
+	// Attempts is the number of times the step was executed
+	Attempts int
+	// StartTime is the time when the first attempt started. It is zero when the step did not run
+	StartTime time.Time
+	// Duration is the duration of the step, including all the attempts and the backoff between them
+	Duration time.Duration
+}
+
+// WorkflowResult is the report of a workflow execution
+type WorkflowResult struct {
+	// Steps is the list of step results, in the same order the steps are defined in the workflow
+	Steps []*WorkflowStepResult
+	// Duration is the duration of the workflow execution
+	Duration time.Duration
+}
+
+// Step returns the result of the step with the provided name. It returns nil when the step does not exist
+func (r *WorkflowResult) Step(name string) *WorkflowStepResult {
+	for _, step := range r.Steps {
+		if step.Name == name {
+			return step
+		}
+	}
+
+	return nil
+}
+
+// Err returns the errors of the steps joined by errors.Join. It returns nil when no step has failed
+func (r *WorkflowResult) Err() error {
+	errs := make([]error, 0, len(r.Steps))
+	for _, step := range r.Steps {
+		if step.Error != nil {
+			errs = append(errs, step.Error)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,38 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowResult(t *testing.T) {
+
+	err1 := errors.New("error in step1")
+	err2 := errors.New("error in step3")
+
+	result := &WorkflowResult{
+		Steps: []*WorkflowStepResult{
+			{Name: "step1", Status: StepFailed, Error: err1},
+			{Name: "step2", Status: StepSucceeded},
+			{Name: "step3", Status: StepFailed, Error: err2},
+		},
+	}
+
+	t.Run("Testing get a step result by name", func(t *testing.T) {
+		assert.Equal(t, result.Steps[1], result.Step("step2"))
+		assert.Nil(t, result.Step("undefined"))
+	})
+
+	t.Run("Testing join the errors of the failed steps", func(t *testing.T) {
+		err := result.Err()
+		assert.EqualError(t, err, "error in step1\nerror in step3")
+		assert.True(t, errors.Is(err, err1))
+		assert.True(t, errors.Is(err, err2))
+	})
+
+	t.Run("Testing there is no error when all steps succeeded", func(t *testing.T) {
+		assert.Nil(t, (&WorkflowResult{Steps: []*WorkflowStepResult{{Name: "step1", Status: StepSucceeded}}}).Err())
+	})
+}
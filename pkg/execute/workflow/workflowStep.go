@@ -0,0 +1,154 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+)
+
+// WorkflowStepCondition defines when a step runs, based on the result of the steps it depends on
+type WorkflowStepCondition string
+
+const (
+	// OnSuccess runs the step when all the steps it depends on succeeded. It is the default condition
+	OnSuccess WorkflowStepCondition = "on-success"
+	// OnFailure runs the step when any of the steps it depends on failed, either directly or because a step they depend on failed and they were not executed. It is useful to define cleanup or rollback steps. The step must depend on at least one step
+	OnFailure WorkflowStepCondition = "on-failure"
+	// Always runs the step once the steps it depends on finished, regardless of their result
+	Always WorkflowStepCondition = "always"
+)
+
+// WorkflowStepOptionsFunc is a function to set the WorkflowStep options
+type WorkflowStepOptionsFunc func(*WorkflowStep)
+
+// WorkflowStep is a named executor of a workflow that may depend on other steps
+type WorkflowStep struct {
+	// Name is the unique name of the step in the workflow
+	Name string
+	// Executor is the executor run by the step
+	Executor execute.Executor
+	// DependsOn is the list of step names that must finish before running the step
+	DependsOn []string
+	// Condition defines when the step runs. OnSuccess is used when it is not set
+	Condition WorkflowStepCondition
+	// Retries is the number of times the step is retried when it fails
+	Retries int
+	// RetryBackoff is the time to wait before the first retry. It is doubled on each retry
+	RetryBackoff time.Duration
+	// Timeout is the maximum duration of each attempt. There is no timeout when it is zero
+	Timeout time.Duration
+}
+
+// NewWorkflowStep creates a new WorkflowStep
+func NewWorkflowStep(name string, executor execute.Executor, options ...WorkflowStepOptionsFunc) *WorkflowStep {
+	step := &WorkflowStep{
+		Name:     name,
+		Executor: executor,
+	}
+
+	for _, option := range options {
+		option(step)
+	}
+
+	return step
+}
+
+// WithDependsOn sets the steps that must finish before running the step
+func WithDependsOn(names ...string) WorkflowStepOptionsFunc {
+	return func(s *WorkflowStep) {
+		s.DependsOn = append(s.DependsOn, names...)
+	}
+}
+
+// WithCondition sets when the step runs, based on the result of the steps it depends on
+func WithCondition(condition WorkflowStepCondition) WorkflowStepOptionsFunc {
+	return func(s *WorkflowStep) {
+		s.Condition = condition
+	}
+}
+
+// WithRetries sets the number of times the step is retried when it fails and the time to wait before the first retry, which is doubled on each retry
+func WithRetries(retries int, backoff time.Duration) WorkflowStepOptionsFunc {
+	return func(s *WorkflowStep) {
+		s.Retries = retries
+		s.RetryBackoff = backoff
+	}
+}
+
+// WithTimeout sets the maximum duration of each attempt of the step
+func WithTimeout(timeout time.Duration) WorkflowStepOptionsFunc {
+	return func(s *WorkflowStep) {
+		s.Timeout = timeout
+	}
+}
+
+// condition returns the step condition, which is OnSuccess by default
+func (s *WorkflowStep) condition() WorkflowStepCondition {
+	if s.Condition == "" {
+		return OnSuccess
+	}
+	return s.Condition
+}
+
+// shouldRun returns whether the step runs based on the number of dependencies that did not succeed and the number of dependencies that failed, or that were not executed because of a failure of the steps they depend on
+func (s *WorkflowStep) shouldRun(unsucceeded, failed int, continueOnError bool) bool {
+	switch s.condition() {
+	case Always:
+		return true
+	case OnFailure:
+		return failed > 0
+	default:
+		return unsucceeded == 0 || continueOnError
+	}
+}
+
+// run executes the step, retrying it when it fails. The reporter is notified about each attempt
+func (s *WorkflowStep) run(ctx context.Context, reporter WorkflowReporter) (attempts int, err error) {
+
+	// the step is attempted at least once, even when the retries are negative
+	attemptsAllowed := max(s.Retries, 0) + 1
+	backoff := s.RetryBackoff
+
+attemptsLoop:
+	for attempts = 1; attempts <= attemptsAllowed; attempts++ {
+
+		reporter.StepAttemptStarted(s, attempts, attemptsAllowed)
+
+		err = s.attempt(ctx)
+		if err == nil {
+			return attempts, nil
+		}
+
+		reporter.StepAttemptFailed(s, attempts, attemptsAllowed, err)
+
+		if attempts == attemptsAllowed || ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			break attemptsLoop
+		}
+		backoff = backoff * 2
+	}
+
+	if attemptsAllowed > 1 {
+		err = fmt.Errorf("step '%s' failed on attempt %d out of %d: %w", s.Name, attempts, attemptsAllowed, err)
+	}
+
+	return attempts, err
+}
+
+// attempt executes the step once, within the step timeout
+func (s *WorkflowStep) attempt(ctx context.Context) error {
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+
+	return s.Executor.Execute(ctx)
+}
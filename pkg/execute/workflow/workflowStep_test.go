@@ -0,0 +1,225 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWorkflowStep(t *testing.T) {
+	t.Parallel()
+
+	desc := "Testing create new WorkflowStep using NewWorkflowStep function and options"
+	t.Run(desc, func(t *testing.T) {
+		t.Log(desc)
+
+		executor := execute.NewMockExecute()
+		step := NewWorkflowStep("step", executor,
+			WithDependsOn("dependency"),
+			WithCondition(Always),
+			WithRetries(2, time.Second),
+			WithTimeout(time.Minute),
+		)
+
+		assert.Equal(t, &WorkflowStep{
+			Name:         "step",
+			Executor:     executor,
+			DependsOn:    []string{"dependency"},
+			Condition:    Always,
+			Retries:      2,
+			RetryBackoff: time.Second,
+			Timeout:      time.Minute,
+		}, step)
+	})
+}
+
+func TestWorkflowStepRun(t *testing.T) {
+
+	t.Run("Testing a step is retried until it succeeds", func(t *testing.T) {
+		attempts := 0
+		var traces bytes.Buffer
+
+		step := NewWorkflowStep("step", executorFunc(func(ctx context.Context) error {
+			attempts++
+			if attempts < 2 {
+				return errors.New("some error")
+			}
+			return nil
+		}), WithRetries(3, time.Millisecond))
+
+		num, err := step.run(context.TODO(), NewTraceReporter(&traces))
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, num)
+		assert.Equal(t, "\n\u2022 executing step 'step', attempt 1 out of 4\n"+
+			"\n\u2022 step 'step' failed on attempt 1 out of 4: some error\n"+
+			"\n\u2022 executing step 'step', attempt 2 out of 4\n", traces.String())
+	})
+
+	t.Run("Testing error when a step fails on all the attempts", func(t *testing.T) {
+		someErr := errors.New("some error")
+
+		step := NewWorkflowStep("step", executorFunc(func(ctx context.Context) error {
+			return someErr
+		}), WithRetries(1, time.Millisecond))
+
+		num, err := step.run(context.TODO(), nopReporter{})
+
+		assert.Equal(t, 2, num)
+		assert.EqualError(t, err, "step 'step' failed on attempt 2 out of 2: some error")
+		assert.True(t, errors.Is(err, someErr))
+	})
+
+	t.Run("Testing a step with negative retries is attempted once", func(t *testing.T) {
+		someErr := errors.New("some error")
+		attempts := 0
+
+		step := NewWorkflowStep("step", executorFunc(func(ctx context.Context) error {
+			attempts++
+			return someErr
+		}), WithRetries(-1, time.Millisecond))
+
+		num, err := step.run(context.TODO(), nopReporter{})
+
+		assert.Equal(t, 1, num)
+		assert.Equal(t, 1, attempts)
+		assert.Equal(t, someErr, err)
+	})
+
+	t.Run("Testing a step attempt is cancelled when the timeout is reached", func(t *testing.T) {
+		step := NewWorkflowStep("step", executorFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}), WithTimeout(10*time.Millisecond))
+
+		_, err := step.run(context.TODO(), nopReporter{})
+
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+}
+
+func TestExecuteStepsConditions(t *testing.T) {
+
+	tests := []struct {
+		desc          string
+		deployErr     error
+		rollbackRuns  bool
+		cleanupRuns   bool
+		expectedError error
+	}{
+		{
+			desc:          "Testing on-failure and always steps when the dependency fails",
+			deployErr:     errors.New("deploy error"),
+			rollbackRuns:  true,
+			cleanupRuns:   true,
+			expectedError: errors.New("deploy error"),
+		},
+		{
+			desc:         "Testing on-failure and always steps when the dependency succeeds",
+			rollbackRuns: false,
+			cleanupRuns:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			rollbackRuns, cleanupRuns := false, false
+
+			err := NewWorkflowExecute().
+				AppendStep(
+					NewWorkflowStep("deploy", executorFunc(func(ctx context.Context) error {
+						return test.deployErr
+					})),
+					NewWorkflowStep("rollback", executorFunc(func(ctx context.Context) error {
+						rollbackRuns = true
+						return nil
+					}), WithDependsOn("deploy"), WithCondition(OnFailure)),
+					NewWorkflowStep("cleanup", executorFunc(func(ctx context.Context) error {
+						cleanupRuns = true
+						return nil
+					}), WithDependsOn("deploy", "rollback"), WithCondition(Always)),
+				).
+				Execute(context.TODO())
+
+			if test.expectedError != nil {
+				assert.EqualError(t, err, test.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, test.rollbackRuns, rollbackRuns)
+			assert.Equal(t, test.cleanupRuns, cleanupRuns)
+		})
+	}
+}
+
+func TestExecuteStepsOnFailurePropagation(t *testing.T) {
+
+	tests := []struct {
+		desc          string
+		buildErr      error
+		deployRuns    bool
+		rollbackRuns  bool
+		expectedError error
+	}{
+		{
+			desc:          "Testing on-failure step runs when a step it depends on indirectly fails",
+			buildErr:      errors.New("build error"),
+			deployRuns:    false,
+			rollbackRuns:  true,
+			expectedError: errors.New("build error"),
+		},
+		{
+			desc:         "Testing on-failure step does not run when the steps it depends on indirectly succeed",
+			deployRuns:   true,
+			rollbackRuns: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			deployRuns, rollbackRuns := false, false
+
+			res, err := NewWorkflowExecute().
+				AppendStep(
+					NewWorkflowStep("build", executorFunc(func(ctx context.Context) error {
+						return test.buildErr
+					})),
+					NewWorkflowStep("deploy", executorFunc(func(ctx context.Context) error {
+						deployRuns = true
+						return nil
+					}), WithDependsOn("build")),
+					NewWorkflowStep("rollback", executorFunc(func(ctx context.Context) error {
+						rollbackRuns = true
+						return nil
+					}), WithDependsOn("deploy"), WithCondition(OnFailure)),
+				).
+				ExecuteWithResult(context.TODO())
+
+			if test.expectedError != nil {
+				assert.EqualError(t, err, test.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, test.deployRuns, deployRuns)
+			assert.Equal(t, test.rollbackRuns, rollbackRuns)
+			assert.NotNil(t, res)
+		})
+	}
+
+	t.Run("Testing error when an on-failure step does not depend on any step", func(t *testing.T) {
+		err := NewWorkflowExecute().
+			AppendStep(NewWorkflowStep("rollback", execute.NewMockExecute(), WithCondition(OnFailure))).
+			Execute(context.TODO())
+
+		assert.EqualError(t, err, "Step 'rollback' runs on failure but it does not depend on any step")
+	})
+}
@@ -0,0 +1,13 @@
+package galaxy
+
+import (
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+)
+
+// AnsibleGalaxyErrorEnrich is the error enricher for ansible-galaxy errors. It is an execute.ExitCodeErrorEnrich that converts the errors into the typed errors defined in the execute package, such as execute.BadOptionsError, when the exit code is known. There is no executor for the ansible-galaxy commands, so set it to the execute.DefaultExecute that runs them using the execute.WithErrorEnrich option
+type AnsibleGalaxyErrorEnrich = execute.ExitCodeErrorEnrich
+
+// NewAnsibleGalaxyErrorEnrich creates a new AnsibleGalaxyErrorEnrich instance
+func NewAnsibleGalaxyErrorEnrich() *AnsibleGalaxyErrorEnrich {
+	return execute.NewExitCodeErrorEnrich(DefaultAnsibleGalaxyBinary)
+}
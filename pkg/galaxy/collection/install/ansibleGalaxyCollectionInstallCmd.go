@@ -5,6 +5,7 @@ import (
 
 	galaxy "github.com/apenella/go-ansible/v2/pkg/galaxy"
 	galaxycollection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection"
+	"github.com/apenella/go-ansible/v2/pkg/redact"
 )
 
 const (
@@ -85,7 +86,7 @@ func (p *AnsibleGalaxyCollectionInstallCmd) Command() ([]string, error) {
 	return cmd, nil
 }
 
-// String returns the ansible-galaxy role install command as a string
+// String returns the ansible-galaxy role install command as a string. The secrets registered on the redact package are masked
 func (p *AnsibleGalaxyCollectionInstallCmd) String() string {
 
 	// Use default binary when it is not already defined
@@ -104,5 +105,5 @@ func (p *AnsibleGalaxyCollectionInstallCmd) String() string {
 		str = fmt.Sprintf("%s %s", str, roleName)
 	}
 
-	return str
+	return redact.Redact(str)
 }
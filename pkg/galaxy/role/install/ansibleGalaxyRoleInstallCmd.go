@@ -5,6 +5,7 @@ import (
 
 	galaxy "github.com/apenella/go-ansible/v2/pkg/galaxy"
 	galaxyrole "github.com/apenella/go-ansible/v2/pkg/galaxy/role"
+	"github.com/apenella/go-ansible/v2/pkg/redact"
 )
 
 const (
@@ -91,7 +92,7 @@ func (p *AnsibleGalaxyRoleInstallCmd) Command() ([]string, error) {
 	return cmd, nil
 }
 
-// String returns the ansible-galaxy role install command as a string
+// String returns the ansible-galaxy role install command as a string. The secrets registered on the redact package are masked
 func (p *AnsibleGalaxyRoleInstallCmd) String() string {
 
 	// Use default binary when it is not already defined
@@ -110,5 +111,5 @@ func (p *AnsibleGalaxyRoleInstallCmd) String() string {
 		str = fmt.Sprintf("%s %s", str, roleName)
 	}
 
-	return str
+	return redact.Redact(str)
 }
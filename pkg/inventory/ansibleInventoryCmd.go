@@ -3,6 +3,8 @@ package inventory
 import (
 	"fmt"
 
+	"github.com/apenella/go-ansible/v2/pkg/redact"
+	"github.com/apenella/go-ansible/v2/pkg/vault"
 	errors "github.com/apenella/go-common-utils/error"
 )
 
@@ -143,7 +145,7 @@ func (p *AnsibleInventoryCmd) Command() ([]string, error) {
 	return cmd, nil
 }
 
-// String returns AnsibleInventoryCmd as string
+// String returns AnsibleInventoryCmd as string. The secrets registered on the redact package are masked
 func (p *AnsibleInventoryCmd) String() string {
 
 	// Use default binary when it is not already defined
@@ -159,7 +161,7 @@ func (p *AnsibleInventoryCmd) String() string {
 		str = fmt.Sprintf("%s %s", str, p.InventoryOptions.String())
 	}
 
-	return str
+	return redact.Redact(str)
 }
 
 // AnsibleInventoryOptions object has those parameters described on `Options` section within ansible-inventory's man page, and which defines which should be the ansible-inventory execution behavior.
@@ -200,6 +202,9 @@ type AnsibleInventoryOptions struct {
 	// VaultID the vault identity to use
 	VaultID string
 
+	// VaultKeyring the vault identities to use, passed as a --vault-id flag per vault identity
+	VaultKeyring *vault.VaultKeyring
+
 	// VaultPasswordFile vault password file
 	VaultPasswordFile string
 
@@ -289,6 +294,17 @@ func (o *AnsibleInventoryOptions) GenerateCommandOptions() ([]string, error) {
 		cmd = append(cmd, o.VaultID)
 	}
 
+	if o.VaultKeyring != nil {
+		vaultIDs, err := o.VaultKeyring.VaultIDs()
+		if err != nil {
+			return nil, errors.New(errContext, "Error generating the vault identities", err)
+		}
+
+		for _, vaultID := range vaultIDs {
+			cmd = append(cmd, VaultIdFlag, vaultID)
+		}
+	}
+
 	if o.VaultPasswordFile != "" {
 		cmd = append(cmd, VaultPasswordFileFlag)
 		cmd = append(cmd, o.VaultPasswordFile)
@@ -393,6 +409,13 @@ func (o *AnsibleInventoryOptions) String() string {
 		str = fmt.Sprintf("%s %s %s", str, VaultIdFlag, o.VaultID)
 	}
 
+	if o.VaultKeyring != nil {
+		vaultIDs, _ := o.VaultKeyring.VaultIDs()
+		for _, vaultID := range vaultIDs {
+			str = fmt.Sprintf("%s %s %s", str, VaultIdFlag, vaultID)
+		}
+	}
+
 	if o.VaultPasswordFile != "" {
 		str = fmt.Sprintf("%s %s %s", str, VaultPasswordFileFlag, o.VaultPasswordFile)
 	}
@@ -3,6 +3,7 @@ package inventory
 import (
 	"testing"
 
+	"github.com/apenella/go-ansible/v2/pkg/vault"
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/stretchr/testify/assert"
 )
@@ -120,6 +121,17 @@ func TestGenerateCommandOptions(t *testing.T) {
 			err:     nil,
 			options: []string{"--host", "localhost", "--inventory", "test/ansible/inventory/all", "--output", "/tmp/output.ini", "--vars"},
 		},
+		{
+			desc: "Testing AnsibleInventoryOptions with a vault keyring",
+			ansibleInventoryOptions: &AnsibleInventoryOptions{
+				VaultKeyring: vault.NewVaultKeyring(
+					vault.WithVaultIdentitySource("dev", "dev-password-file"),
+					vault.WithVaultIdentitySource("prod", vault.VaultIDPromptSource),
+				),
+			},
+			err:     nil,
+			options: []string{"--vault-id", "dev@dev-password-file", "--vault-id", "prod@prompt"},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,13 @@
+package inventory
+
+import (
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+)
+
+// AnsibleInventoryErrorEnrich is the error enricher for ansible-inventory errors. It is an execute.ExitCodeErrorEnrich that converts the errors into the typed errors defined in the execute package, such as execute.BadOptionsError, when the exit code is known
+type AnsibleInventoryErrorEnrich = execute.ExitCodeErrorEnrich
+
+// NewAnsibleInventoryErrorEnrich creates a new AnsibleInventoryErrorEnrich instance
+func NewAnsibleInventoryErrorEnrich() *AnsibleInventoryErrorEnrich {
+	return execute.NewExitCodeErrorEnrich(DefaultAnsibleInventoryBinary)
+}
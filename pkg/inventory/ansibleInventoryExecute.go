@@ -49,6 +49,7 @@ func (e *AnsibleInventoryExecute) Execute(ctx context.Context) error {
 
 	exec := execute.NewDefaultExecute(
 		execute.WithCmd(e.cmd),
+		execute.WithErrorEnrich(NewAnsibleInventoryErrorEnrich()),
 	)
 
 	err := exec.Execute(ctx)
@@ -1,8 +1,13 @@
 package inventory
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
 	"testing"
 
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -117,3 +122,138 @@ func TestWithPattern(t *testing.T) {
 		})
 	}
 }
+
+func TestAnsibleInventoryListExecute(t *testing.T) {
+	tests := []struct {
+		desc       string
+		stdout     string
+		waitErr    error
+		assertFunc func(t *testing.T, e *AnsibleInventoryListExecute, inventory *AnsibleInventoryList, err error)
+	}{
+		{
+			desc:   "Testing execute ansible-inventory list returning the parsed inventory",
+			stdout: `{"_meta": {"hostvars": {"web1": {"http_port": 80}}}, "all": {"children": ["ungrouped", "web"]}, "web": {"hosts": ["web1"]}}`,
+			assertFunc: func(t *testing.T, e *AnsibleInventoryListExecute, inventory *AnsibleInventoryList, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"web1"}, inventory.Hosts())
+				assert.Equal(t, inventory, e.Inventory())
+			},
+		},
+		{
+			desc:   "Testing execute ansible-inventory list returning an error when the output is not valid",
+			stdout: `not a json`,
+			assertFunc: func(t *testing.T, e *AnsibleInventoryListExecute, inventory *AnsibleInventoryList, err error) {
+				assert.ErrorContains(t, err, "Error parsing the ansible-inventory output")
+				assert.Nil(t, inventory)
+				assert.Nil(t, e.Inventory())
+			},
+		},
+		{
+			desc:    "Testing execute ansible-inventory list returning an error when the command fails",
+			waitErr: fmt.Errorf("command failed"),
+			assertFunc: func(t *testing.T, e *AnsibleInventoryListExecute, inventory *AnsibleInventoryList, err error) {
+				assert.ErrorContains(t, err, "command failed")
+				assert.Nil(t, inventory)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			cmd := exec.NewMockCmd()
+			cmd.On("StdoutPipe").Return(io.NopCloser(bytes.NewBufferString(test.stdout)), nil)
+			cmd.On("StderrPipe").Return(io.NopCloser(bytes.NewBufferString("")), nil)
+			cmd.On("Start").Return(nil)
+			cmd.On("Wait").Return(test.waitErr)
+
+			executable := exec.NewMockExec()
+			executable.On("CommandContext", context.TODO(), "ansible-inventory", []string{"", "--inventory", "inventory.yml", "--list"}).Return(cmd)
+
+			e := NewAnsibleInventoryListExecute().
+				WithInventoryOptions(&AnsibleInventoryOptions{
+					Inventory: "inventory.yml",
+					Graph:     true,
+					Yaml:      true,
+				}).
+				WithExecutable(executable)
+
+			inventory, err := e.ExecuteWithInventory(context.TODO())
+			test.assertFunc(t, e, inventory, err)
+		})
+	}
+}
+
+func TestAnsibleInventoryListExecuteAddEnvVar(t *testing.T) {
+	t.Run("Testing add an environment variable to AnsibleInventoryListExecute", func(t *testing.T) {
+		e := NewAnsibleInventoryListExecute()
+		e.AddEnvVar("ANSIBLE_INVENTORY_ENABLED", "yaml")
+
+		assert.Equal(t, map[string]string{"ANSIBLE_INVENTORY_ENABLED": "yaml"}, e.envVars)
+	})
+}
+
+func TestAnsibleInventoryGraphExecute(t *testing.T) {
+	tests := []struct {
+		desc       string
+		stdout     string
+		waitErr    error
+		assertFunc func(t *testing.T, e *AnsibleInventoryGraphExecute, graph *AnsibleInventoryGraphNode, err error)
+	}{
+		{
+			desc:   "Testing execute ansible-inventory graph returning the parsed graph",
+			stdout: "@webservers:\n  |--web1\n",
+			assertFunc: func(t *testing.T, e *AnsibleInventoryGraphExecute, graph *AnsibleInventoryGraphNode, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"web1"}, graph.Hosts())
+				assert.Equal(t, graph, e.Graph())
+			},
+		},
+		{
+			desc:   "Testing execute ansible-inventory graph returning an error when the command does not print any graph",
+			stdout: "",
+			assertFunc: func(t *testing.T, e *AnsibleInventoryGraphExecute, graph *AnsibleInventoryGraphNode, err error) {
+				assert.ErrorContains(t, err, "ansible-inventory has not printed any graph")
+				assert.Nil(t, graph)
+				assert.Nil(t, e.Graph())
+			},
+		},
+		{
+			desc:    "Testing execute ansible-inventory graph returning an error when the command fails",
+			waitErr: fmt.Errorf("command failed"),
+			assertFunc: func(t *testing.T, e *AnsibleInventoryGraphExecute, graph *AnsibleInventoryGraphNode, err error) {
+				assert.ErrorContains(t, err, "command failed")
+				assert.Nil(t, graph)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			cmd := exec.NewMockCmd()
+			cmd.On("StdoutPipe").Return(io.NopCloser(bytes.NewBufferString(test.stdout)), nil)
+			cmd.On("StderrPipe").Return(io.NopCloser(bytes.NewBufferString("")), nil)
+			cmd.On("Start").Return(nil)
+			cmd.On("Wait").Return(test.waitErr)
+
+			executable := exec.NewMockExec()
+			executable.On("CommandContext", context.TODO(), "ansible-inventory", []string{"webservers", "--graph", "--inventory", "inventory.yml", "--vars"}).Return(cmd)
+
+			e := NewAnsibleInventoryGraphExecute().
+				WithPattern("webservers").
+				WithInventoryOptions(&AnsibleInventoryOptions{
+					Inventory: "inventory.yml",
+					List:      true,
+					Vars:      true,
+					Yaml:      true,
+				}).
+				WithExecutable(executable)
+
+			graph, err := e.ExecuteWithGraph(context.TODO())
+			test.assertFunc(t, e, graph, err)
+		})
+	}
+}
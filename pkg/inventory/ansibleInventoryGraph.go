@@ -0,0 +1,297 @@
+package inventory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// GraphNodeGroup is the type of the graph nodes that are groups
+	GraphNodeGroup = "group"
+
+	// GraphNodeHost is the type of the graph nodes that are hosts
+	GraphNodeHost = "host"
+
+	// graphIndent is the indentation that ansible-inventory prints for each depth level of the graph
+	graphIndent = "  |"
+
+	// graphItemPrefix is the prefix that ansible-inventory prints before each item of the graph, except the root group
+	graphItemPrefix = "--"
+
+	// graphGroupPrefix is the prefix of the group names on the graph
+	graphGroupPrefix = "@"
+
+	// graphGroupSuffix is the suffix of the group names on the graph
+	graphGroupSuffix = ":"
+
+	// graphVarPrefix is the prefix of the variables on the graph
+	graphVarPrefix = "{"
+
+	// graphVarSuffix is the suffix of the variables on the graph
+	graphVarSuffix = "}"
+
+	// graphVarSeparator is the separator between the variable name and value on the graph
+	graphVarSeparator = " = "
+)
+
+// AnsibleInventoryGraphNode is a node of the `ansible-inventory --graph` output, which is either a group or a host
+type AnsibleInventoryGraphNode struct {
+	// Name is the group or host name
+	Name string `json:"name"`
+
+	// Type is the node type, GraphNodeGroup or GraphNodeHost
+	Type string `json:"type"`
+
+	// Vars are the variables of the node, only available when the graph is generated using the --vars flag. The values are kept as they are printed by ansible-inventory, which uses the python representation of the values
+	Vars map[string]string `json:"vars,omitempty"`
+
+	// Children are the children groups followed by the hosts of a group node
+	Children []*AnsibleInventoryGraphNode `json:"children,omitempty"`
+}
+
+// ParseAnsibleInventoryGraph parses the `ansible-inventory --graph` output, with or without the --vars flag, and returns its root group
+func ParseAnsibleInventoryGraph(data []byte) (*AnsibleInventoryGraphNode, error) {
+	var root *AnsibleInventoryGraphNode
+	var stack []*AnsibleInventoryGraphNode
+	var varOwner *AnsibleInventoryGraphNode
+	var varLine string
+
+	errContext := "(inventory::ParseAnsibleInventoryGraph)"
+
+	flushVar := func() error {
+		if varOwner == nil {
+			return nil
+		}
+
+		content := strings.TrimSuffix(strings.TrimPrefix(varLine, graphVarPrefix), graphVarSuffix)
+		name, value, found := strings.Cut(content, graphVarSeparator)
+		if !found || !strings.HasSuffix(varLine, graphVarSuffix) {
+			return fmt.Errorf("invalid variable '%s' of %s '%s'", varLine, varOwner.Type, varOwner.Name)
+		}
+
+		if varOwner.Vars == nil {
+			varOwner.Vars = map[string]string{}
+		}
+		varOwner.Vars[name] = value
+		varOwner = nil
+
+		return nil
+	}
+
+	content := strings.TrimRight(string(data), "\r\n")
+	if strings.TrimSpace(content) == "" {
+		return nil, errors.New(errContext, "The ansible-inventory graph is empty")
+	}
+
+	lines := strings.Split(content, "\n")
+	for number, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+
+		depth, item, isItem := parseGraphLine(line)
+
+		// the lines that are not graph items belong to a multiline variable value
+		if !isItem || (depth == 0 && root != nil) {
+			if varOwner == nil {
+				return nil, errors.New(errContext, fmt.Sprintf("Invalid ansible-inventory graph line %d: '%s'", number+1, line))
+			}
+			varLine = fmt.Sprintf("%s\n%s", varLine, line)
+			continue
+		}
+
+		err := flushVar()
+		if err != nil {
+			return nil, errors.New(errContext, "Error parsing the ansible-inventory graph", err)
+		}
+
+		if depth == 0 {
+			if !isGraphGroup(item) {
+				return nil, errors.New(errContext, fmt.Sprintf("Invalid ansible-inventory graph root '%s', it must be a group", item))
+			}
+			root = &AnsibleInventoryGraphNode{Name: graphGroupName(item), Type: GraphNodeGroup}
+			stack = []*AnsibleInventoryGraphNode{root}
+			continue
+		}
+
+		if depth > len(stack) {
+			return nil, errors.New(errContext, fmt.Sprintf("Invalid ansible-inventory graph line %d: '%s', its parent is not defined", number+1, line))
+		}
+		parent := stack[depth-1]
+
+		if strings.HasPrefix(item, graphVarPrefix) {
+			varOwner = parent
+			varLine = item
+			continue
+		}
+
+		if parent.Type != GraphNodeGroup {
+			return nil, errors.New(errContext, fmt.Sprintf("Invalid ansible-inventory graph line %d: '%s', its parent is not a group", number+1, line))
+		}
+
+		node := &AnsibleInventoryGraphNode{Name: item, Type: GraphNodeHost}
+		if isGraphGroup(item) {
+			node = &AnsibleInventoryGraphNode{Name: graphGroupName(item), Type: GraphNodeGroup}
+		}
+		parent.Children = append(parent.Children, node)
+		stack = append(stack[:depth], node)
+	}
+
+	err := flushVar()
+	if err != nil {
+		return nil, errors.New(errContext, "Error parsing the ansible-inventory graph", err)
+	}
+
+	return root, nil
+}
+
+// Hosts returns the sorted names of the hosts under the node
+func (n *AnsibleInventoryGraphNode) Hosts() []string {
+	hosts := map[string]struct{}{}
+	n.walk(func(node, parent *AnsibleInventoryGraphNode) {
+		if node.Type == GraphNodeHost {
+			hosts[node.Name] = struct{}{}
+		}
+	})
+
+	return sortedKeys(hosts)
+}
+
+// Groups returns the sorted names of the groups under the node, including the node itself when it is a group
+func (n *AnsibleInventoryGraphNode) Groups() []string {
+	groups := map[string]struct{}{}
+	n.walk(func(node, parent *AnsibleInventoryGraphNode) {
+		if node.Type == GraphNodeGroup {
+			groups[node.Name] = struct{}{}
+		}
+	})
+
+	return sortedKeys(groups)
+}
+
+// JSON returns the node and its children rendered using the JSON format
+func (n *AnsibleInventoryGraphNode) JSON() ([]byte, error) {
+	errContext := "(inventory::AnsibleInventoryGraphNode::JSON)"
+
+	data, err := json.Marshal(n)
+	if err != nil {
+		return nil, errors.New(errContext, "Error rendering the inventory graph", err)
+	}
+
+	return data, nil
+}
+
+// DOT returns the node and its children rendered using the Graphviz DOT language. The groups are drawn as boxes and the hosts as ellipses, labelled with their variables when they are available
+func (n *AnsibleInventoryGraphNode) DOT() string {
+	var buff bytes.Buffer
+
+	nodes := map[string]struct{}{}
+	edges := map[string]struct{}{}
+
+	fmt.Fprintln(&buff, "digraph inventory {")
+	fmt.Fprintln(&buff, "  rankdir=LR;")
+
+	n.walk(func(node, parent *AnsibleInventoryGraphNode) {
+		id := node.dotID()
+		if _, exists := nodes[id]; !exists {
+			nodes[id] = struct{}{}
+
+			shape := "ellipse"
+			if node.Type == GraphNodeGroup {
+				shape = "box"
+			}
+			fmt.Fprintf(&buff, "  %s [shape=%s, label=%s];\n", dotQuote(id), shape, dotQuote(node.dotLabel()))
+		}
+
+		if parent == nil {
+			return
+		}
+
+		edge := fmt.Sprintf("  %s -> %s;", dotQuote(parent.dotID()), dotQuote(id))
+		if _, exists := edges[edge]; !exists {
+			edges[edge] = struct{}{}
+			fmt.Fprintln(&buff, edge)
+		}
+	})
+
+	fmt.Fprintln(&buff, "}")
+
+	return buff.String()
+}
+
+// walk calls the function for the node and each of its descendants, in depth-first order, along with their parent
+func (n *AnsibleInventoryGraphNode) walk(fn func(node, parent *AnsibleInventoryGraphNode)) {
+	var visit func(node, parent *AnsibleInventoryGraphNode)
+	visit = func(node, parent *AnsibleInventoryGraphNode) {
+		fn(node, parent)
+		for _, child := range node.Children {
+			visit(child, node)
+		}
+	}
+
+	visit(n, nil)
+}
+
+// dotID returns the DOT identifier of the node. The groups are prefixed as ansible-inventory does, so a group and a host with the same name are different nodes
+func (n *AnsibleInventoryGraphNode) dotID() string {
+	if n.Type == GraphNodeGroup {
+		return graphGroupPrefix + n.Name
+	}
+
+	return n.Name
+}
+
+// dotLabel returns the DOT label of the node, which includes its variables sorted by name
+func (n *AnsibleInventoryGraphNode) dotLabel() string {
+	lines := []string{n.dotID()}
+
+	names := make([]string, 0, len(n.Vars))
+	for name := range n.Vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		lines = append(lines, name+graphVarSeparator+n.Vars[name])
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// parseGraphLine returns the depth and the item of an ansible-inventory graph line. It returns false when the line is not a graph item
+func parseGraphLine(line string) (int, string, bool) {
+	depth := 0
+	for strings.HasPrefix(line, graphIndent) {
+		line = strings.TrimPrefix(line, graphIndent)
+		depth++
+	}
+
+	if depth == 0 {
+		return 0, line, isGraphGroup(line)
+	}
+
+	if !strings.HasPrefix(line, graphItemPrefix) {
+		return 0, "", false
+	}
+
+	return depth, strings.TrimPrefix(line, graphItemPrefix), true
+}
+
+// isGraphGroup returns whether the graph item is a group
+func isGraphGroup(item string) bool {
+	return len(item) > len(graphGroupPrefix)+len(graphGroupSuffix) && strings.HasPrefix(item, graphGroupPrefix) && strings.HasSuffix(item, graphGroupSuffix)
+}
+
+// graphGroupName returns the group name of a graph item
+func graphGroupName(item string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(item, graphGroupPrefix), graphGroupSuffix)
+}
+
+// dotQuote returns the value quoted as a DOT string
+func dotQuote(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(value) + `"`
+}
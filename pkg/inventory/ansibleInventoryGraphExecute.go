@@ -0,0 +1,134 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// AnsibleInventoryGraphExecute is an executor for ansible-inventory command that runs the command with the --graph flag and parses its output into an AnsibleInventoryGraphNode tree
+type AnsibleInventoryGraphExecute struct {
+	cmd        *AnsibleInventoryCmd
+	executable execute.Executabler
+	envVars    map[string]string
+	graph      *AnsibleInventoryGraphNode
+}
+
+// NewAnsibleInventoryGraphExecute returns a new AnsibleInventoryGraphExecute
+func NewAnsibleInventoryGraphExecute() *AnsibleInventoryGraphExecute {
+
+	exec := &AnsibleInventoryGraphExecute{
+		cmd:     &AnsibleInventoryCmd{},
+		envVars: map[string]string{},
+	}
+
+	return exec
+}
+
+// WithBinary returns an AnsibleInventoryGraphExecute with the binary file set
+func (e *AnsibleInventoryGraphExecute) WithBinary(binary string) *AnsibleInventoryGraphExecute {
+	e.cmd.Binary = binary
+
+	return e
+}
+
+// WithPattern returns an AnsibleInventoryGraphExecute with the group whose graph is printed set. The whole inventory graph is printed when it is not set
+func (e *AnsibleInventoryGraphExecute) WithPattern(pattern string) *AnsibleInventoryGraphExecute {
+	e.cmd.Pattern = pattern
+
+	return e
+}
+
+// WithInventoryOptions returns an AnsibleInventoryGraphExecute with the ansible's inventory options set. The options that change the output format, such as Export, Host, List, Output, Toml or Yaml, are ignored
+func (e *AnsibleInventoryGraphExecute) WithInventoryOptions(options *AnsibleInventoryOptions) *AnsibleInventoryGraphExecute {
+	e.cmd.InventoryOptions = options
+
+	return e
+}
+
+// WithExecutable returns an AnsibleInventoryGraphExecute with the executabler used to run the command set
+func (e *AnsibleInventoryGraphExecute) WithExecutable(executable execute.Executabler) *AnsibleInventoryGraphExecute {
+	e.executable = executable
+
+	return e
+}
+
+// AddEnvVar adds an environment variable to the ansible-inventory execution. It allows the executor to be decorated by the configuration package
+func (e *AnsibleInventoryGraphExecute) AddEnvVar(key, value string) {
+	if e.envVars == nil {
+		e.envVars = map[string]string{}
+	}
+
+	e.envVars[key] = value
+}
+
+// Execute method runs the ansible-inventory command with the --graph flag and parses its output, which is available through the Graph method
+func (e *AnsibleInventoryGraphExecute) Execute(ctx context.Context) error {
+	_, err := e.ExecuteWithGraph(ctx)
+
+	return err
+}
+
+// ExecuteWithGraph method runs the ansible-inventory command with the --graph flag and returns its output parsed into an AnsibleInventoryGraphNode tree. It returns an error when the command does not print any graph
+func (e *AnsibleInventoryGraphExecute) ExecuteWithGraph(ctx context.Context) (*AnsibleInventoryGraphNode, error) {
+	var graph *AnsibleInventoryGraphNode
+
+	errContext := "(inventory::AnsibleInventoryGraphExecute::ExecuteWithGraph)"
+
+	e.graph = nil
+
+	err := execute.CaptureExecute(ctx,
+		func(stdout []byte) error {
+			var err error
+
+			if len(stdout) == 0 {
+				return errors.New(errContext, "ansible-inventory has not printed any graph")
+			}
+
+			graph, err = ParseAnsibleInventoryGraph(stdout)
+			if err != nil {
+				return errors.New(errContext, "Error parsing the ansible-inventory graph", err)
+			}
+
+			return nil
+		},
+		execute.WithCmd(e.graphCmd()),
+		execute.WithErrorEnrich(NewAnsibleInventoryErrorEnrich()),
+		execute.WithExecutable(e.executable),
+		execute.WithEnvVars(e.envVars),
+	)
+	if err != nil {
+		return nil, err
+	}
+	e.graph = graph
+
+	return graph, nil
+}
+
+// Graph returns the inventory graph parsed on the last execution. It is nil when the command has not been executed or the execution failed
+func (e *AnsibleInventoryGraphExecute) Graph() *AnsibleInventoryGraphNode {
+	return e.graph
+}
+
+// graphCmd returns a copy of the ansible-inventory command with the --graph flag set and the flags that change the output format unset
+func (e *AnsibleInventoryGraphExecute) graphCmd() *AnsibleInventoryCmd {
+	options := &AnsibleInventoryOptions{}
+	if e.cmd.InventoryOptions != nil {
+		*options = *e.cmd.InventoryOptions
+	}
+
+	options.Graph = true
+	options.Export = false
+	options.Host = ""
+	options.List = false
+	options.Output = ""
+	options.Toml = false
+	options.Yaml = false
+
+	return &AnsibleInventoryCmd{
+		Binary:           e.cmd.Binary,
+		Pattern:          e.cmd.Pattern,
+		InventoryOptions: options,
+	}
+}
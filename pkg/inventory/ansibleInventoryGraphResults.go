@@ -0,0 +1,128 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/result"
+	defaultresults "github.com/apenella/go-ansible/v2/pkg/execute/result/default"
+	"github.com/apenella/go-ansible/v2/pkg/redact"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// GraphFormat is the format used by AnsibleInventoryGraphResults to print the inventory graph
+type GraphFormat string
+
+const (
+	// GraphFormatText prints the inventory graph as it is printed by ansible-inventory
+	GraphFormatText GraphFormat = "text"
+
+	// GraphFormatJSON prints the inventory graph using the JSON format
+	GraphFormatJSON GraphFormat = "json"
+
+	// GraphFormatDOT prints the inventory graph using the Graphviz DOT language
+	GraphFormatDOT GraphFormat = "dot"
+)
+
+// AnsibleInventoryGraphResults is a results outputer for the ansible-inventory command executed with the --graph flag. It parses the graph printed on the stdout into an AnsibleInventoryGraphNode tree, which is available through the Graph method, and prints it using the configured format
+type AnsibleInventoryGraphResults struct {
+	stdout io.Writer
+	format GraphFormat
+	graph  *AnsibleInventoryGraphNode
+	mutex  sync.Mutex
+}
+
+// NewAnsibleInventoryGraphResults returns an AnsibleInventoryGraphResults. The stdout writer must be the one set to the executor to write the command output, which is the only output parsed as the inventory graph. The rest of outputs, such as stderr, are printed by DefaultResults
+func NewAnsibleInventoryGraphResults(stdout io.Writer, options ...result.OptionsFunc) *AnsibleInventoryGraphResults {
+	results := &AnsibleInventoryGraphResults{
+		stdout: stdout,
+		format: GraphFormatText,
+	}
+	results.Options(options...)
+
+	return results
+}
+
+// WithGraphFormat sets the format used to print the inventory graph
+func WithGraphFormat(format GraphFormat) result.OptionsFunc {
+	return func(r result.ResultsOutputer) {
+		r.(*AnsibleInventoryGraphResults).format = format
+	}
+}
+
+// Options executes the options functions received as a parameters to set the AnsibleInventoryGraphResults attributes
+func (r *AnsibleInventoryGraphResults) Options(options ...result.OptionsFunc) {
+	for _, opt := range options {
+		opt(r)
+	}
+}
+
+// Print parses the inventory graph from the stdout and prints it using the configured format. The rest of outputs are printed using DefaultResults. The secrets of the redactor carried by the context are masked, or the ones registered on the redact package when it does not carry any
+func (r *AnsibleInventoryGraphResults) Print(ctx context.Context, reader io.Reader, writer io.Writer, options ...result.OptionsFunc) error {
+	var rendered []byte
+
+	errContext := "(inventory::AnsibleInventoryGraphResults::Print)"
+
+	if reader == nil {
+		return errors.New(errContext, "AnsibleInventoryGraphResults requires a reader to print the output of the execution")
+	}
+
+	if writer == nil {
+		return errors.New(errContext, "AnsibleInventoryGraphResults requires a writer to print the output of the execution")
+	}
+
+	if writer != r.stdout {
+		return defaultresults.NewDefaultResults().Print(ctx, reader, writer, options...)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return errors.New(errContext, "Error reading the ansible-inventory output", err)
+	}
+
+	// the command does not print any graph when it fails, and the failure is reported by the executor
+	if len(data) == 0 {
+		return nil
+	}
+
+	graph, err := ParseAnsibleInventoryGraph(data)
+	if err != nil {
+		return errors.New(errContext, "Error parsing the ansible-inventory graph", err)
+	}
+
+	r.mutex.Lock()
+	r.graph = graph
+	r.mutex.Unlock()
+
+	switch r.format {
+	case GraphFormatText, "":
+		rendered = data
+	case GraphFormatJSON:
+		rendered, err = graph.JSON()
+		if err != nil {
+			return errors.New(errContext, "Error printing the ansible-inventory graph", err)
+		}
+		rendered = append(rendered, '\n')
+	case GraphFormatDOT:
+		rendered = []byte(graph.DOT())
+	default:
+		return errors.New(errContext, fmt.Sprintf("Inventory graph format '%s' is not supported", r.format))
+	}
+
+	_, err = io.WriteString(writer, redact.FromContext(ctx).Redact(string(rendered)))
+	if err != nil {
+		return errors.New(errContext, "Error printing the ansible-inventory graph", err)
+	}
+
+	return nil
+}
+
+// Graph returns the inventory graph parsed on the last execution. It is nil when the command has not been executed or it has not printed any graph
+func (r *AnsibleInventoryGraphResults) Graph() *AnsibleInventoryGraphNode {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.graph
+}
@@ -0,0 +1,102 @@
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleInventoryGraphResultsPrint(t *testing.T) {
+	graphOutput := "@all:\n  |--@webservers:\n  |  |--web1\n"
+
+	tests := []struct {
+		desc        string
+		format      GraphFormat
+		input       string
+		toStdout    bool
+		res         string
+		parsedGraph bool
+		err         bool
+	}{
+		{
+			desc:        "Testing print the inventory graph using the text format",
+			format:      GraphFormatText,
+			input:       graphOutput,
+			toStdout:    true,
+			res:         graphOutput,
+			parsedGraph: true,
+		},
+		{
+			desc:        "Testing print the inventory graph using the JSON format",
+			format:      GraphFormatJSON,
+			input:       graphOutput,
+			toStdout:    true,
+			res:         `{"name":"all","type":"group","children":[{"name":"webservers","type":"group","children":[{"name":"web1","type":"host"}]}]}` + "\n",
+			parsedGraph: true,
+		},
+		{
+			desc:        "Testing print the inventory graph using the DOT format",
+			format:      GraphFormatDOT,
+			input:       graphOutput,
+			toStdout:    true,
+			res:         "digraph inventory {\n  rankdir=LR;\n  \"@all\" [shape=box, label=\"@all\"];\n  \"@webservers\" [shape=box, label=\"@webservers\"];\n  \"@all\" -> \"@webservers\";\n  \"web1\" [shape=ellipse, label=\"web1\"];\n  \"@webservers\" -> \"web1\";\n}\n",
+			parsedGraph: true,
+		},
+		{
+			desc:     "Testing print the stderr output",
+			format:   GraphFormatJSON,
+			input:    "[WARNING]: Unable to parse inventory.yml as an inventory source\n",
+			toStdout: false,
+			res:      "[WARNING]: Unable to parse inventory.yml as an inventory source\n",
+		},
+		{
+			desc:     "Testing print an empty stdout",
+			format:   GraphFormatJSON,
+			input:    "",
+			toStdout: true,
+			res:      "",
+		},
+		{
+			desc:     "Testing print an invalid inventory graph",
+			format:   GraphFormatJSON,
+			input:    "web1\n",
+			toStdout: true,
+			err:      true,
+		},
+		{
+			desc:     "Testing print the inventory graph using an unsupported format",
+			format:   GraphFormat("svg"),
+			input:    graphOutput,
+			toStdout: true,
+			err:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			var stdout, stderr bytes.Buffer
+
+			results := NewAnsibleInventoryGraphResults(&stdout, WithGraphFormat(test.format))
+
+			writer := &stderr
+			if test.toStdout {
+				writer = &stdout
+			}
+
+			err := results.Print(context.TODO(), strings.NewReader(test.input), writer)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, writer.String())
+			assert.Equal(t, test.parsedGraph, results.Graph() != nil)
+		})
+	}
+}
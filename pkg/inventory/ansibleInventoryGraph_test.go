@@ -0,0 +1,168 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testGraphWithVars = `@all:
+  |--@ungrouped:
+  |  |--bastion
+  |  |  |--{ansible_user = admin}
+  |--@webservers:
+  |  |--@frontend:
+  |  |  |--web3
+  |  |  |  |--{motd = hello
+world}
+  |  |  |  |--{tier = web}
+  |  |--web1
+  |  |  |--{ports = [80, 443]}
+  |  |  |--{tier = web}
+  |  |--{tier = web}
+  |--{env = production}
+`
+
+func TestParseAnsibleInventoryGraph(t *testing.T) {
+	tests := []struct {
+		desc       string
+		data       string
+		res        *AnsibleInventoryGraphNode
+		err        bool
+		errMessage string
+	}{
+		{
+			desc: "Testing parse an ansible-inventory graph",
+			data: "@all:\n  |--@ungrouped:\n  |  |--bastion\n  |--@webservers:\n  |  |--@frontend:\n  |  |  |--web3\n  |  |--web1\n",
+			res: &AnsibleInventoryGraphNode{
+				Name: "all",
+				Type: GraphNodeGroup,
+				Children: []*AnsibleInventoryGraphNode{
+					{Name: "ungrouped", Type: GraphNodeGroup, Children: []*AnsibleInventoryGraphNode{
+						{Name: "bastion", Type: GraphNodeHost},
+					}},
+					{Name: "webservers", Type: GraphNodeGroup, Children: []*AnsibleInventoryGraphNode{
+						{Name: "frontend", Type: GraphNodeGroup, Children: []*AnsibleInventoryGraphNode{
+							{Name: "web3", Type: GraphNodeHost},
+						}},
+						{Name: "web1", Type: GraphNodeHost},
+					}},
+				},
+			},
+		},
+		{
+			desc: "Testing parse an ansible-inventory graph with variables",
+			data: testGraphWithVars,
+			res: &AnsibleInventoryGraphNode{
+				Name: "all",
+				Type: GraphNodeGroup,
+				Vars: map[string]string{"env": "production"},
+				Children: []*AnsibleInventoryGraphNode{
+					{Name: "ungrouped", Type: GraphNodeGroup, Children: []*AnsibleInventoryGraphNode{
+						{Name: "bastion", Type: GraphNodeHost, Vars: map[string]string{"ansible_user": "admin"}},
+					}},
+					{Name: "webservers", Type: GraphNodeGroup, Vars: map[string]string{"tier": "web"}, Children: []*AnsibleInventoryGraphNode{
+						{Name: "frontend", Type: GraphNodeGroup, Children: []*AnsibleInventoryGraphNode{
+							{Name: "web3", Type: GraphNodeHost, Vars: map[string]string{"motd": "hello\nworld", "tier": "web"}},
+						}},
+						{Name: "web1", Type: GraphNodeHost, Vars: map[string]string{"ports": "[80, 443]", "tier": "web"}},
+					}},
+				},
+			},
+		},
+		{
+			desc: "Testing parse an ansible-inventory graph of a group",
+			data: "@webservers:\n  |--web1\n",
+			res: &AnsibleInventoryGraphNode{
+				Name: "webservers",
+				Type: GraphNodeGroup,
+				Children: []*AnsibleInventoryGraphNode{
+					{Name: "web1", Type: GraphNodeHost},
+				},
+			},
+		},
+		{
+			desc:       "Testing parse an empty ansible-inventory graph",
+			data:       "",
+			err:        true,
+			errMessage: "The ansible-inventory graph is empty",
+		},
+		{
+			desc:       "Testing parse an ansible-inventory graph without root group",
+			data:       "web1\n",
+			err:        true,
+			errMessage: "Invalid ansible-inventory graph line 1: 'web1'",
+		},
+		{
+			desc:       "Testing parse an ansible-inventory graph with an item without parent",
+			data:       "@all:\n  |  |--web1\n",
+			err:        true,
+			errMessage: "Invalid ansible-inventory graph line 2: '  |  |--web1', its parent is not defined",
+		},
+		{
+			desc:       "Testing parse an ansible-inventory graph with a host child of a host",
+			data:       "@all:\n  |--web1\n  |  |--web2\n",
+			err:        true,
+			errMessage: "Invalid ansible-inventory graph line 3: '  |  |--web2', its parent is not a group",
+		},
+		{
+			desc:       "Testing parse an ansible-inventory graph with an invalid variable",
+			data:       "@all:\n  |--{env}\n",
+			err:        true,
+			errMessage: "invalid variable '{env}' of group 'all'",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := ParseAnsibleInventoryGraph([]byte(test.data))
+			if test.err {
+				assert.ErrorContains(t, err, test.errMessage)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestAnsibleInventoryGraphNodeHostsAndGroups(t *testing.T) {
+	graph, err := ParseAnsibleInventoryGraph([]byte(testGraphWithVars))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"bastion", "web1", "web3"}, graph.Hosts())
+	assert.Equal(t, []string{"all", "frontend", "ungrouped", "webservers"}, graph.Groups())
+	assert.Equal(t, []string{"web1", "web3"}, graph.Children[1].Hosts())
+}
+
+func TestAnsibleInventoryGraphNodeJSON(t *testing.T) {
+	graph, err := ParseAnsibleInventoryGraph([]byte("@all:\n  |--@webservers:\n  |  |--web1\n  |  |  |--{http_port = 80}\n"))
+	assert.NoError(t, err)
+
+	res, err := graph.JSON()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"all","type":"group","children":[{"name":"webservers","type":"group","children":[{"name":"web1","type":"host","vars":{"http_port":"80"}}]}]}`, string(res))
+}
+
+func TestAnsibleInventoryGraphNodeDOT(t *testing.T) {
+	graph, err := ParseAnsibleInventoryGraph([]byte("@all:\n  |--@staging:\n  |  |--web1\n  |  |  |--{motd = \"hi\"}\n  |--@webservers:\n  |  |--web1\n  |  |  |--{motd = \"hi\"}\n  |--{env = production}\n"))
+	assert.NoError(t, err)
+
+	expected := `digraph inventory {
+  rankdir=LR;
+  "@all" [shape=box, label="@all\nenv = production"];
+  "@staging" [shape=box, label="@staging"];
+  "@all" -> "@staging";
+  "web1" [shape=ellipse, label="web1\nmotd = \"hi\""];
+  "@staging" -> "web1";
+  "@webservers" [shape=box, label="@webservers"];
+  "@all" -> "@webservers";
+  "@webservers" -> "web1";
+}
+`
+
+	assert.Equal(t, expected, graph.DOT())
+}
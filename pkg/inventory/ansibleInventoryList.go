@@ -0,0 +1,506 @@
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// AllGroup is the group that contains all the hosts of the inventory
+	AllGroup = "all"
+
+	// UngroupedGroup is the group that contains the hosts that do not belong to any other group than all
+	UngroupedGroup = "ungrouped"
+
+	// metaKey is the key of the ansible-inventory list output that holds the hosts variables
+	metaKey = "_meta"
+
+	// patternIntersectionPrefix is the prefix of the pattern terms that intersect the hosts
+	patternIntersectionPrefix = "&"
+
+	// patternExclusionPrefix is the prefix of the pattern terms that exclude the hosts
+	patternExclusionPrefix = "!"
+
+	// patternRegexpPrefix is the prefix of the pattern terms that are regular expressions
+	patternRegexpPrefix = "~"
+)
+
+// AnsibleInventoryList is the inventory model of the `ansible-inventory --list` output
+type AnsibleInventoryList struct {
+	// Groups are the inventory groups by name
+	Groups map[string]*AnsibleInventoryGroup
+
+	// HostVars are the variables of each host, defined in the `_meta` section
+	HostVars map[string]map[string]interface{}
+}
+
+// AnsibleInventoryGroup is an inventory group of the `ansible-inventory --list` output
+type AnsibleInventoryGroup struct {
+	// Name is the group name
+	Name string `json:"-"`
+
+	// Hosts are the hosts directly defined in the group
+	Hosts []string `json:"hosts,omitempty"`
+
+	// Children are the groups directly defined as children of the group
+	Children []string `json:"children,omitempty"`
+
+	// Vars are the group variables. The ansible-inventory command only provides them using the --export flag, otherwise they are already merged into the hosts variables
+	Vars map[string]interface{} `json:"vars,omitempty"`
+}
+
+// ansibleInventoryListMeta is the `_meta` section of the `ansible-inventory --list` output
+type ansibleInventoryListMeta struct {
+	HostVars map[string]map[string]interface{} `json:"hostvars"`
+}
+
+// ParseAnsibleInventoryList returns the AnsibleInventoryList of the `ansible-inventory --list` JSON output
+func ParseAnsibleInventoryList(data []byte) (*AnsibleInventoryList, error) {
+	errContext := "(inventory::ParseAnsibleInventoryList)"
+
+	sections := map[string]json.RawMessage{}
+	err := json.Unmarshal(data, &sections)
+	if err != nil {
+		return nil, errors.New(errContext, "Error parsing the ansible-inventory list output", err)
+	}
+
+	inventory := &AnsibleInventoryList{
+		Groups:   map[string]*AnsibleInventoryGroup{},
+		HostVars: map[string]map[string]interface{}{},
+	}
+
+	for name, section := range sections {
+		if name == metaKey {
+			meta := &ansibleInventoryListMeta{}
+			err = json.Unmarshal(section, meta)
+			if err != nil {
+				return nil, errors.New(errContext, "Error parsing the hosts variables of the ansible-inventory list output", err)
+			}
+
+			for host, vars := range meta.HostVars {
+				if vars == nil {
+					vars = map[string]interface{}{}
+				}
+				inventory.HostVars[host] = vars
+			}
+
+			continue
+		}
+
+		group := &AnsibleInventoryGroup{}
+		err = json.Unmarshal(section, group)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error parsing the group '%s' of the ansible-inventory list output", name), err)
+		}
+		group.Name = name
+		inventory.Groups[name] = group
+	}
+
+	return inventory, nil
+}
+
+// GroupNames returns the names of all the groups, sorted alphabetically
+func (i *AnsibleInventoryList) GroupNames() []string {
+	names := make([]string, 0, len(i.Groups))
+	for name := range i.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Hosts returns all the hosts of the inventory, sorted alphabetically
+func (i *AnsibleInventoryList) Hosts() []string {
+	hosts := map[string]struct{}{}
+
+	for host := range i.HostVars {
+		hosts[host] = struct{}{}
+	}
+
+	for _, group := range i.Groups {
+		for _, host := range group.Hosts {
+			hosts[host] = struct{}{}
+		}
+	}
+
+	return sortedKeys(hosts)
+}
+
+// HasHost returns true when the host belongs to the inventory
+func (i *AnsibleInventoryList) HasHost(host string) bool {
+	if _, exists := i.HostVars[host]; exists {
+		return true
+	}
+
+	for _, group := range i.Groups {
+		for _, h := range group.Hosts {
+			if h == host {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// HostsInGroup returns the hosts of the group, including the ones of its children groups, in the inventory order
+func (i *AnsibleInventoryList) HostsInGroup(group string) ([]string, error) {
+	errContext := "(inventory::AnsibleInventoryList::HostsInGroup)"
+
+	if group == AllGroup {
+		return i.allHosts(), nil
+	}
+
+	_, exists := i.Groups[group]
+	if !exists {
+		return nil, errors.New(errContext, fmt.Sprintf("Group '%s' is not defined in the inventory", group))
+	}
+
+	hosts := []string{}
+	i.collectGroupHosts(group, map[string]struct{}{}, map[string]struct{}{}, &hosts)
+
+	return hosts, nil
+}
+
+// GroupsOfHost returns the groups that contain the host, directly or through their children groups, sorted alphabetically. The all group is always included
+func (i *AnsibleInventoryList) GroupsOfHost(host string) ([]string, error) {
+	errContext := "(inventory::AnsibleInventoryList::GroupsOfHost)"
+
+	if !i.HasHost(host) {
+		return nil, errors.New(errContext, fmt.Sprintf("Host '%s' is not defined in the inventory", host))
+	}
+
+	groups := map[string]struct{}{
+		AllGroup: {},
+	}
+
+	parents := i.parents()
+	pending := []string{}
+	for name, group := range i.Groups {
+		for _, h := range group.Hosts {
+			if h == host {
+				pending = append(pending, name)
+				break
+			}
+		}
+	}
+
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+
+		if _, visited := groups[name]; visited {
+			continue
+		}
+		groups[name] = struct{}{}
+		pending = append(pending, parents[name]...)
+	}
+
+	return sortedKeys(groups), nil
+}
+
+// EffectiveVars returns the variables of the host once the variables of its groups are merged. The variables of the deeper groups override the ones of their parents, the groups of the same depth are merged in alphabetical order, and the host variables override all of them
+func (i *AnsibleInventoryList) EffectiveVars(host string) (map[string]interface{}, error) {
+	errContext := "(inventory::AnsibleInventoryList::EffectiveVars)"
+
+	groups, err := i.GroupsOfHost(host)
+	if err != nil {
+		return nil, errors.New(errContext, "Error getting the groups of the host", err)
+	}
+
+	depths := i.depths()
+	sort.SliceStable(groups, func(a, b int) bool {
+		if depths[groups[a]] != depths[groups[b]] {
+			return depths[groups[a]] < depths[groups[b]]
+		}
+		return groups[a] < groups[b]
+	})
+
+	vars := map[string]interface{}{}
+	for _, name := range groups {
+		group, exists := i.Groups[name]
+		if !exists {
+			continue
+		}
+
+		for key, value := range group.Vars {
+			vars[key] = value
+		}
+	}
+
+	for key, value := range i.HostVars[host] {
+		vars[key] = value
+	}
+
+	return vars, nil
+}
+
+// ResolvePattern returns the hosts that match the ansible host pattern, such as `webservers:&staging:!web3`. The pattern terms are separated by `,` or `:`, and they can be groups, hosts, wildcards or regular expressions prefixed by `~`. The terms prefixed by `&` intersect the hosts and the terms prefixed by `!` exclude them
+func (i *AnsibleInventoryList) ResolvePattern(pattern string) ([]string, error) {
+	errContext := "(inventory::AnsibleInventoryList::ResolvePattern)"
+
+	unions, intersections, exclusions := []string{}, []string{}, []string{}
+	for _, term := range splitPattern(pattern) {
+		switch {
+		case strings.HasPrefix(term, patternIntersectionPrefix):
+			intersections = append(intersections, strings.TrimPrefix(term, patternIntersectionPrefix))
+		case strings.HasPrefix(term, patternExclusionPrefix):
+			exclusions = append(exclusions, strings.TrimPrefix(term, patternExclusionPrefix))
+		default:
+			unions = append(unions, term)
+		}
+	}
+
+	// as ansible does, the pattern refers to all the hosts when it only has intersections or exclusions
+	if len(unions) == 0 {
+		unions = append(unions, AllGroup)
+	}
+
+	hosts := []string{}
+	seen := map[string]struct{}{}
+	for _, term := range unions {
+		termHosts, err := i.resolvePatternTerm(term)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error resolving the pattern '%s'", pattern), err)
+		}
+
+		for _, host := range termHosts {
+			if _, exists := seen[host]; !exists {
+				seen[host] = struct{}{}
+				hosts = append(hosts, host)
+			}
+		}
+	}
+
+	for _, term := range intersections {
+		termHosts, err := i.resolvePatternTerm(term)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error resolving the pattern '%s'", pattern), err)
+		}
+		hosts = filterHosts(hosts, termHosts, true)
+	}
+
+	for _, term := range exclusions {
+		termHosts, err := i.resolvePatternTerm(term)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error resolving the pattern '%s'", pattern), err)
+		}
+		hosts = filterHosts(hosts, termHosts, false)
+	}
+
+	return hosts, nil
+}
+
+// resolvePatternTerm returns the hosts that match a single pattern term
+func (i *AnsibleInventoryList) resolvePatternTerm(term string) ([]string, error) {
+	if term == AllGroup || term == "*" {
+		return i.allHosts(), nil
+	}
+
+	if _, exists := i.Groups[term]; exists {
+		return i.HostsInGroup(term)
+	}
+
+	if i.HasHost(term) {
+		return []string{term}, nil
+	}
+
+	var match func(string) bool
+	switch {
+	case strings.HasPrefix(term, patternRegexpPrefix):
+		// as python's re.match does, the regular expression is anchored at the start of the name
+		re, err := regexp.Compile("^(?:" + strings.TrimPrefix(term, patternRegexpPrefix) + ")")
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression '%s': %w", term, err)
+		}
+		match = re.MatchString
+	case strings.ContainsAny(term, "*?["):
+		_, err := path.Match(term, "")
+		if err != nil {
+			return nil, fmt.Errorf("invalid wildcard '%s': %w", term, err)
+		}
+		match = func(name string) bool {
+			matched, _ := path.Match(term, name)
+			return matched
+		}
+	default:
+		// as ansible does, a term that does not match any group or host does not provide any host
+		return []string{}, nil
+	}
+
+	hosts := []string{}
+	seen := map[string]struct{}{}
+	add := func(host string) {
+		if _, exists := seen[host]; !exists {
+			seen[host] = struct{}{}
+			hosts = append(hosts, host)
+		}
+	}
+
+	for _, name := range i.GroupNames() {
+		if match(name) {
+			groupHosts, _ := i.HostsInGroup(name)
+			for _, host := range groupHosts {
+				add(host)
+			}
+		}
+	}
+
+	for _, host := range i.allHosts() {
+		if match(host) {
+			add(host)
+		}
+	}
+
+	return hosts, nil
+}
+
+// allHosts returns all the hosts of the inventory in the inventory order, starting from the all group. The hosts that are not reachable from the all group are appended in alphabetical order
+func (i *AnsibleInventoryList) allHosts() []string {
+	hosts := []string{}
+	seenHosts := map[string]struct{}{}
+
+	if _, exists := i.Groups[AllGroup]; exists {
+		i.collectGroupHosts(AllGroup, map[string]struct{}{}, seenHosts, &hosts)
+	}
+
+	for _, host := range i.Hosts() {
+		if _, exists := seenHosts[host]; !exists {
+			seenHosts[host] = struct{}{}
+			hosts = append(hosts, host)
+		}
+	}
+
+	return hosts
+}
+
+// collectGroupHosts appends the hosts of the group and its children groups, skipping the groups and hosts already visited
+func (i *AnsibleInventoryList) collectGroupHosts(name string, visitedGroups, seenHosts map[string]struct{}, hosts *[]string) {
+	if _, visited := visitedGroups[name]; visited {
+		return
+	}
+	visitedGroups[name] = struct{}{}
+
+	group, exists := i.Groups[name]
+	if !exists {
+		return
+	}
+
+	for _, host := range group.Hosts {
+		if _, seen := seenHosts[host]; !seen {
+			seenHosts[host] = struct{}{}
+			*hosts = append(*hosts, host)
+		}
+	}
+
+	for _, child := range group.Children {
+		i.collectGroupHosts(child, visitedGroups, seenHosts, hosts)
+	}
+}
+
+// parents returns the parent groups of each group
+func (i *AnsibleInventoryList) parents() map[string][]string {
+	parents := map[string][]string{}
+
+	for _, name := range i.GroupNames() {
+		for _, child := range i.Groups[name].Children {
+			parents[child] = append(parents[child], name)
+		}
+	}
+
+	return parents
+}
+
+// depths returns the depth of each group, which is the length of the longest path from the all group
+func (i *AnsibleInventoryList) depths() map[string]int {
+	depths := map[string]int{}
+
+	var walk func(name string, depth int, path map[string]struct{})
+	walk = func(name string, depth int, path map[string]struct{}) {
+		if _, cycle := path[name]; cycle {
+			return
+		}
+
+		current, exists := depths[name]
+		if exists && current >= depth {
+			return
+		}
+		depths[name] = depth
+
+		group, exists := i.Groups[name]
+		if !exists {
+			return
+		}
+
+		path[name] = struct{}{}
+		for _, child := range group.Children {
+			walk(child, depth+1, path)
+		}
+		delete(path, name)
+	}
+
+	walk(AllGroup, 0, map[string]struct{}{})
+
+	// the groups that are not reachable from the all group are considered its direct children
+	for name := range i.Groups {
+		if _, exists := depths[name]; !exists {
+			walk(name, 1, map[string]struct{}{})
+		}
+	}
+
+	return depths
+}
+
+// splitPattern returns the terms of the ansible host pattern. As ansible does, the terms are separated by `,` when it is present, otherwise by `:`
+func splitPattern(pattern string) []string {
+	separator := ":"
+	if strings.Contains(pattern, ",") {
+		separator = ","
+	}
+
+	terms := []string{}
+	for _, term := range strings.Split(pattern, separator) {
+		term = strings.TrimSpace(term)
+		if term != "" {
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
+
+// filterHosts keeps the hosts that are in the filter, when keep is true, or the ones that are not in the filter otherwise
+func filterHosts(hosts, filter []string, keep bool) []string {
+	filterSet := make(map[string]struct{}, len(filter))
+	for _, host := range filter {
+		filterSet[host] = struct{}{}
+	}
+
+	filtered := []string{}
+	for _, host := range hosts {
+		_, exists := filterSet[host]
+		if exists == keep {
+			filtered = append(filtered, host)
+		}
+	}
+
+	return filtered
+}
+
+// sortedKeys returns the keys of the set sorted alphabetically
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,122 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// AnsibleInventoryListExecute is an executor for ansible-inventory command that runs the command with the --list flag and parses its output into an AnsibleInventoryList
+type AnsibleInventoryListExecute struct {
+	cmd        *AnsibleInventoryCmd
+	executable execute.Executabler
+	envVars    map[string]string
+	inventory  *AnsibleInventoryList
+}
+
+// NewAnsibleInventoryListExecute returns a new AnsibleInventoryListExecute
+func NewAnsibleInventoryListExecute() *AnsibleInventoryListExecute {
+
+	exec := &AnsibleInventoryListExecute{
+		cmd:     &AnsibleInventoryCmd{},
+		envVars: map[string]string{},
+	}
+
+	return exec
+}
+
+// WithBinary returns an AnsibleInventoryListExecute with the binary file set
+func (e *AnsibleInventoryListExecute) WithBinary(binary string) *AnsibleInventoryListExecute {
+	e.cmd.Binary = binary
+
+	return e
+}
+
+// WithInventoryOptions returns an AnsibleInventoryListExecute with the ansible's inventory options set. The options that change the output format, such as Graph, Host, Output, Toml or Yaml, are ignored
+func (e *AnsibleInventoryListExecute) WithInventoryOptions(options *AnsibleInventoryOptions) *AnsibleInventoryListExecute {
+	e.cmd.InventoryOptions = options
+
+	return e
+}
+
+// WithExecutable returns an AnsibleInventoryListExecute with the executabler used to run the command set
+func (e *AnsibleInventoryListExecute) WithExecutable(executable execute.Executabler) *AnsibleInventoryListExecute {
+	e.executable = executable
+
+	return e
+}
+
+// AddEnvVar adds an environment variable to the ansible-inventory execution. It allows the executor to be decorated by the configuration package
+func (e *AnsibleInventoryListExecute) AddEnvVar(key, value string) {
+	if e.envVars == nil {
+		e.envVars = map[string]string{}
+	}
+
+	e.envVars[key] = value
+}
+
+// Execute method runs the ansible-inventory command with the --list flag and parses its output, which is available through the Inventory method
+func (e *AnsibleInventoryListExecute) Execute(ctx context.Context) error {
+	_, err := e.ExecuteWithInventory(ctx)
+
+	return err
+}
+
+// ExecuteWithInventory method runs the ansible-inventory command with the --list flag and returns its output parsed into an AnsibleInventoryList
+func (e *AnsibleInventoryListExecute) ExecuteWithInventory(ctx context.Context) (*AnsibleInventoryList, error) {
+	var inventory *AnsibleInventoryList
+
+	errContext := "(inventory::AnsibleInventoryListExecute::ExecuteWithInventory)"
+
+	e.inventory = nil
+
+	err := execute.CaptureExecute(ctx,
+		func(stdout []byte) error {
+			var err error
+
+			inventory, err = ParseAnsibleInventoryList(stdout)
+			if err != nil {
+				return errors.New(errContext, "Error parsing the ansible-inventory output", err)
+			}
+
+			return nil
+		},
+		execute.WithCmd(e.listCmd()),
+		execute.WithErrorEnrich(NewAnsibleInventoryErrorEnrich()),
+		execute.WithExecutable(e.executable),
+		execute.WithEnvVars(e.envVars),
+	)
+	if err != nil {
+		return nil, err
+	}
+	e.inventory = inventory
+
+	return inventory, nil
+}
+
+// Inventory returns the inventory parsed on the last execution. It is nil when the command has not been executed or the execution failed
+func (e *AnsibleInventoryListExecute) Inventory() *AnsibleInventoryList {
+	return e.inventory
+}
+
+// listCmd returns a copy of the ansible-inventory command with the --list flag set and the flags that change the output format unset
+func (e *AnsibleInventoryListExecute) listCmd() *AnsibleInventoryCmd {
+	options := &AnsibleInventoryOptions{}
+	if e.cmd.InventoryOptions != nil {
+		*options = *e.cmd.InventoryOptions
+	}
+
+	options.List = true
+	options.Graph = false
+	options.Host = ""
+	options.Output = ""
+	options.Toml = false
+	options.Vars = false
+	options.Yaml = false
+
+	return &AnsibleInventoryCmd{
+		Binary:           e.cmd.Binary,
+		InventoryOptions: options,
+	}
+}
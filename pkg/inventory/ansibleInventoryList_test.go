@@ -0,0 +1,344 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const inventoryListOutput = `{
+    "_meta": {
+        "hostvars": {
+            "db1": {"ansible_host": "10.0.0.10", "role": "database"},
+            "web1": {"ansible_host": "10.0.0.1", "http_port": 8080},
+            "web2": {"ansible_host": "10.0.0.2"},
+            "web3": {}
+        }
+    },
+    "all": {
+        "children": ["ungrouped", "webservers", "databases", "staging"],
+        "vars": {"env": "production", "http_port": 80}
+    },
+    "databases": {
+        "hosts": ["db1"]
+    },
+    "frontend": {
+        "hosts": ["web3"],
+        "vars": {"http_port": 8443, "tier": "frontend"}
+    },
+    "staging": {
+        "hosts": ["web2", "db1"],
+        "vars": {"env": "staging"}
+    },
+    "ungrouped": {
+        "hosts": ["bastion"]
+    },
+    "webservers": {
+        "children": ["frontend"],
+        "hosts": ["web1", "web2"],
+        "vars": {"http_port": 8000, "tier": "web"}
+    }
+}`
+
+func TestParseAnsibleInventoryList(t *testing.T) {
+	tests := []struct {
+		desc string
+		data string
+		res  *AnsibleInventoryList
+		err  bool
+	}{
+		{
+			desc: "Testing parse an ansible-inventory list output",
+			data: `{"_meta": {"hostvars": {"web1": {"http_port": 80}, "db1": null}}, "all": {"children": ["ungrouped", "web"]}, "web": {"hosts": ["web1"], "vars": {"tier": "web"}}, "ungrouped": {"hosts": ["db1"]}}`,
+			res: &AnsibleInventoryList{
+				Groups: map[string]*AnsibleInventoryGroup{
+					"all":       {Name: "all", Children: []string{"ungrouped", "web"}},
+					"web":       {Name: "web", Hosts: []string{"web1"}, Vars: map[string]interface{}{"tier": "web"}},
+					"ungrouped": {Name: "ungrouped", Hosts: []string{"db1"}},
+				},
+				HostVars: map[string]map[string]interface{}{
+					"web1": {"http_port": float64(80)},
+					"db1":  {},
+				},
+			},
+		},
+		{
+			desc: "Testing parse an invalid ansible-inventory list output",
+			data: `[WARNING]: No inventory was parsed`,
+			err:  true,
+		},
+		{
+			desc: "Testing parse an ansible-inventory list output with an invalid group",
+			data: `{"web": {"hosts": "web1"}}`,
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := ParseAnsibleInventoryList([]byte(test.data))
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestAnsibleInventoryListHosts(t *testing.T) {
+	inventory, err := ParseAnsibleInventoryList([]byte(inventoryListOutput))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"bastion", "db1", "web1", "web2", "web3"}, inventory.Hosts())
+	assert.Equal(t, []string{"all", "databases", "frontend", "staging", "ungrouped", "webservers"}, inventory.GroupNames())
+	assert.True(t, inventory.HasHost("bastion"))
+	assert.False(t, inventory.HasHost("unknown"))
+}
+
+func TestAnsibleInventoryListHostsInGroup(t *testing.T) {
+	tests := []struct {
+		desc  string
+		group string
+		res   []string
+		err   bool
+	}{
+		{
+			desc:  "Testing get the hosts of the all group in the inventory order",
+			group: "all",
+			res:   []string{"bastion", "web1", "web2", "web3", "db1"},
+		},
+		{
+			desc:  "Testing get the hosts of a group including its children groups",
+			group: "webservers",
+			res:   []string{"web1", "web2", "web3"},
+		},
+		{
+			desc:  "Testing get the hosts of an undefined group",
+			group: "unknown",
+			err:   true,
+		},
+	}
+
+	inventory, err := ParseAnsibleInventoryList([]byte(inventoryListOutput))
+	assert.NoError(t, err)
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := inventory.HostsInGroup(test.group)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestAnsibleInventoryListGroupsOfHost(t *testing.T) {
+	tests := []struct {
+		desc string
+		host string
+		res  []string
+		err  bool
+	}{
+		{
+			desc: "Testing get the groups of a host including the parent groups",
+			host: "web3",
+			res:  []string{"all", "frontend", "webservers"},
+		},
+		{
+			desc: "Testing get the groups of a host defined in several groups",
+			host: "db1",
+			res:  []string{"all", "databases", "staging"},
+		},
+		{
+			desc: "Testing get the groups of an undefined host",
+			host: "unknown",
+			err:  true,
+		},
+	}
+
+	inventory, err := ParseAnsibleInventoryList([]byte(inventoryListOutput))
+	assert.NoError(t, err)
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := inventory.GroupsOfHost(test.host)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestAnsibleInventoryListEffectiveVars(t *testing.T) {
+	tests := []struct {
+		desc string
+		host string
+		res  map[string]interface{}
+		err  bool
+	}{
+		{
+			desc: "Testing get the effective vars of a host where the host vars override the group vars",
+			host: "web1",
+			res: map[string]interface{}{
+				"ansible_host": "10.0.0.1",
+				"env":          "production",
+				"http_port":    float64(8080),
+				"tier":         "web",
+			},
+		},
+		{
+			desc: "Testing get the effective vars of a host where the deeper groups override their parents",
+			host: "web3",
+			res: map[string]interface{}{
+				"env":       "production",
+				"http_port": float64(8443),
+				"tier":      "frontend",
+			},
+		},
+		{
+			desc: "Testing get the effective vars of a host where the groups of the same depth are merged alphabetically",
+			host: "web2",
+			res: map[string]interface{}{
+				"ansible_host": "10.0.0.2",
+				"env":          "staging",
+				"http_port":    float64(8000),
+				"tier":         "web",
+			},
+		},
+		{
+			desc: "Testing get the effective vars of an undefined host",
+			host: "unknown",
+			err:  true,
+		},
+	}
+
+	inventory, err := ParseAnsibleInventoryList([]byte(inventoryListOutput))
+	assert.NoError(t, err)
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := inventory.EffectiveVars(test.host)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestAnsibleInventoryListResolvePattern(t *testing.T) {
+	tests := []struct {
+		desc    string
+		data    string
+		pattern string
+		res     []string
+		err     bool
+	}{
+		{
+			desc:    "Testing resolve the all pattern",
+			pattern: "all",
+			res:     []string{"bastion", "web1", "web2", "web3", "db1"},
+		},
+		{
+			desc:    "Testing resolve a union of a group and a host",
+			pattern: "databases:bastion",
+			res:     []string{"db1", "bastion"},
+		},
+		{
+			desc:    "Testing resolve a union separated by commas",
+			pattern: "databases, web1",
+			res:     []string{"db1", "web1"},
+		},
+		{
+			desc:    "Testing resolve an intersection",
+			pattern: "webservers:&staging",
+			res:     []string{"web2"},
+		},
+		{
+			desc:    "Testing resolve an exclusion",
+			pattern: "webservers:!frontend",
+			res:     []string{"web1", "web2"},
+		},
+		{
+			desc:    "Testing resolve an exclusion without a union refers to all the hosts",
+			pattern: "!webservers",
+			res:     []string{"bastion", "db1"},
+		},
+		{
+			desc:    "Testing resolve a wildcard",
+			pattern: "web*",
+			res:     []string{"web1", "web2", "web3"},
+		},
+		{
+			desc:    "Testing resolve a regular expression",
+			pattern: "~(web|db)1",
+			res:     []string{"web1", "db1"},
+		},
+		{
+			desc:    "Testing resolve a regular expression anchored at the start of the host name",
+			data:    `{"_meta": {"hostvars": {}}, "all": {"children": ["ungrouped"]}, "ungrouped": {"hosts": ["oldweb1", "web1"]}}`,
+			pattern: "~web",
+			res:     []string{"web1"},
+		},
+		{
+			desc:    "Testing resolve a regular expression that only matches in the middle of the host names",
+			pattern: "~eb",
+			res:     []string{},
+		},
+		{
+			desc:    "Testing resolve a term that does not match any group or host",
+			pattern: "unknown",
+			res:     []string{},
+		},
+		{
+			desc:    "Testing resolve an invalid regular expression",
+			pattern: "~web[",
+			err:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			data := test.data
+			if data == "" {
+				data = inventoryListOutput
+			}
+
+			inventory, err := ParseAnsibleInventoryList([]byte(data))
+			assert.NoError(t, err)
+
+			res, err := inventory.ResolvePattern(test.pattern)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package builder
+
+import "github.com/apenella/go-ansible/v2/pkg/execute"
+
+// ExecutorEnvVarSetter is an executor that accepts environment variables
+type ExecutorEnvVarSetter interface {
+	execute.Executor
+	AddEnvVar(key, value string)
+}
@@ -0,0 +1,336 @@
+package builder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// AllGroup is the group that contains all the hosts of the inventory
+	AllGroup = "all"
+
+	// UngroupedGroup is the group that contains the hosts that do not belong to any other group than all
+	UngroupedGroup = "ungrouped"
+)
+
+// Host is an inventory host
+type Host struct {
+	// Name is the host name
+	Name string
+
+	// Vars are the host variables
+	Vars map[string]interface{}
+}
+
+// Group is an inventory group
+type Group struct {
+	// Name is the group name
+	Name string
+
+	// Hosts are the names of the hosts of the group, in the order they were added
+	Hosts []string
+
+	// Children are the names of the children groups, in the order they were added
+	Children []string
+
+	// Vars are the group variables
+	Vars map[string]interface{}
+}
+
+// Inventory is an ansible inventory built programmatically, which can be rendered to the INI, YAML or JSON formats
+type Inventory struct {
+	// Hosts are the inventory hosts by name
+	Hosts map[string]*Host
+
+	// Groups are the inventory groups by name. The all group is only defined when it has variables
+	Groups map[string]*Group
+
+	// hostsOrder keeps the order in which the hosts were added
+	hostsOrder []string
+}
+
+// NewInventory returns an empty Inventory
+func NewInventory() *Inventory {
+	return &Inventory{
+		Hosts:  map[string]*Host{},
+		Groups: map[string]*Group{},
+	}
+}
+
+// AddHost adds the host to the inventory and to the groups, which are created when they do not exist. When the host already exists, its variables are merged. The all and ungrouped groups are managed implicitly, so the host is only added to the rest of groups
+func (i *Inventory) AddHost(name string, vars map[string]interface{}, groups ...string) error {
+	errContext := "(builder::Inventory::AddHost)"
+
+	err := validateName(name)
+	if err != nil {
+		return errors.New(errContext, "Invalid host name", err)
+	}
+
+	for _, group := range groups {
+		err = validateName(group)
+		if err != nil {
+			return errors.New(errContext, fmt.Sprintf("Invalid group name for host '%s'", name), err)
+		}
+	}
+
+	host, exists := i.Hosts[name]
+	if !exists {
+		host = &Host{
+			Name: name,
+			Vars: map[string]interface{}{},
+		}
+		i.Hosts[name] = host
+		i.hostsOrder = append(i.hostsOrder, name)
+	}
+
+	for key, value := range vars {
+		host.Vars[key] = value
+	}
+
+	for _, groupName := range groups {
+		if groupName == AllGroup || groupName == UngroupedGroup {
+			continue
+		}
+
+		group := i.group(groupName)
+		if !contains(group.Hosts, name) {
+			group.Hosts = append(group.Hosts, name)
+		}
+	}
+
+	return nil
+}
+
+// AddGroup adds the group to the inventory. When the group already exists, its variables are merged. The variables of the all group are applied to all the hosts
+func (i *Inventory) AddGroup(name string, vars map[string]interface{}) error {
+	errContext := "(builder::Inventory::AddGroup)"
+
+	err := validateName(name)
+	if err != nil {
+		return errors.New(errContext, "Invalid group name", err)
+	}
+
+	if name == UngroupedGroup {
+		return errors.New(errContext, fmt.Sprintf("Group '%s' is managed implicitly and can not be defined", UngroupedGroup))
+	}
+
+	group := i.group(name)
+	for key, value := range vars {
+		group.Vars[key] = value
+	}
+
+	return nil
+}
+
+// AddChildren adds the children groups to the group. The groups are created when they do not exist. It returns an error when a child is already an ancestor of the group
+func (i *Inventory) AddChildren(name string, children ...string) error {
+	errContext := "(builder::Inventory::AddChildren)"
+
+	err := validateName(name)
+	if err != nil {
+		return errors.New(errContext, "Invalid group name", err)
+	}
+
+	if name == AllGroup || name == UngroupedGroup {
+		return errors.New(errContext, fmt.Sprintf("Children can not be added to group '%s', which is managed implicitly", name))
+	}
+
+	for _, child := range children {
+		err = validateName(child)
+		if err != nil {
+			return errors.New(errContext, fmt.Sprintf("Invalid child group name for group '%s'", name), err)
+		}
+
+		if child == AllGroup || child == UngroupedGroup {
+			return errors.New(errContext, fmt.Sprintf("Group '%s' can not be a child of group '%s'", child, name))
+		}
+
+		if child == name || i.isDescendant(name, child) {
+			return errors.New(errContext, fmt.Sprintf("Group '%s' can not be a child of group '%s' because it would create a cycle", child, name))
+		}
+	}
+
+	group := i.group(name)
+	for _, child := range children {
+		i.group(child)
+		if !contains(group.Children, child) {
+			group.Children = append(group.Children, child)
+		}
+	}
+
+	return nil
+}
+
+// SetHostVar sets a variable of the host. It returns an error when the host does not exist
+func (i *Inventory) SetHostVar(name, key string, value interface{}) error {
+	errContext := "(builder::Inventory::SetHostVar)"
+
+	host, exists := i.Hosts[name]
+	if !exists {
+		return errors.New(errContext, fmt.Sprintf("Host '%s' is not defined in the inventory", name))
+	}
+
+	host.Vars[key] = value
+
+	return nil
+}
+
+// SetGroupVar sets a variable of the group. It returns an error when the group does not exist, except for the all group
+func (i *Inventory) SetGroupVar(name, key string, value interface{}) error {
+	errContext := "(builder::Inventory::SetGroupVar)"
+
+	group, exists := i.Groups[name]
+	if !exists && name != AllGroup {
+		return errors.New(errContext, fmt.Sprintf("Group '%s' is not defined in the inventory", name))
+	}
+
+	if !exists {
+		group = i.group(name)
+	}
+	group.Vars[key] = value
+
+	return nil
+}
+
+// group returns the group, creating it when it does not exist
+func (i *Inventory) group(name string) *Group {
+	group, exists := i.Groups[name]
+	if !exists {
+		group = &Group{
+			Name: name,
+			Vars: map[string]interface{}{},
+		}
+		i.Groups[name] = group
+	}
+
+	return group
+}
+
+// isDescendant returns true when the group is a descendant of the ancestor group
+func (i *Inventory) isDescendant(name, ancestor string) bool {
+	group, exists := i.Groups[ancestor]
+	if !exists {
+		return false
+	}
+
+	for _, child := range group.Children {
+		if child == name || i.isDescendant(name, child) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hostNames returns the host names in the order they were added
+func (i *Inventory) hostNames() []string {
+	names := make([]string, 0, len(i.Hosts))
+	added := make(map[string]struct{}, len(i.Hosts))
+	for _, name := range i.hostsOrder {
+		_, exists := i.Hosts[name]
+		_, isAdded := added[name]
+		if exists && !isAdded {
+			names = append(names, name)
+			added[name] = struct{}{}
+		}
+	}
+
+	// the hosts set directly on the Hosts attribute are appended in alphabetical order
+	pending := []string{}
+	for name := range i.Hosts {
+		if _, isAdded := added[name]; !isAdded {
+			pending = append(pending, name)
+		}
+	}
+	sort.Strings(pending)
+
+	return append(names, pending...)
+}
+
+// groupNames returns the group names, except the all group, in alphabetical order
+func (i *Inventory) groupNames() []string {
+	names := make([]string, 0, len(i.Groups))
+	for name := range i.Groups {
+		if name != AllGroup {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// topLevelGroups returns the groups that are not children of other groups, in alphabetical order
+func (i *Inventory) topLevelGroups() []string {
+	children := map[string]struct{}{}
+	for _, group := range i.Groups {
+		for _, child := range group.Children {
+			children[child] = struct{}{}
+		}
+	}
+
+	groups := []string{}
+	for _, name := range i.groupNames() {
+		if _, isChild := children[name]; !isChild {
+			groups = append(groups, name)
+		}
+	}
+
+	return groups
+}
+
+// ungroupedHosts returns the hosts that do not belong to any group, in the order they were added
+func (i *Inventory) ungroupedHosts() []string {
+	grouped := map[string]struct{}{}
+	for _, group := range i.Groups {
+		for _, host := range group.Hosts {
+			grouped[host] = struct{}{}
+		}
+	}
+
+	hosts := []string{}
+	for _, name := range i.hostNames() {
+		if _, isGrouped := grouped[name]; !isGrouped {
+			hosts = append(hosts, name)
+		}
+	}
+
+	return hosts
+}
+
+// allVars returns the variables of the all group
+func (i *Inventory) allVars() map[string]interface{} {
+	group, exists := i.Groups[AllGroup]
+	if !exists {
+		return map[string]interface{}{}
+	}
+
+	return group.Vars
+}
+
+// validateName returns an error when the host or group name is empty or contains whitespaces
+func validateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("name must be defined")
+	}
+
+	if strings.ContainsAny(name, " \t\r\n") {
+		return fmt.Errorf("name '%s' must not contain whitespaces", name)
+	}
+
+	return nil
+}
+
+// contains returns true when the item is in the list
+func contains(list []string, item string) bool {
+	for _, i := range list {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
+}
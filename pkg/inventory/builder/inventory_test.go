@@ -0,0 +1,227 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddHost(t *testing.T) {
+	tests := []struct {
+		desc       string
+		inventory  *Inventory
+		host       string
+		vars       map[string]interface{}
+		groups     []string
+		res        *Inventory
+		err        bool
+		errMessage string
+	}{
+		{
+			desc:      "Testing add a host to the groups",
+			inventory: NewInventory(),
+			host:      "web1",
+			vars:      map[string]interface{}{"http_port": 80},
+			groups:    []string{"webservers", "all"},
+			res: &Inventory{
+				Hosts: map[string]*Host{
+					"web1": {Name: "web1", Vars: map[string]interface{}{"http_port": 80}},
+				},
+				Groups: map[string]*Group{
+					"webservers": {Name: "webservers", Hosts: []string{"web1"}, Vars: map[string]interface{}{}},
+				},
+				hostsOrder: []string{"web1"},
+			},
+		},
+		{
+			desc: "Testing add an existing host merging its variables",
+			inventory: &Inventory{
+				Hosts: map[string]*Host{
+					"web1": {Name: "web1", Vars: map[string]interface{}{"http_port": 80, "tier": "web"}},
+				},
+				Groups: map[string]*Group{
+					"webservers": {Name: "webservers", Hosts: []string{"web1"}, Vars: map[string]interface{}{}},
+				},
+				hostsOrder: []string{"web1"},
+			},
+			host:   "web1",
+			vars:   map[string]interface{}{"http_port": 8080},
+			groups: []string{"webservers"},
+			res: &Inventory{
+				Hosts: map[string]*Host{
+					"web1": {Name: "web1", Vars: map[string]interface{}{"http_port": 8080, "tier": "web"}},
+				},
+				Groups: map[string]*Group{
+					"webservers": {Name: "webservers", Hosts: []string{"web1"}, Vars: map[string]interface{}{}},
+				},
+				hostsOrder: []string{"web1"},
+			},
+		},
+		{
+			desc:       "Testing add a host with an invalid name",
+			inventory:  NewInventory(),
+			host:       "web 1",
+			err:        true,
+			errMessage: "Invalid host name",
+		},
+		{
+			desc:       "Testing add a host to a group with an empty name",
+			inventory:  NewInventory(),
+			host:       "web1",
+			groups:     []string{""},
+			err:        true,
+			errMessage: "Invalid group name for host 'web1'",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.inventory.AddHost(test.host, test.vars, test.groups...)
+			if test.err {
+				assert.ErrorContains(t, err, test.errMessage)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, test.inventory)
+		})
+	}
+}
+
+func TestAddGroup(t *testing.T) {
+	tests := []struct {
+		desc       string
+		group      string
+		vars       map[string]interface{}
+		res        map[string]*Group
+		err        bool
+		errMessage string
+	}{
+		{
+			desc:  "Testing add a group with variables",
+			group: "webservers",
+			vars:  map[string]interface{}{"tier": "web"},
+			res: map[string]*Group{
+				"webservers": {Name: "webservers", Vars: map[string]interface{}{"tier": "web"}},
+			},
+		},
+		{
+			desc:  "Testing add the variables of the all group",
+			group: "all",
+			vars:  map[string]interface{}{"env": "production"},
+			res: map[string]*Group{
+				"all": {Name: "all", Vars: map[string]interface{}{"env": "production"}},
+			},
+		},
+		{
+			desc:       "Testing add the ungrouped group",
+			group:      "ungrouped",
+			err:        true,
+			errMessage: "Group 'ungrouped' is managed implicitly and can not be defined",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			inventory := NewInventory()
+			err := inventory.AddGroup(test.group, test.vars)
+			if test.err {
+				assert.ErrorContains(t, err, test.errMessage)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, inventory.Groups)
+		})
+	}
+}
+
+func TestAddChildren(t *testing.T) {
+	tests := []struct {
+		desc       string
+		group      string
+		children   []string
+		res        []string
+		err        bool
+		errMessage string
+	}{
+		{
+			desc:     "Testing add children groups",
+			group:    "webservers",
+			children: []string{"backend", "frontend"},
+			res:      []string{"frontend", "backend"},
+		},
+		{
+			desc:       "Testing add a child that creates a cycle",
+			group:      "frontend",
+			children:   []string{"webservers"},
+			err:        true,
+			errMessage: "Group 'webservers' can not be a child of group 'frontend' because it would create a cycle",
+		},
+		{
+			desc:       "Testing add a group as its own child",
+			group:      "webservers",
+			children:   []string{"webservers"},
+			err:        true,
+			errMessage: "because it would create a cycle",
+		},
+		{
+			desc:       "Testing add the all group as a child",
+			group:      "webservers",
+			children:   []string{"all"},
+			err:        true,
+			errMessage: "Group 'all' can not be a child of group 'webservers'",
+		},
+		{
+			desc:       "Testing add children to the all group",
+			group:      "all",
+			children:   []string{"webservers"},
+			err:        true,
+			errMessage: "Children can not be added to group 'all', which is managed implicitly",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			inventory := NewInventory()
+			err := inventory.AddChildren("webservers", "frontend")
+			assert.NoError(t, err)
+
+			err = inventory.AddChildren(test.group, test.children...)
+			if test.err {
+				assert.ErrorContains(t, err, test.errMessage)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, inventory.Groups[test.group].Children)
+			for _, child := range test.children {
+				assert.Contains(t, inventory.Groups, child)
+			}
+		})
+	}
+}
+
+func TestSetVars(t *testing.T) {
+	t.Run("Testing set host and group variables", func(t *testing.T) {
+		inventory := NewInventory()
+		assert.NoError(t, inventory.AddHost("web1", nil, "webservers"))
+
+		assert.NoError(t, inventory.SetHostVar("web1", "http_port", 80))
+		assert.NoError(t, inventory.SetGroupVar("webservers", "tier", "web"))
+		assert.NoError(t, inventory.SetGroupVar("all", "env", "production"))
+
+		assert.Equal(t, map[string]interface{}{"http_port": 80}, inventory.Hosts["web1"].Vars)
+		assert.Equal(t, map[string]interface{}{"tier": "web"}, inventory.Groups["webservers"].Vars)
+		assert.Equal(t, map[string]interface{}{"env": "production"}, inventory.Groups["all"].Vars)
+
+		assert.ErrorContains(t, inventory.SetHostVar("web2", "http_port", 80), "Host 'web2' is not defined in the inventory")
+		assert.ErrorContains(t, inventory.SetGroupVar("databases", "tier", "db"), "Group 'databases' is not defined in the inventory")
+	})
+}
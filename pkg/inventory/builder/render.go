@@ -0,0 +1,388 @@
+package builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the format used to render the inventory
+type Format string
+
+const (
+	// FormatINI renders the inventory using the INI format of the ansible ini inventory plugin
+	FormatINI Format = "ini"
+
+	// FormatYAML renders the inventory using the YAML format of the ansible yaml inventory plugin
+	FormatYAML Format = "yaml"
+
+	// FormatJSON renders the inventory using the JSON format returned by the dynamic inventory scripts on `--list`, which includes the hosts variables in the `_meta` section
+	FormatJSON Format = "json"
+
+	// yamlIndent is the indentation used to render the YAML format
+	yamlIndent = 2
+)
+
+// Render returns the inventory rendered using the format
+func (i *Inventory) Render(format Format) ([]byte, error) {
+	errContext := "(builder::Inventory::Render)"
+
+	switch format {
+	case FormatINI:
+		return i.INI()
+	case FormatYAML:
+		return i.YAML()
+	case FormatJSON:
+		return i.JSON()
+	default:
+		return nil, errors.New(errContext, fmt.Sprintf("Inventory format '%s' is not supported", format))
+	}
+}
+
+// INI returns the inventory rendered using the INI format. The variables must be scalar values, since the INI format does not support lists or maps. As ansible does for INI inventories, the values are typed by ansible when they are loaded
+func (i *Inventory) INI() ([]byte, error) {
+	var buff bytes.Buffer
+
+	errContext := "(builder::Inventory::INI)"
+
+	renderedHosts := map[string]struct{}{}
+	writeHost := func(name string) error {
+		line := name
+		if _, rendered := renderedHosts[name]; !rendered {
+			renderedHosts[name] = struct{}{}
+
+			vars, err := iniVars(i.Hosts[name].Vars, " ")
+			if err != nil {
+				return errors.New(errContext, fmt.Sprintf("Error rendering the variables of host '%s'", name), err)
+			}
+			if vars != "" {
+				line = fmt.Sprintf("%s %s", line, vars)
+			}
+		}
+
+		fmt.Fprintln(&buff, line)
+		return nil
+	}
+
+	for _, name := range i.ungroupedHosts() {
+		err := writeHost(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	allVars, err := iniVars(i.allVars(), "\n")
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error rendering the variables of group '%s'", AllGroup), err)
+	}
+	if allVars != "" {
+		writeINISection(&buff, fmt.Sprintf("[%s:vars]", AllGroup), allVars)
+	}
+
+	for _, name := range i.groupNames() {
+		group := i.Groups[name]
+
+		writeINISection(&buff, fmt.Sprintf("[%s]", name), "")
+		for _, host := range group.Hosts {
+			if _, exists := i.Hosts[host]; !exists {
+				return nil, errors.New(errContext, fmt.Sprintf("Host '%s' of group '%s' is not defined in the inventory", host, name))
+			}
+
+			err = writeHost(host)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(group.Children) > 0 {
+			writeINISection(&buff, fmt.Sprintf("[%s:children]", name), strings.Join(group.Children, "\n"))
+		}
+
+		vars, err := iniVars(group.Vars, "\n")
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error rendering the variables of group '%s'", name), err)
+		}
+		if vars != "" {
+			writeINISection(&buff, fmt.Sprintf("[%s:vars]", name), vars)
+		}
+	}
+
+	return buff.Bytes(), nil
+}
+
+// YAML returns the inventory rendered using the YAML format. The groups are nested under their first parent group, in alphabetical order, and the variables of each host are rendered on its first occurrence
+func (i *Inventory) YAML() ([]byte, error) {
+	var buff bytes.Buffer
+
+	errContext := "(builder::Inventory::YAML)"
+
+	renderedHosts := map[string]struct{}{}
+	renderedGroups := map[string]struct{}{}
+
+	hostsNode := func(hosts []string) (*yaml.Node, error) {
+		node := mappingNode()
+		for _, name := range hosts {
+			host, exists := i.Hosts[name]
+			if !exists {
+				return nil, fmt.Errorf("host '%s' is not defined in the inventory", name)
+			}
+
+			value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: ""}
+			if _, rendered := renderedHosts[name]; !rendered && len(host.Vars) > 0 {
+				err := value.Encode(host.Vars)
+				if err != nil {
+					return nil, fmt.Errorf("error rendering the variables of host '%s': %w", name, err)
+				}
+			}
+			renderedHosts[name] = struct{}{}
+
+			appendMappingItem(node, name, value)
+		}
+
+		return node, nil
+	}
+
+	var groupNode func(name string) (*yaml.Node, error)
+	groupNode = func(name string) (*yaml.Node, error) {
+		node := mappingNode()
+
+		if _, rendered := renderedGroups[name]; rendered {
+			return node, nil
+		}
+		renderedGroups[name] = struct{}{}
+
+		group := i.Groups[name]
+
+		if len(group.Hosts) > 0 {
+			hosts, err := hostsNode(group.Hosts)
+			if err != nil {
+				return nil, err
+			}
+			appendMappingItem(node, "hosts", hosts)
+		}
+
+		if len(group.Children) > 0 {
+			children := mappingNode()
+			for _, child := range group.Children {
+				childNode, err := groupNode(child)
+				if err != nil {
+					return nil, err
+				}
+				appendMappingItem(children, child, childNode)
+			}
+			appendMappingItem(node, "children", children)
+		}
+
+		if len(group.Vars) > 0 {
+			vars := &yaml.Node{}
+			err := vars.Encode(group.Vars)
+			if err != nil {
+				return nil, fmt.Errorf("error rendering the variables of group '%s': %w", name, err)
+			}
+			appendMappingItem(node, "vars", vars)
+		}
+
+		return node, nil
+	}
+
+	all := mappingNode()
+
+	ungrouped := i.ungroupedHosts()
+	if len(ungrouped) > 0 {
+		hosts, err := hostsNode(ungrouped)
+		if err != nil {
+			return nil, errors.New(errContext, "Error rendering the inventory", err)
+		}
+		appendMappingItem(all, "hosts", hosts)
+	}
+
+	topLevelGroups := i.topLevelGroups()
+	if len(topLevelGroups) > 0 {
+		children := mappingNode()
+		for _, name := range topLevelGroups {
+			node, err := groupNode(name)
+			if err != nil {
+				return nil, errors.New(errContext, "Error rendering the inventory", err)
+			}
+			appendMappingItem(children, name, node)
+		}
+		appendMappingItem(all, "children", children)
+	}
+
+	allVars := i.allVars()
+	if len(allVars) > 0 {
+		vars := &yaml.Node{}
+		err := vars.Encode(allVars)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error rendering the variables of group '%s'", AllGroup), err)
+		}
+		appendMappingItem(all, "vars", vars)
+	}
+
+	root := mappingNode()
+	appendMappingItem(root, AllGroup, all)
+
+	encoder := yaml.NewEncoder(&buff)
+	encoder.SetIndent(yamlIndent)
+	err := encoder.Encode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}})
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err != nil {
+		return nil, errors.New(errContext, "Error writing the YAML inventory", err)
+	}
+
+	return buff.Bytes(), nil
+}
+
+// JSON returns the inventory rendered using the JSON format returned by the dynamic inventory scripts on `--list`. The variables of all the hosts are included in the `_meta` section, so ansible does not need to request them host by host
+func (i *Inventory) JSON() ([]byte, error) {
+	errContext := "(builder::Inventory::JSON)"
+
+	hostVars := map[string]map[string]interface{}{}
+	for name, host := range i.Hosts {
+		vars := host.Vars
+		if vars == nil {
+			vars = map[string]interface{}{}
+		}
+		hostVars[name] = vars
+	}
+
+	all := map[string]interface{}{}
+	allChildren := append([]string{}, i.topLevelGroups()...)
+
+	inventory := map[string]interface{}{
+		"_meta": map[string]interface{}{
+			"hostvars": hostVars,
+		},
+	}
+
+	ungrouped := i.ungroupedHosts()
+	if len(ungrouped) > 0 {
+		allChildren = append(allChildren, UngroupedGroup)
+		inventory[UngroupedGroup] = map[string]interface{}{
+			"hosts": ungrouped,
+		}
+	}
+	sort.Strings(allChildren)
+
+	if len(allChildren) > 0 {
+		all["children"] = allChildren
+	}
+	if allVars := i.allVars(); len(allVars) > 0 {
+		all["vars"] = allVars
+	}
+	inventory[AllGroup] = all
+
+	for _, name := range i.groupNames() {
+		group := i.Groups[name]
+		item := map[string]interface{}{}
+
+		for _, host := range group.Hosts {
+			if _, exists := i.Hosts[host]; !exists {
+				return nil, errors.New(errContext, fmt.Sprintf("Host '%s' of group '%s' is not defined in the inventory", host, name))
+			}
+		}
+
+		if len(group.Hosts) > 0 {
+			item["hosts"] = group.Hosts
+		}
+		if len(group.Children) > 0 {
+			item["children"] = group.Children
+		}
+		if len(group.Vars) > 0 {
+			item["vars"] = group.Vars
+		}
+
+		inventory[name] = item
+	}
+
+	data, err := json.Marshal(inventory)
+	if err != nil {
+		return nil, errors.New(errContext, "Error writing the JSON inventory", err)
+	}
+
+	return data, nil
+}
+
+// writeINISection writes a section header followed by its content, separated from the previous content by an empty line
+func writeINISection(buff *bytes.Buffer, header, content string) {
+	if buff.Len() > 0 {
+		fmt.Fprintln(buff)
+	}
+
+	fmt.Fprintln(buff, header)
+	if content != "" {
+		fmt.Fprintln(buff, content)
+	}
+}
+
+// iniVars returns the variables rendered as `key=value`, sorted by key and joined by the separator
+func iniVars(vars map[string]interface{}, separator string) (string, error) {
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	items := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, err := iniValue(vars[key])
+		if err != nil {
+			return "", fmt.Errorf("variable '%s' can not be rendered in the INI format: %w", key, err)
+		}
+		items = append(items, fmt.Sprintf("%s=%s", key, value))
+	}
+
+	return strings.Join(items, separator), nil
+}
+
+// iniValue returns the value rendered as an INI inventory value. The strings that contain whitespaces, quotes or comment characters are quoted
+func iniValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		if v == "" || strings.ContainsAny(v, " \t\r\n\"'#;=\\") {
+			return strconv.Quote(v), nil
+		}
+		return v, nil
+	case bool:
+		if v {
+			return "True", nil
+		}
+		return "False", nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprintf("%v", v), nil
+	default:
+		return "", fmt.Errorf("only scalar values are supported, use the YAML or JSON formats instead")
+	}
+}
+
+// mappingNode returns an empty YAML mapping node
+func mappingNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+// appendMappingItem appends the key and value to the YAML mapping node
+func appendMappingItem(node *yaml.Node, key string, value *yaml.Node) {
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// yamlToJSON converts the YAML content to JSON
+func yamlToJSON(content []byte) ([]byte, error) {
+	var data interface{}
+
+	err := yaml.Unmarshal(content, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(data)
+}
@@ -0,0 +1,121 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testInventory returns the inventory used by the render tests
+func testInventory(t *testing.T) *Inventory {
+	inventory := NewInventory()
+
+	assert.NoError(t, inventory.AddHost("bastion", map[string]interface{}{"ansible_user": "admin"}))
+	assert.NoError(t, inventory.AddHost("web1", map[string]interface{}{"ansible_host": "10.0.0.1", "http_port": 8080}, "webservers", "staging"))
+	assert.NoError(t, inventory.AddHost("web2", nil, "webservers"))
+	assert.NoError(t, inventory.AddHost("web3", map[string]interface{}{"motd": "hello world", "enabled": true}, "frontend"))
+	assert.NoError(t, inventory.AddChildren("webservers", "frontend"))
+	assert.NoError(t, inventory.AddGroup("webservers", map[string]interface{}{"tier": "web"}))
+	assert.NoError(t, inventory.AddGroup("all", map[string]interface{}{"env": "production"}))
+
+	return inventory
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		desc   string
+		format Format
+		res    string
+		err    bool
+	}{
+		{
+			desc:   "Testing render the inventory using the INI format",
+			format: FormatINI,
+			res: `bastion ansible_user=admin
+
+[all:vars]
+env=production
+
+[frontend]
+web3 enabled=True motd="hello world"
+
+[staging]
+web1 ansible_host=10.0.0.1 http_port=8080
+
+[webservers]
+web1
+web2
+
+[webservers:children]
+frontend
+
+[webservers:vars]
+tier=web
+`,
+		},
+		{
+			desc:   "Testing render the inventory using the YAML format",
+			format: FormatYAML,
+			res: `all:
+  hosts:
+    bastion:
+      ansible_user: admin
+  children:
+    staging:
+      hosts:
+        web1:
+          ansible_host: 10.0.0.1
+          http_port: 8080
+    webservers:
+      hosts:
+        web1:
+        web2:
+      children:
+        frontend:
+          hosts:
+            web3:
+              enabled: true
+              motd: hello world
+      vars:
+        tier: web
+  vars:
+    env: production
+`,
+		},
+		{
+			desc:   "Testing render the inventory using the JSON format",
+			format: FormatJSON,
+			res:    `{"_meta":{"hostvars":{"bastion":{"ansible_user":"admin"},"web1":{"ansible_host":"10.0.0.1","http_port":8080},"web2":{},"web3":{"enabled":true,"motd":"hello world"}}},"all":{"children":["staging","ungrouped","webservers"],"vars":{"env":"production"}},"frontend":{"hosts":["web3"]},"staging":{"hosts":["web1"]},"ungrouped":{"hosts":["bastion"]},"webservers":{"children":["frontend"],"hosts":["web1","web2"],"vars":{"tier":"web"}}}`,
+		},
+		{
+			desc:   "Testing render the inventory using an unsupported format",
+			format: Format("toml"),
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := testInventory(t).Render(test.format)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, string(res))
+		})
+	}
+}
+
+func TestINIWithComplexValues(t *testing.T) {
+	t.Run("Testing render a list variable using the INI format", func(t *testing.T) {
+		inventory := NewInventory()
+		assert.NoError(t, inventory.AddHost("web1", map[string]interface{}{"ports": []int{80, 443}}))
+
+		_, err := inventory.INI()
+		assert.ErrorContains(t, err, "Error rendering the variables of host 'web1'")
+	})
+}
@@ -0,0 +1,182 @@
+package builder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// AnsibleInventoryEnv is the environment variable that holds the inventory sources used by default by the ansible commands. It is equivalent to the --inventory flag, which takes precedence over it
+	AnsibleInventoryEnv = "ANSIBLE_INVENTORY"
+
+	// FormatScript feeds the inventory to ansible as a dynamic inventory script that returns the inventory using the JSON format
+	FormatScript Format = "script"
+
+	// inventoryFileName is the name of the inventory file, without the extension
+	inventoryFileName = "inventory"
+
+	// scriptHeredocDelimiter is the delimiter of the heredoc that holds the JSON inventory on the dynamic inventory script
+	scriptHeredocDelimiter = "GO_ANSIBLE_INVENTORY"
+)
+
+// inventoryScript is the dynamic inventory script. The hosts variables are provided on `--list` through the `_meta` section, so `--host` returns an empty object
+const inventoryScript = `#!/bin/sh
+if [ "$1" = "--host" ]; then
+    echo '{}'
+    exit 0
+fi
+
+cat <<'%s'
+%s
+%s
+`
+
+// TempInventoryOptionsFunc is a function to set the TempInventory options
+type TempInventoryOptionsFunc func(*TempInventory)
+
+// TempInventory writes the inventory to a temporary file or dynamic inventory script to be passed to the ansible commands. The file is created in a temporary directory only accessible by the current user, and it is removed once the inventory is stopped
+type TempInventory struct {
+	// Inventory is the inventory to be written
+	Inventory *Inventory
+
+	// Format is the format of the inventory file. It is FormatScript by default
+	Format Format
+
+	mutex sync.Mutex
+	dir   string
+	path  string
+}
+
+// NewTempInventory returns a TempInventory
+func NewTempInventory(inventory *Inventory, options ...TempInventoryOptionsFunc) *TempInventory {
+	temp := &TempInventory{
+		Inventory: inventory,
+		Format:    FormatScript,
+	}
+
+	for _, option := range options {
+		option(temp)
+	}
+
+	return temp
+}
+
+// WithFormat sets the format of the inventory file
+func WithFormat(format Format) TempInventoryOptionsFunc {
+	return func(t *TempInventory) {
+		t.Format = format
+	}
+}
+
+// Start renders the inventory and writes it to the temporary file or dynamic inventory script
+func (t *TempInventory) Start() error {
+	errContext := "(builder::TempInventory::Start)"
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.path != "" {
+		return errors.New(errContext, "Temporary inventory is already started")
+	}
+
+	if t.Inventory == nil {
+		return errors.New(errContext, "Inventory must be provided to create a temporary inventory")
+	}
+
+	content, fileName, mode, err := t.render()
+	if err != nil {
+		return errors.New(errContext, "Error rendering the temporary inventory", err)
+	}
+
+	dir, err := os.MkdirTemp("", "go-ansible-inventory-")
+	if err != nil {
+		return errors.New(errContext, "Error creating the temporary inventory directory", err)
+	}
+
+	path := filepath.Join(dir, fileName)
+	err = os.WriteFile(path, content, mode)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return errors.New(errContext, "Error writing the temporary inventory", err)
+	}
+
+	t.dir = dir
+	t.path = path
+
+	return nil
+}
+
+// Stop removes the temporary inventory
+func (t *TempInventory) Stop() error {
+	errContext := "(builder::TempInventory::Stop)"
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.path == "" {
+		return nil
+	}
+
+	err := os.RemoveAll(t.dir)
+	if err != nil {
+		return errors.New(errContext, "Error removing the temporary inventory directory", err)
+	}
+
+	t.dir = ""
+	t.path = ""
+
+	return nil
+}
+
+// Path returns the path of the temporary inventory, to be set to the Inventory attribute of the ansible options. It is only available once the inventory is started
+func (t *TempInventory) Path() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.path
+}
+
+// EnvVars returns the ANSIBLE_INVENTORY environment variable that sets the temporary inventory as the default inventory of the ansible commands. It is only available once the inventory is started
+func (t *TempInventory) EnvVars() map[string]string {
+	path := t.Path()
+	if path == "" {
+		return map[string]string{}
+	}
+
+	return map[string]string{
+		AnsibleInventoryEnv: path,
+	}
+}
+
+// render returns the content, the file name and the file mode of the temporary inventory
+func (t *TempInventory) render() ([]byte, string, os.FileMode, error) {
+	switch t.Format {
+	case FormatScript:
+		content, err := t.Inventory.JSON()
+		if err != nil {
+			return nil, "", 0, err
+		}
+		script := fmt.Sprintf(inventoryScript, scriptHeredocDelimiter, content, scriptHeredocDelimiter)
+		return []byte(script), inventoryFileName, 0700, nil
+	case FormatINI:
+		content, err := t.Inventory.INI()
+		return content, inventoryFileName + ".ini", 0600, err
+	case FormatYAML:
+		content, err := t.Inventory.YAML()
+		return content, inventoryFileName + ".yml", 0600, err
+	case FormatJSON:
+		// the yaml inventory plugin loads the .json files, which must have the same structure as the YAML format
+		content, err := t.Inventory.YAML()
+		if err != nil {
+			return nil, "", 0, err
+		}
+		content, err = yamlToJSON(content)
+		return content, inventoryFileName + ".json", 0600, err
+	default:
+		return nil, "", 0, fmt.Errorf("inventory format '%s' is not supported", t.Format)
+	}
+}
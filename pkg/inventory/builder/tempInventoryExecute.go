@@ -0,0 +1,54 @@
+package builder
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// TempInventoryExecute is a middleware that passes the inventory to the executed ansible command through a TempInventory. The temporary inventory is created before the execution and removed after it
+type TempInventoryExecute struct {
+	executor  ExecutorEnvVarSetter
+	inventory *TempInventory
+}
+
+// NewTempInventoryExecute returns a TempInventoryExecute
+func NewTempInventoryExecute(executor ExecutorEnvVarSetter, inventory *Inventory, options ...TempInventoryOptionsFunc) *TempInventoryExecute {
+	return &TempInventoryExecute{
+		executor:  executor,
+		inventory: NewTempInventory(inventory, options...),
+	}
+}
+
+// WithExecutor sets the executor
+func (e *TempInventoryExecute) WithExecutor(executor ExecutorEnvVarSetter) *TempInventoryExecute {
+	e.executor = executor
+	return e
+}
+
+// Execute creates the temporary inventory, sets it as the default inventory of the executor through the ANSIBLE_INVENTORY environment variable and runs it. The inventory defined on the command options, if any, takes precedence over the temporary inventory
+func (e *TempInventoryExecute) Execute(ctx context.Context) (err error) {
+	errContext := "(builder::TempInventoryExecute::Execute)"
+
+	if e.executor == nil {
+		return errors.New(errContext, "Executor must be provided on TempInventoryExecute")
+	}
+
+	err = e.inventory.Start()
+	if err != nil {
+		return errors.New(errContext, "Error creating the temporary inventory", err)
+	}
+	defer func() {
+		stopErr := e.inventory.Stop()
+		if err == nil && stopErr != nil {
+			err = errors.New(errContext, "Error removing the temporary inventory", stopErr)
+		}
+	}()
+
+	for key, value := range e.inventory.EnvVars() {
+		e.executor.AddEnvVar(key, value)
+	}
+
+	// the error is returned as it is to keep it reachable by errors.Is and errors.As
+	return e.executor.Execute(ctx)
+}
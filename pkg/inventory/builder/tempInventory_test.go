@@ -0,0 +1,160 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTempInventory(t *testing.T) {
+	tests := []struct {
+		desc       string
+		format     Format
+		fileName   string
+		mode       os.FileMode
+		assertFunc func(t *testing.T, path string)
+	}{
+		{
+			desc:     "Testing write the inventory as a dynamic inventory script",
+			format:   FormatScript,
+			fileName: "inventory",
+			mode:     0700,
+			assertFunc: func(t *testing.T, path string) {
+				list, err := exec.Command(path, "--list").Output()
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"_meta":{"hostvars":{"web1":{"http_port":80}}},"all":{"children":["webservers"]},"webservers":{"hosts":["web1"]}}`, string(list))
+
+				host, err := exec.Command(path, "--host", "web1").Output()
+				assert.NoError(t, err)
+				assert.Equal(t, "{}\n", string(host))
+			},
+		},
+		{
+			desc:     "Testing write the inventory as an INI file",
+			format:   FormatINI,
+			fileName: "inventory.ini",
+			mode:     0600,
+			assertFunc: func(t *testing.T, path string) {
+				content, err := os.ReadFile(path)
+				assert.NoError(t, err)
+				assert.Equal(t, "[webservers]\nweb1 http_port=80\n", string(content))
+			},
+		},
+		{
+			desc:     "Testing write the inventory as a YAML file",
+			format:   FormatYAML,
+			fileName: "inventory.yml",
+			mode:     0600,
+			assertFunc: func(t *testing.T, path string) {
+				content, err := os.ReadFile(path)
+				assert.NoError(t, err)
+				assert.Equal(t, "all:\n  children:\n    webservers:\n      hosts:\n        web1:\n          http_port: 80\n", string(content))
+			},
+		},
+		{
+			desc:     "Testing write the inventory as a JSON file loaded by the yaml inventory plugin",
+			format:   FormatJSON,
+			fileName: "inventory.json",
+			mode:     0600,
+			assertFunc: func(t *testing.T, path string) {
+				content, err := os.ReadFile(path)
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"all":{"children":{"webservers":{"hosts":{"web1":{"http_port":80}}}}}}`, string(content))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			inventory := NewInventory()
+			assert.NoError(t, inventory.AddHost("web1", map[string]interface{}{"http_port": 80}, "webservers"))
+
+			temp := NewTempInventory(inventory, WithFormat(test.format))
+			assert.Empty(t, temp.Path())
+			assert.Empty(t, temp.EnvVars())
+
+			err := temp.Start()
+			assert.NoError(t, err)
+
+			path := temp.Path()
+			assert.Regexp(t, "/"+test.fileName+"$", path)
+			assert.Equal(t, map[string]string{AnsibleInventoryEnv: path}, temp.EnvVars())
+
+			info, err := os.Stat(path)
+			assert.NoError(t, err)
+			assert.Equal(t, test.mode, info.Mode().Perm())
+
+			test.assertFunc(t, path)
+
+			assert.ErrorContains(t, temp.Start(), "Temporary inventory is already started")
+
+			err = temp.Stop()
+			assert.NoError(t, err)
+			assert.NoFileExists(t, path)
+			assert.Empty(t, temp.Path())
+		})
+	}
+}
+
+func TestTempInventoryStartErrors(t *testing.T) {
+	tests := []struct {
+		desc       string
+		temp       *TempInventory
+		errMessage string
+	}{
+		{
+			desc:       "Testing start a temporary inventory without inventory",
+			temp:       NewTempInventory(nil),
+			errMessage: "Inventory must be provided to create a temporary inventory",
+		},
+		{
+			desc:       "Testing start a temporary inventory with an unsupported format",
+			temp:       NewTempInventory(NewInventory(), WithFormat(Format("toml"))),
+			errMessage: "Error rendering the temporary inventory",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.temp.Start()
+			assert.ErrorContains(t, err, test.errMessage)
+		})
+	}
+}
+
+func TestTempInventoryExecute(t *testing.T) {
+	envVars := map[string]string{}
+
+	inventory := NewInventory()
+	assert.NoError(t, inventory.AddHost("web1", nil, "webservers"))
+
+	executor := execute.NewMockExecute()
+	executor.On("AddEnvVar", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		envVars[args.String(0)] = args.String(1)
+	})
+	executor.On("Execute", mock.Anything).Run(func(args mock.Arguments) {
+		assert.FileExists(t, envVars[AnsibleInventoryEnv])
+	}).Return(errors.New("execution failed"))
+
+	exec := NewTempInventoryExecute(executor, inventory, WithFormat(FormatINI))
+
+	err := exec.Execute(context.TODO())
+	assert.EqualError(t, err, "execution failed")
+	assert.Regexp(t, "/inventory.ini$", envVars[AnsibleInventoryEnv])
+	assert.NoFileExists(t, envVars[AnsibleInventoryEnv])
+}
+
+func TestTempInventoryExecuteWithoutExecutor(t *testing.T) {
+	err := NewTempInventoryExecute(nil, NewInventory()).Execute(context.TODO())
+	assert.ErrorContains(t, err, "Executor must be provided on TempInventoryExecute")
+}
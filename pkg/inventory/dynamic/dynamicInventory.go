@@ -0,0 +1,77 @@
+package dynamic
+
+import (
+	"os"
+
+	"github.com/apenella/go-ansible/v2/pkg/inventory/builder"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// DynamicInventoryOptionsFunc is a function to set the DynamicInventory options
+type DynamicInventoryOptionsFunc func(*DynamicInventory)
+
+// DynamicInventory passes a registered inventory source to the ansible commands, using the Go binary itself as the dynamic inventory script. Ansible runs the binary with the `--list` flag, which is answered by HandleDynamicInventory
+type DynamicInventory struct {
+	// Source is the name of the registered inventory source
+	Source string
+
+	// Executable is the Go binary run as the dynamic inventory script. It is the current executable when it is not defined
+	Executable string
+}
+
+// NewDynamicInventory returns a DynamicInventory for the registered inventory source
+func NewDynamicInventory(source string, options ...DynamicInventoryOptionsFunc) *DynamicInventory {
+	inventory := &DynamicInventory{
+		Source: source,
+	}
+
+	for _, option := range options {
+		option(inventory)
+	}
+
+	return inventory
+}
+
+// WithExecutable sets the Go binary run as the dynamic inventory script
+func WithExecutable(executable string) DynamicInventoryOptionsFunc {
+	return func(d *DynamicInventory) {
+		d.Executable = executable
+	}
+}
+
+// Path returns the path of the dynamic inventory script, to be set to the Inventory attribute of the ansible options. The DynamicInventorySourceEnv and DynamicInventoryExecutableEnv environment variables must also be set to the command, as EnvVars does
+func (d *DynamicInventory) Path() (string, error) {
+	errContext := "(dynamic::DynamicInventory::Path)"
+
+	if d.Executable != "" {
+		return d.Executable, nil
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return "", errors.New(errContext, "Error getting the current executable", err)
+	}
+
+	return executable, nil
+}
+
+// EnvVars returns the environment variables required to run the inventory source, including the ANSIBLE_INVENTORY that sets the dynamic inventory script as the default inventory of the ansible commands
+func (d *DynamicInventory) EnvVars() (map[string]string, error) {
+	errContext := "(dynamic::DynamicInventory::EnvVars)"
+
+	_, err := Source(d.Source)
+	if err != nil {
+		return nil, errors.New(errContext, "Dynamic inventory source must be registered to be run by the Go binary", err)
+	}
+
+	path, err := d.Path()
+	if err != nil {
+		return nil, errors.New(errContext, "Error getting the dynamic inventory script path", err)
+	}
+
+	return map[string]string{
+		DynamicInventorySourceEnv:     d.Source,
+		DynamicInventoryExecutableEnv: path,
+		builder.AnsibleInventoryEnv:   path,
+	}, nil
+}
@@ -0,0 +1,48 @@
+package dynamic
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// DynamicInventoryExecute is a middleware that passes a registered inventory source to the executed ansible command through a DynamicInventory
+type DynamicInventoryExecute struct {
+	executor  ExecutorEnvVarSetter
+	inventory *DynamicInventory
+}
+
+// NewDynamicInventoryExecute returns a DynamicInventoryExecute
+func NewDynamicInventoryExecute(executor ExecutorEnvVarSetter, source string, options ...DynamicInventoryOptionsFunc) *DynamicInventoryExecute {
+	return &DynamicInventoryExecute{
+		executor:  executor,
+		inventory: NewDynamicInventory(source, options...),
+	}
+}
+
+// WithExecutor sets the executor
+func (e *DynamicInventoryExecute) WithExecutor(executor ExecutorEnvVarSetter) *DynamicInventoryExecute {
+	e.executor = executor
+	return e
+}
+
+// Execute sets the dynamic inventory environment variables to the executor and runs it. The Go binary is set as the default inventory through the ANSIBLE_INVENTORY environment variable, so the inventory defined on the command options, if any, takes precedence over it unless it is set to the DynamicInventory path
+func (e *DynamicInventoryExecute) Execute(ctx context.Context) error {
+	errContext := "(dynamic::DynamicInventoryExecute::Execute)"
+
+	if e.executor == nil {
+		return errors.New(errContext, "Executor must be provided on DynamicInventoryExecute")
+	}
+
+	envVars, err := e.inventory.EnvVars()
+	if err != nil {
+		return errors.New(errContext, "Error preparing the dynamic inventory", err)
+	}
+
+	for key, value := range envVars {
+		e.executor.AddEnvVar(key, value)
+	}
+
+	// the error is returned as it is to keep it reachable by errors.Is and errors.As
+	return e.executor.Execute(ctx)
+}
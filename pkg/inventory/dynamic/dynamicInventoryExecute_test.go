@@ -0,0 +1,76 @@
+package dynamic
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	"github.com/apenella/go-ansible/v2/pkg/inventory/builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDynamicInventoryExecute(t *testing.T) {
+	tests := []struct {
+		desc       string
+		executor   *execute.MockExecute
+		source     string
+		envVars    map[string]string
+		err        bool
+		errMessage string
+	}{
+		{
+			desc:     "Testing execute setting the dynamic inventory environment variables",
+			executor: execute.NewMockExecute(),
+			source:   testSourceName,
+			envVars: map[string]string{
+				DynamicInventorySourceEnv:     testSourceName,
+				DynamicInventoryExecutableEnv: "/usr/local/bin/deployer",
+				builder.AnsibleInventoryEnv:   "/usr/local/bin/deployer",
+			},
+			err:        true,
+			errMessage: "execution failed",
+		},
+		{
+			desc:       "Testing execute with an unregistered source",
+			executor:   execute.NewMockExecute(),
+			source:     "unknown",
+			envVars:    map[string]string{},
+			err:        true,
+			errMessage: "Dynamic inventory source must be registered to be run by the Go binary",
+		},
+		{
+			desc:       "Testing execute without executor",
+			source:     testSourceName,
+			envVars:    map[string]string{},
+			err:        true,
+			errMessage: "Executor must be provided on DynamicInventoryExecute",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			envVars := map[string]string{}
+
+			exec := NewDynamicInventoryExecute(nil, test.source, WithExecutable("/usr/local/bin/deployer"))
+			if test.executor != nil {
+				test.executor.On("AddEnvVar", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					envVars[args.String(0)] = args.String(1)
+				})
+				test.executor.On("Execute", mock.Anything).Return(errors.New("execution failed"))
+				exec.WithExecutor(test.executor)
+			}
+
+			err := exec.Execute(context.TODO())
+			if test.err {
+				assert.ErrorContains(t, err, test.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.envVars, envVars)
+		})
+	}
+}
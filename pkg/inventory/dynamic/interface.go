@@ -0,0 +1,22 @@
+package dynamic
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	"github.com/apenella/go-ansible/v2/pkg/inventory/builder"
+)
+
+// InventorySource is a dynamic inventory source implemented in Go
+type InventorySource interface {
+	// ListGroups returns the inventory groups, with their hosts, children and variables
+	ListGroups(ctx context.Context) ([]*builder.Group, error)
+	// HostVars returns the variables of the host
+	HostVars(ctx context.Context, host string) (map[string]interface{}, error)
+}
+
+// ExecutorEnvVarSetter is an executor that accepts environment variables
+type ExecutorEnvVarSetter interface {
+	execute.Executor
+	AddEnvVar(key, value string)
+}
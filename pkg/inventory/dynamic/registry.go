@@ -0,0 +1,41 @@
+package dynamic
+
+import (
+	"fmt"
+	"sync"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+var (
+	sourcesMutex sync.RWMutex
+	sources      = map[string]InventorySource{}
+)
+
+// Register registers the inventory source with the name. The Go binary runs the sources by name when it is re-invoked as a dynamic inventory script, so they must be registered before calling HandleDynamicInventory
+func Register(name string, source InventorySource) {
+	sourcesMutex.Lock()
+	defer sourcesMutex.Unlock()
+
+	if source == nil {
+		delete(sources, name)
+		return
+	}
+
+	sources[name] = source
+}
+
+// Source returns the inventory source registered with the name
+func Source(name string) (InventorySource, error) {
+	errContext := "(dynamic::Source)"
+
+	sourcesMutex.RLock()
+	defer sourcesMutex.RUnlock()
+
+	source, exists := sources[name]
+	if !exists {
+		return nil, errors.New(errContext, fmt.Sprintf("Dynamic inventory source '%s' is not registered", name))
+	}
+
+	return source, nil
+}
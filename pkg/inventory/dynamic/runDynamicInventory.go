@@ -0,0 +1,181 @@
+package dynamic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/apenella/go-ansible/v2/pkg/inventory/builder"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// DynamicInventorySourceEnv is the environment variable that holds the name of the inventory source run by the Go binary when it is re-invoked as a dynamic inventory script
+	DynamicInventorySourceEnv = "GO_ANSIBLE_DYNAMIC_INVENTORY_SOURCE"
+
+	// DynamicInventoryExecutableEnv is the environment variable that holds the path of the Go binary re-invoked as a dynamic inventory script. Any other binary that inherits the environment ignores the DynamicInventorySourceEnv environment variable
+	DynamicInventoryExecutableEnv = "GO_ANSIBLE_DYNAMIC_INVENTORY_EXECUTABLE"
+
+	// ListFlag is the flag used by ansible to request the whole inventory to a dynamic inventory script
+	ListFlag = "--list"
+
+	// HostFlag is the flag used by ansible to request the variables of a host to a dynamic inventory script
+	HostFlag = "--host"
+)
+
+// HandleDynamicInventory runs the inventory source and exits when the Go binary is re-invoked by ansible as a dynamic inventory script, that is, when it is started with the `--list` or `--host` flags, the DynamicInventorySourceEnv environment variable is set and the DynamicInventoryExecutableEnv environment variable holds the path of the binary. Otherwise, it returns without doing anything. The dynamic inventory environment variables are unset before running the source, so the processes started by the source do not inherit them. The Go binaries that provide dynamic inventory sources must call it at the beginning of their main function, once the sources are registered
+func HandleDynamicInventory() {
+	name := dynamicInventorySourceName(os.Args)
+	if name == "" {
+		return
+	}
+
+	os.Unsetenv(DynamicInventorySourceEnv)
+	os.Unsetenv(DynamicInventoryExecutableEnv)
+
+	source, err := Source(name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+
+	os.Exit(RunDynamicInventory(context.Background(), source, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// dynamicInventorySourceName returns the name of the inventory source to run when the Go binary is re-invoked as a dynamic inventory script, or an empty string otherwise
+func dynamicInventorySourceName(args []string) string {
+	name := os.Getenv(DynamicInventorySourceEnv)
+	if name == "" || len(args) < 2 || (args[1] != ListFlag && args[1] != HostFlag) {
+		return ""
+	}
+
+	if !isCurrentExecutable(os.Getenv(DynamicInventoryExecutableEnv)) {
+		return ""
+	}
+
+	return name
+}
+
+// isCurrentExecutable returns whether the path refers to the binary of the current process
+func isCurrentExecutable(path string) bool {
+	if path == "" {
+		return false
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return false
+	}
+
+	executableInfo, err := os.Stat(executable)
+	if err != nil {
+		return false
+	}
+
+	pathInfo, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+
+	return os.SameFile(executableInfo, pathInfo)
+}
+
+// RunDynamicInventory answers the dynamic inventory script request, received as `--list` or `--host <host>`, writing the JSON response to stdout. The `--list` response includes the variables of all the hosts in the `_meta` section, so ansible does not request them host by host. It returns the exit code expected by ansible
+func RunDynamicInventory(ctx context.Context, source InventorySource, args []string, stdout, stderr io.Writer) int {
+	var data []byte
+	var err error
+
+	switch {
+	case len(args) == 1 && args[0] == ListFlag:
+		data, err = listInventory(ctx, source)
+	case len(args) == 2 && args[0] == HostFlag:
+		data, err = hostInventory(ctx, source, args[1])
+	default:
+		err = fmt.Errorf("usage: %s | %s <host>", ListFlag, HostFlag)
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		return 1
+	}
+
+	fmt.Fprintln(stdout, string(data))
+	return 0
+}
+
+// listInventory returns the inventory of the source using the JSON format expected by ansible on `--list`
+func listInventory(ctx context.Context, source InventorySource) ([]byte, error) {
+	errContext := "(dynamic::listInventory)"
+
+	groups, err := source.ListGroups(ctx)
+	if err != nil {
+		return nil, errors.New(errContext, "Error listing the groups of the dynamic inventory source", err)
+	}
+
+	inventory := builder.NewInventory()
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+
+		// the hosts of the all and ungrouped groups are added without groups, which places them in the ungrouped group unless another group contains them
+		if group.Name != builder.UngroupedGroup {
+			err = inventory.AddGroup(group.Name, group.Vars)
+			if err != nil {
+				return nil, errors.New(errContext, fmt.Sprintf("Error adding group '%s' to the dynamic inventory", group.Name), err)
+			}
+		}
+
+		// the all group contains every group implicitly
+		if group.Name != builder.AllGroup && group.Name != builder.UngroupedGroup && len(group.Children) > 0 {
+			err = inventory.AddChildren(group.Name, group.Children...)
+			if err != nil {
+				return nil, errors.New(errContext, fmt.Sprintf("Error adding the children of group '%s' to the dynamic inventory", group.Name), err)
+			}
+		}
+
+		for _, host := range group.Hosts {
+			err = inventory.AddHost(host, nil, group.Name)
+			if err != nil {
+				return nil, errors.New(errContext, fmt.Sprintf("Error adding host '%s' of group '%s' to the dynamic inventory", host, group.Name), err)
+			}
+		}
+	}
+
+	for name := range inventory.Hosts {
+		vars, err := source.HostVars(ctx, name)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error getting the variables of host '%s' from the dynamic inventory source", name), err)
+		}
+
+		err = inventory.AddHost(name, vars)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error setting the variables of host '%s' to the dynamic inventory", name), err)
+		}
+	}
+
+	return inventory.JSON()
+}
+
+// hostInventory returns the variables of the host using the JSON format expected by ansible on `--host`
+func hostInventory(ctx context.Context, source InventorySource, host string) ([]byte, error) {
+	errContext := "(dynamic::hostInventory)"
+
+	vars, err := source.HostVars(ctx, host)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error getting the variables of host '%s' from the dynamic inventory source", host), err)
+	}
+
+	if vars == nil {
+		vars = map[string]interface{}{}
+	}
+
+	data, err := json.Marshal(vars)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error writing the variables of host '%s'", host), err)
+	}
+
+	return data, nil
+}
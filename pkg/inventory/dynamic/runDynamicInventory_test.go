@@ -0,0 +1,279 @@
+package dynamic
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/inventory/builder"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testSourceName            = "test"
+	testEnvironmentSourceName = "environment"
+)
+
+// testSource is the inventory source used by the tests
+type testSource struct {
+	groups      []*builder.Group
+	hostVars    map[string]map[string]interface{}
+	groupsErr   error
+	hostVarsErr error
+}
+
+func (s *testSource) ListGroups(ctx context.Context) ([]*builder.Group, error) {
+	return s.groups, s.groupsErr
+}
+
+func (s *testSource) HostVars(ctx context.Context, host string) (map[string]interface{}, error) {
+	return s.hostVars[host], s.hostVarsErr
+}
+
+func newTestSource() *testSource {
+	return &testSource{
+		groups: []*builder.Group{
+			{Name: "all", Hosts: []string{"bastion"}, Vars: map[string]interface{}{"env": "production"}},
+			{Name: "webservers", Hosts: []string{"web1", "web2"}, Children: []string{"frontend"}, Vars: map[string]interface{}{"tier": "web"}},
+			{Name: "frontend", Hosts: []string{"web3"}},
+		},
+		hostVars: map[string]map[string]interface{}{
+			"web1": {"ansible_host": "10.0.0.1"},
+		},
+	}
+}
+
+// environmentSource is the inventory source that returns the dynamic inventory environment variables as host variables
+type environmentSource struct{}
+
+func (s *environmentSource) ListGroups(ctx context.Context) ([]*builder.Group, error) {
+	return nil, nil
+}
+
+func (s *environmentSource) HostVars(ctx context.Context, host string) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"executable": os.Getenv(DynamicInventoryExecutableEnv),
+		"source":     os.Getenv(DynamicInventorySourceEnv),
+	}, nil
+}
+
+// TestMain lets the test binary act as a dynamic inventory script
+func TestMain(m *testing.M) {
+	Register(testSourceName, newTestSource())
+	Register(testEnvironmentSourceName, &environmentSource{})
+	HandleDynamicInventory()
+
+	os.Exit(m.Run())
+}
+
+func TestRunDynamicInventory(t *testing.T) {
+	tests := []struct {
+		desc     string
+		source   *testSource
+		args     []string
+		exitCode int
+		stdout   string
+		stderr   string
+	}{
+		{
+			desc:     "Testing list the dynamic inventory",
+			source:   newTestSource(),
+			args:     []string{"--list"},
+			exitCode: 0,
+			stdout:   `{"_meta":{"hostvars":{"bastion":{},"web1":{"ansible_host":"10.0.0.1"},"web2":{},"web3":{}}},"all":{"children":["ungrouped","webservers"],"vars":{"env":"production"}},"frontend":{"hosts":["web3"]},"ungrouped":{"hosts":["bastion"]},"webservers":{"children":["frontend"],"hosts":["web1","web2"],"vars":{"tier":"web"}}}` + "\n",
+		},
+		{
+			desc:     "Testing get the variables of a host",
+			source:   newTestSource(),
+			args:     []string{"--host", "web1"},
+			exitCode: 0,
+			stdout:   `{"ansible_host":"10.0.0.1"}` + "\n",
+		},
+		{
+			desc:     "Testing get the variables of a host without variables",
+			source:   newTestSource(),
+			args:     []string{"--host", "web2"},
+			exitCode: 0,
+			stdout:   "{}\n",
+		},
+		{
+			desc:     "Testing run the dynamic inventory with invalid arguments",
+			source:   newTestSource(),
+			args:     []string{"--host"},
+			exitCode: 1,
+			stderr:   "usage: --list | --host <host>\n",
+		},
+		{
+			desc:     "Testing list the dynamic inventory when the groups can not be listed",
+			source:   &testSource{groupsErr: errors.New("api unavailable")},
+			args:     []string{"--list"},
+			exitCode: 1,
+			stderr:   "Error listing the groups of the dynamic inventory source",
+		},
+		{
+			desc: "Testing list the dynamic inventory when the host variables can not be obtained",
+			source: &testSource{
+				groups:      []*builder.Group{{Name: "webservers", Hosts: []string{"web1"}}},
+				hostVarsErr: errors.New("api unavailable"),
+			},
+			args:     []string{"--list"},
+			exitCode: 1,
+			stderr:   "Error getting the variables of host 'web1' from the dynamic inventory source",
+		},
+		{
+			desc: "Testing list the dynamic inventory with a cycle between groups",
+			source: &testSource{
+				groups: []*builder.Group{
+					{Name: "webservers", Children: []string{"frontend"}},
+					{Name: "frontend", Children: []string{"webservers"}},
+				},
+			},
+			args:     []string{"--list"},
+			exitCode: 1,
+			stderr:   "Error adding the children of group 'frontend' to the dynamic inventory",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			var stdout, stderr bytes.Buffer
+
+			exitCode := RunDynamicInventory(context.TODO(), test.source, test.args, &stdout, &stderr)
+			assert.Equal(t, test.exitCode, exitCode)
+			assert.Equal(t, test.stdout, stdout.String())
+			assert.Contains(t, stderr.String(), test.stderr)
+		})
+	}
+}
+
+func TestHandleDynamicInventory(t *testing.T) {
+	tests := []struct {
+		desc       string
+		source     string
+		executable string
+		exitCode   int
+		stdout     string
+		stderr     string
+	}{
+		{
+			desc:   "Testing run the Go binary as a dynamic inventory script",
+			source: testSourceName,
+			stdout: `{"ansible_host":"10.0.0.1"}` + "\n",
+		},
+		{
+			desc:     "Testing run the Go binary as a dynamic inventory script with an unregistered source",
+			source:   "unknown",
+			exitCode: 1,
+			stderr:   "Dynamic inventory source 'unknown' is not registered",
+		},
+		{
+			desc:   "Testing run the Go binary as a dynamic inventory script unsets the dynamic inventory environment variables",
+			source: testEnvironmentSourceName,
+			stdout: `{"executable":"","source":""}` + "\n",
+		},
+		{
+			desc:       "Testing run the Go binary with the dynamic inventory environment variables of another binary",
+			source:     testSourceName,
+			executable: os.Args[0] + ".other",
+			exitCode:   2,
+			stderr:     "flag provided but not defined: -host",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			var stdout, stderr bytes.Buffer
+
+			path, err := NewDynamicInventory(test.source).Path()
+			assert.NoError(t, err)
+
+			executable := path
+			if test.executable != "" {
+				executable = test.executable
+			}
+
+			cmd := exec.Command(path, "--host", "web1")
+			cmd.Env = append(os.Environ(), DynamicInventorySourceEnv+"="+test.source, DynamicInventoryExecutableEnv+"="+executable)
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+
+			_ = cmd.Run()
+			assert.Equal(t, test.exitCode, cmd.ProcessState.ExitCode())
+			assert.Equal(t, test.stdout, stdout.String())
+			assert.Contains(t, stderr.String(), test.stderr)
+		})
+	}
+}
+
+func TestDynamicInventorySourceName(t *testing.T) {
+	executable, err := os.Executable()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		desc       string
+		args       []string
+		source     string
+		executable string
+		res        string
+	}{
+		{
+			desc:       "Testing get the source name when the binary is re-invoked with the list flag",
+			args:       []string{executable, "--list"},
+			source:     testSourceName,
+			executable: executable,
+			res:        testSourceName,
+		},
+		{
+			desc:       "Testing get the source name when the binary is re-invoked with the host flag",
+			args:       []string{executable, "--host", "web1"},
+			source:     testSourceName,
+			executable: executable,
+			res:        testSourceName,
+		},
+		{
+			desc:       "Testing get the source name without the source environment variable",
+			args:       []string{executable, "--list"},
+			executable: executable,
+			res:        "",
+		},
+		{
+			desc:   "Testing get the source name without the executable environment variable",
+			args:   []string{executable, "--list"},
+			source: testSourceName,
+			res:    "",
+		},
+		{
+			desc:       "Testing get the source name when the executable environment variable holds another binary",
+			args:       []string{executable, "--list"},
+			source:     testSourceName,
+			executable: "/usr/local/bin/deployer",
+			res:        "",
+		},
+		{
+			desc:       "Testing get the source name without the dynamic inventory flags",
+			args:       []string{executable, "--verbose"},
+			source:     testSourceName,
+			executable: executable,
+			res:        "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			t.Setenv(DynamicInventorySourceEnv, test.source)
+			t.Setenv(DynamicInventoryExecutableEnv, test.executable)
+
+			res := dynamicInventorySourceName(test.args)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package playbook
 import (
 	"fmt"
 
+	"github.com/apenella/go-ansible/v2/pkg/redact"
 	errors "github.com/apenella/go-common-utils/error"
 )
 
@@ -87,7 +88,7 @@ func (p *AnsiblePlaybookCmd) Command() ([]string, error) {
 	return cmd, nil
 }
 
-// String returns AnsiblePlaybookCmd as string
+// String returns AnsiblePlaybookCmd as string. The secrets registered on the redact package and the secrets of the options are masked
 func (p *AnsiblePlaybookCmd) String() string {
 
 	// Use default binary when it is not already defined
@@ -106,5 +107,14 @@ func (p *AnsiblePlaybookCmd) String() string {
 		str = fmt.Sprintf("%s %s", str, playbook)
 	}
 
-	return str
+	return redact.RedactWith(str, p.Secrets()...)
+}
+
+// Secrets returns the secrets of the options, which DefaultExecute masks on the outputs of the execution
+func (p *AnsiblePlaybookCmd) Secrets() []string {
+	if p.PlaybookOptions == nil {
+		return []string{}
+	}
+
+	return p.PlaybookOptions.Secrets()
 }
@@ -168,6 +168,20 @@ func TestString(t *testing.T) {
 			},
 			res: "ansible-playbook  --ask-vault-password --check --diff --extra-vars '{\"var1\":\"value1\"}' --extra-vars @test/ansible/extra_vars.yml --flush-cache --force-handlers --forks 10 --inventory test/ansible/inventory/all --limit myhost --list-hosts --list-tags --list-tasks --module-path /dev/null --skip-tags tagN --start-at-task task1 --step --syntax-check --tags tag1 --vault-id asdf --vault-password-file /dev/null -vvvv --version --ask-pass --connection local --private-key pk --timeout 10 --user apenella --ask-become-pass --become --become-method sudo --become-user apenella test/ansible/site.yml test/ansible/site2.yml",
 		},
+		{
+			desc: "Testing AnsiblePlaybookCmd to string masking the extra vars that hold a secret",
+			err:  nil,
+			ansiblePlaybookCmd: &AnsiblePlaybookCmd{
+				Playbooks: []string{"test/ansible/site.yml"},
+				PlaybookOptions: &AnsiblePlaybookOptions{
+					Become: true,
+					ExtraVars: map[string]interface{}{
+						"ansible_become_password": "b3c0m3-p4ss",
+					},
+				},
+			},
+			res: "ansible-playbook  --extra-vars '{\"ansible_become_password\":\"********\"}' --become test/ansible/site.yml",
+		},
 	}
 
 	for _, test := range tests {
@@ -1,75 +1,60 @@
 package playbook
 
 import (
-	"github.com/pkg/errors"
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	jsonresults "github.com/apenella/go-ansible/v2/pkg/execute/result/json"
 )
 
 const (
-	// TODO: error management
 	// AnsiblePlaybookErrorCodeGeneralError is the error code for a general error
-	AnsiblePlaybookErrorCodeGeneralError = 1
+	AnsiblePlaybookErrorCodeGeneralError = execute.AnsiblePlaybookErrorCodeGeneralError
 	// AnsiblePlaybookErrorCodeOneOrMoreHostFailed is the error code for a one or more host failed
-	AnsiblePlaybookErrorCodeOneOrMoreHostFailed = 2
+	AnsiblePlaybookErrorCodeOneOrMoreHostFailed = execute.AnsiblePlaybookErrorCodeOneOrMoreHostFailed
 	// AnsiblePlaybookErrorCodeOneOrMoreHostUnreachable is the error code for a one or more host unreachable
-	AnsiblePlaybookErrorCodeOneOrMoreHostUnreachable = 3
+	AnsiblePlaybookErrorCodeOneOrMoreHostUnreachable = execute.AnsiblePlaybookErrorCodeOneOrMoreHostUnreachable
 	// AnsiblePlaybookErrorCodeParserError is the error code for a parser error
-	AnsiblePlaybookErrorCodeParserError = 4
+	AnsiblePlaybookErrorCodeParserError = execute.AnsiblePlaybookErrorCodeParserError
 	// AnsiblePlaybookErrorCodeBadOrIncompleteOptions is the error code for a bad or incomplete options
-	AnsiblePlaybookErrorCodeBadOrIncompleteOptions = 5
+	AnsiblePlaybookErrorCodeBadOrIncompleteOptions = execute.AnsiblePlaybookErrorCodeBadOrIncompleteOptions
 	// AnsiblePlaybookErrorCodeUserInterruptedExecution is the error code for a user interrupted execution
-	AnsiblePlaybookErrorCodeUserInterruptedExecution = 99
+	AnsiblePlaybookErrorCodeUserInterruptedExecution = execute.AnsiblePlaybookErrorCodeUserInterruptedExecution
 	// AnsiblePlaybookErrorCodeUnexpectedError is the error code for a unexpected error
-	AnsiblePlaybookErrorCodeUnexpectedError = 250
+	AnsiblePlaybookErrorCodeUnexpectedError = execute.AnsiblePlaybookErrorCodeUnexpectedError
 
 	// AnsiblePlaybookErrorMessageGeneralError is the error message for a general error
-	AnsiblePlaybookErrorMessageGeneralError = "ansible-playbook error: general error"
+	AnsiblePlaybookErrorMessageGeneralError = execute.AnsiblePlaybookErrorMessageGeneralError
 	// AnsiblePlaybookErrorMessageOneOrMoreHostFailed is the error message for a one or more host failed
-	AnsiblePlaybookErrorMessageOneOrMoreHostFailed = "ansible-playbook error: one or more host failed"
+	AnsiblePlaybookErrorMessageOneOrMoreHostFailed = execute.AnsiblePlaybookErrorMessageOneOrMoreHostFailed
 	// AnsiblePlaybookErrorMessageOneOrMoreHostUnreachable is the error message for a one or more host unreachable
-	AnsiblePlaybookErrorMessageOneOrMoreHostUnreachable = "ansible-playbook error: one or more host unreachable"
+	AnsiblePlaybookErrorMessageOneOrMoreHostUnreachable = execute.AnsiblePlaybookErrorMessageOneOrMoreHostUnreachable
 	// AnsiblePlaybookErrorMessageParserError is the error message for a parser error
-	AnsiblePlaybookErrorMessageParserError = "ansible-playbook error: parser error"
+	AnsiblePlaybookErrorMessageParserError = execute.AnsiblePlaybookErrorMessageParserError
 	// AnsiblePlaybookErrorMessageBadOrIncompleteOptions is the error message for a bad or incomplete options
-	AnsiblePlaybookErrorMessageBadOrIncompleteOptions = "ansible-playbook error: bad or incomplete options"
+	AnsiblePlaybookErrorMessageBadOrIncompleteOptions = execute.AnsiblePlaybookErrorMessageBadOrIncompleteOptions
 	// AnsiblePlaybookErrorMessageUserInterruptedExecution is the error message for a user interrupted execution
-	AnsiblePlaybookErrorMessageUserInterruptedExecution = "ansible-playbook error: user interrupted execution"
+	AnsiblePlaybookErrorMessageUserInterruptedExecution = execute.AnsiblePlaybookErrorMessageUserInterruptedExecution
 	// AnsiblePlaybookErrorMessageUnexpectedError is the error message for a unexpected error
-	AnsiblePlaybookErrorMessageUnexpectedError = "ansible-playbook error: unexpected error"
+	AnsiblePlaybookErrorMessageUnexpectedError = execute.AnsiblePlaybookErrorMessageUnexpectedError
 )
 
 // AnsiblePlaybookErrorEnrich is an error enricher for ansible-playbook errors
-type AnsiblePlaybookErrorEnrich struct{}
+type AnsiblePlaybookErrorEnrich struct {
+	enrich *execute.ExitCodeErrorEnrich
+}
 
 // NewAnsiblePlaybookErrorEnrich creates a new AnsiblePlaybookErrorEnrich instance
 func NewAnsiblePlaybookErrorEnrich() *AnsiblePlaybookErrorEnrich {
-	return &AnsiblePlaybookErrorEnrich{}
+	return &AnsiblePlaybookErrorEnrich{
+		enrich: execute.NewExitCodeErrorEnrich(DefaultAnsiblePlaybookBinary),
+	}
 }
 
-// Enrich return an error enriched with ansible-playbook error information
+// Enrich return an error enriched with ansible-playbook error information. The returned error is one of the typed errors defined in the execute package, such as execute.HostFailedError, when the exit code is known
 func (e *AnsiblePlaybookErrorEnrich) Enrich(err error) error {
+	return e.enrich.Enrich(err)
+}
 
-	var errorMessage string
-
-	_, hasExitCode := err.(ExitCodeErrorer)
-
-	if hasExitCode {
-		switch err.(ExitCodeErrorer).ExitCode() {
-		case AnsiblePlaybookErrorCodeGeneralError:
-			errorMessage = AnsiblePlaybookErrorMessageGeneralError
-		case AnsiblePlaybookErrorCodeOneOrMoreHostFailed:
-			errorMessage = AnsiblePlaybookErrorMessageOneOrMoreHostFailed
-		case AnsiblePlaybookErrorCodeOneOrMoreHostUnreachable:
-			errorMessage = AnsiblePlaybookErrorMessageOneOrMoreHostUnreachable
-		case AnsiblePlaybookErrorCodeParserError:
-			errorMessage = AnsiblePlaybookErrorMessageParserError
-		case AnsiblePlaybookErrorCodeBadOrIncompleteOptions:
-			errorMessage = AnsiblePlaybookErrorMessageBadOrIncompleteOptions
-		case AnsiblePlaybookErrorCodeUserInterruptedExecution:
-			errorMessage = AnsiblePlaybookErrorMessageUserInterruptedExecution
-		case AnsiblePlaybookErrorCodeUnexpectedError:
-			errorMessage = AnsiblePlaybookErrorMessageUnexpectedError
-		}
-	}
-
-	return errors.Wrap(err, errorMessage)
+// EnrichWithResults return an error enriched with ansible-playbook error information, including the failed or unreachable hosts found in the results stats
+func (e *AnsiblePlaybookErrorEnrich) EnrichWithResults(err error, results *jsonresults.AnsiblePlaybookJSONResults) error {
+	return e.enrich.EnrichWithResults(err, results)
 }
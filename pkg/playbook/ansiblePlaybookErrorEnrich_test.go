@@ -1,10 +1,13 @@
 package playbook
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/apenella/go-ansible/v2/mocks"
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	jsonresults "github.com/apenella/go-ansible/v2/pkg/execute/result/json"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -81,3 +84,27 @@ func TestEnrich(t *testing.T) {
 		})
 	}
 }
+
+func TestEnrichWithResults(t *testing.T) {
+	t.Log("Testing enrich with results a ansible-playbook hosts failed error")
+
+	results := &jsonresults.AnsiblePlaybookJSONResults{
+		Stats: map[string]*jsonresults.AnsiblePlaybookJSONResultsStats{
+			"host1": {Failures: 1},
+			"host2": {Ok: 1},
+		},
+	}
+
+	e := NewAnsiblePlaybookErrorEnrich()
+	err := e.EnrichWithResults(&mocks.MockExitCodeErr{
+		Code:    AnsiblePlaybookErrorCodeOneOrMoreHostFailed,
+		Message: "error cause",
+	}, results)
+
+	var hostFailedErr *execute.HostFailedError
+	assert.True(t, errors.As(err, &hostFailedErr))
+	assert.True(t, errors.Is(err, execute.ErrHostFailed))
+	assert.Equal(t, []string{"host1"}, hostFailedErr.Hosts)
+	assert.Equal(t, AnsiblePlaybookErrorCodeOneOrMoreHostFailed, hostFailedErr.ExitCode())
+	assert.Equal(t, fmt.Sprintf("%s [host1]: %s", AnsiblePlaybookErrorMessageOneOrMoreHostFailed, "error cause"), err.Error())
+}
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/apenella/go-ansible/v2/pkg/vault"
+	"github.com/apenella/go-ansible/v2/pkg/vault/encrypt"
 	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -73,6 +75,18 @@ func TestGenerateCommandOptions(t *testing.T) {
 			err:     nil,
 			options: []string{"--ask-vault-password", "--check", "--diff", "--extra-vars", "{\"extra\":\"var\"}", "--extra-vars", "@test.yml", "--flush-cache", "--force-handlers", "--forks", "10", "--inventory", "inventory", "--limit", "limit", "--list-hosts", "--list-tags", "--list-tasks", "--module-path", "module-path", "--skip-tags", "skip-tags", "--start-at-task", "start-at-task", "--step", "--syntax-check", "--tags", "tags", "--vault-id", "vault-ID", "--vault-password-file", "vault-password-file", "-vvvv", "--version", "--ask-pass", "--connection", "local", "--private-key", "private-key", "--scp-extra-args", "scp-extra-args1 scp-extra-args2", "--sftp-extra-args", "sftp-extra-args1 sftp-extra-args2", "--ssh-common-args", "ssh-common-args1 ssh-common-args2", "--ssh-extra-args", "ssh-extra-args1 ssh-extra-args2", "--timeout", "11", "--user", "user", "--ask-become-pass", "--become", "--become-method", "become-method", "--become-user", "become-user"},
 		},
+		{
+			desc: "Testing AnsiblePlaybookOptions with a vault keyring",
+			ansiblePlaybookOptions: &AnsiblePlaybookOptions{
+				VaultID: "default@vault-password-file",
+				VaultKeyring: vault.NewVaultKeyring(
+					vault.WithVaultIdentitySource("dev", "dev-password-file"),
+					vault.WithVaultIdentitySource("prod", vault.VaultIDPromptSource),
+				),
+			},
+			err:     nil,
+			options: []string{"--vault-id", "default@vault-password-file", "--vault-id", "dev@dev-password-file", "--vault-id", "prod@prompt"},
+		},
 	}
 
 	for _, test := range tests {
@@ -342,6 +356,7 @@ func TestAddVaultedExtraVar(t *testing.T) {
 				assert.Equal(t, test.err, err)
 			} else {
 				assert.Equal(t, test.res, test.options.ExtraVars, "Unexpected options value")
+				assert.Equal(t, []string{test.value}, test.options.Secrets())
 			}
 		})
 	}
@@ -516,3 +531,94 @@ func TestAnsiblePlaybookOptionsString(t *testing.T) {
 		})
 	}
 }
+
+// AddVaultedExtraVars(vaulter Vaulter, vars map[string]interface{})
+func TestAddVaultedExtraVars(t *testing.T) {
+	vaulter := vault.NewMockVariableVaulter()
+	vaulter.On("Vault", "admin").Return(vault.NewVaultVariableValue("encrypted_admin"), nil)
+	vaulter.On("Vault", "secret").Return(vault.NewVaultVariableValue("encrypted_secret"), nil)
+
+	tests := []struct {
+		desc    string
+		options *AnsiblePlaybookOptions
+		vaulter Vaulter
+		vars    map[string]interface{}
+		res     map[string]interface{}
+		err     error
+	}{
+		{
+			desc:    "Testing add vaulted extra-vars",
+			options: &AnsiblePlaybookOptions{},
+			vaulter: vaulter,
+			vars: map[string]interface{}{
+				"user": "admin",
+				"db": map[string]interface{}{
+					"password": "secret",
+					"port":     5432,
+				},
+			},
+			res: map[string]interface{}{
+				"user": vault.NewVaultVariableValue("encrypted_admin"),
+				"db": map[string]interface{}{
+					"password": vault.NewVaultVariableValue("encrypted_secret"),
+					"port":     5432,
+				},
+			},
+		},
+		{
+			desc:    "Testing error adding vaulted extra-vars when vaulter is nil",
+			options: &AnsiblePlaybookOptions{},
+			vaulter: nil,
+			err:     errors.New("(playbook::AddVaultedExtraVars)", "To define vaulted extra-vars you need to initialize a vaulter"),
+		},
+		{
+			desc: "Testing error adding vaulted extra-vars when a variable already exist",
+			options: &AnsiblePlaybookOptions{
+				ExtraVars: map[string]interface{}{
+					"user": "admin",
+				},
+			},
+			vaulter: vaulter,
+			vars: map[string]interface{}{
+				"user": "admin",
+			},
+			err: errors.New("(playbook::AddVaultedExtraVars)", "ExtraVar 'user' already exist"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.AddVaultedExtraVars(test.vaulter, test.vars)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.res, test.options.ExtraVars, "Unexpected options value")
+				// every vaulted value is masked on the outputs
+				assert.ElementsMatch(t, []string{"admin", "secret"}, test.options.Secrets())
+			}
+		})
+	}
+}
+
+// AddVaultedExtraVarsFile(fs afero.Fs, encrypter vault.Encrypter, file, vaultedFile string)
+func TestAddVaultedExtraVarsFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_ = afero.WriteFile(fs, "/vars.yml", []byte("password: secret\n"), 0600)
+
+	encrypter := encrypt.NewMockEncryptString()
+	encrypter.On("Encrypt", "password: secret\n").Return("$ANSIBLE_VAULT;1.1;AES256\n3238", nil)
+
+	options := &AnsiblePlaybookOptions{}
+	err := options.AddVaultedExtraVarsFile(fs, encrypter, "@/vars.yml", "/vars.vault.yml")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"@/vars.vault.yml"}, options.ExtraVarsFile)
+
+	content, _ := afero.ReadFile(fs, "/vars.vault.yml")
+	assert.Equal(t, "$ANSIBLE_VAULT;1.1;AES256\n3238\n", string(content))
+
+	err = options.AddVaultedExtraVarsFile(fs, nil, "/vars.yml", "/vars.vault.yml")
+	assert.Equal(t, errors.New("(playbook::AddVaultedExtraVarsFile)", "To define a vaulted extra-vars file you need to initialize an encrypter"), err)
+}
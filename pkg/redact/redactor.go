@@ -0,0 +1,381 @@
+package redact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf16"
+)
+
+const (
+	// RedactedMask is the string that replaces the secrets
+	RedactedMask = "********"
+)
+
+var (
+	// sensitiveNameRegexp matches the names of the variables and environment variables that hold a secret, such as ANSIBLE_BECOME_PASS or ansible_become_password
+	sensitiveNameRegexp = regexp.MustCompile(`(?i)(^|_)(pass|passwd|password|secret|token)$`)
+
+	// askNameRegexp matches the names of the variables and environment variables that enable prompting for a secret, such as ANSIBLE_ASK_PASS, which do not hold a secret
+	askNameRegexp = regexp.MustCompile(`(?i)(^|_)ask_(pass|password)$`)
+
+	// defaultRedactor is the redactor where the caller registers the secrets to be masked in the outputs, the error messages and the commands of the whole process. The library does not register any secret on it
+	defaultRedactor = NewRedactor()
+)
+
+// redactorContextKey is the key of the redactor carried by a context
+type redactorContextKey struct{}
+
+// SecretsProvider is implemented by the components that know the secrets they hold, such as the commands whose extra-vars hold a password. DefaultExecute registers those secrets on the redactor of each execution
+type SecretsProvider interface {
+	Secrets() []string
+}
+
+// Redactor masks the registered secrets
+type Redactor struct {
+	mutex    sync.RWMutex
+	secrets  map[string]struct{}
+	replacer *strings.Replacer
+}
+
+// NewRedactor returns a Redactor that masks the secrets
+func NewRedactor(secrets ...string) *Redactor {
+	redactor := &Redactor{
+		secrets: map[string]struct{}{},
+	}
+
+	redactor.AddSecret(secrets...)
+
+	return redactor
+}
+
+// AddSecret registers the secrets to be masked. Empty secrets are ignored and each line of a multiline secret is also registered, since the outputs are processed line by line
+func (r *Redactor) AddSecret(secrets ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, secret := range secrets {
+		for _, s := range secretValues(secret) {
+			r.secrets[s] = struct{}{}
+		}
+	}
+
+	r.replacer = nil
+}
+
+// RemoveSecret unregisters the secrets
+func (r *Redactor) RemoveSecret(secrets ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, secret := range secrets {
+		for _, s := range secretValues(secret) {
+			delete(r.secrets, s)
+		}
+	}
+
+	r.replacer = nil
+}
+
+// Reset unregisters all the secrets
+func (r *Redactor) Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.secrets = map[string]struct{}{}
+	r.replacer = nil
+}
+
+// Secrets returns the registered secrets
+func (r *Redactor) Secrets() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	secrets := make([]string, 0, len(r.secrets))
+	for secret := range r.secrets {
+		secrets = append(secrets, secret)
+	}
+	sort.Strings(secrets)
+
+	return secrets
+}
+
+// AddVariables registers the string values of the variables whose name holds a secret, such as ansible_become_password, including the ones of the nested maps
+func (r *Redactor) AddVariables(vars map[string]interface{}) {
+	r.AddSecret(SensitiveValues(vars)...)
+}
+
+// AddEnvVars registers the values of the environment variables whose name holds a secret, such as ANSIBLE_BECOME_PASS
+func (r *Redactor) AddEnvVars(envVars map[string]string) {
+	for name, value := range envVars {
+		if IsSensitiveName(name) {
+			r.AddSecret(value)
+		}
+	}
+}
+
+// Redact returns the value with the registered secrets masked. The longest secrets are masked first, so a secret that contains another one is completely masked
+func (r *Redactor) Redact(value string) string {
+	if value == "" {
+		return value
+	}
+
+	replacer := r.getReplacer()
+	if replacer == nil {
+		return value
+	}
+
+	return replacer.Replace(value)
+}
+
+// RedactEnvVar returns the value of the environment variable masked when its name holds a secret, otherwise the registered secrets are masked on the value
+func (r *Redactor) RedactEnvVar(name, value string) string {
+	if IsSensitiveName(name) && value != "" {
+		return RedactedMask
+	}
+
+	return r.Redact(value)
+}
+
+// getReplacer returns the replacer of the registered secrets, creating it when the secrets have changed. It returns nil when there are no secrets
+func (r *Redactor) getReplacer() *strings.Replacer {
+	r.mutex.RLock()
+	replacer := r.replacer
+	numSecrets := len(r.secrets)
+	r.mutex.RUnlock()
+
+	if replacer != nil || numSecrets == 0 {
+		return replacer
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.replacer != nil {
+		return r.replacer
+	}
+
+	// the JSON escaped forms of the secrets are also masked, since that is how they are found on the JSON outputs
+	values := map[string]struct{}{}
+	for secret := range r.secrets {
+		values[secret] = struct{}{}
+		for _, escaped := range jsonEscapedValues(secret) {
+			values[escaped] = struct{}{}
+		}
+	}
+
+	secrets := make([]string, 0, len(values))
+	for secret := range values {
+		secrets = append(secrets, secret)
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		if len(secrets[i]) != len(secrets[j]) {
+			return len(secrets[i]) > len(secrets[j])
+		}
+		return secrets[i] < secrets[j]
+	})
+
+	oldnew := make([]string, 0, len(secrets)*2)
+	for _, secret := range secrets {
+		oldnew = append(oldnew, secret, RedactedMask)
+	}
+	r.replacer = strings.NewReplacer(oldnew...)
+
+	return r.replacer
+}
+
+// secretValues returns the values to register for the secret: the secret itself and, when it is multiline, each one of its lines
+func secretValues(secret string) []string {
+	values := []string{}
+
+	secret = strings.TrimRight(secret, "\r\n")
+	if secret == "" {
+		return values
+	}
+	values = append(values, secret)
+
+	if strings.Contains(secret, "\n") {
+		for _, line := range strings.Split(secret, "\n") {
+			line = strings.TrimRight(line, "\r")
+			if strings.TrimSpace(line) != "" {
+				values = append(values, line)
+			}
+		}
+	}
+
+	return values
+}
+
+// jsonEscapedValues returns the forms of the secret once it is escaped to be a JSON string, as encoded by Go and by Python, with and without escaping the non ASCII characters. The forms equal to the secret are skipped
+func jsonEscapedValues(secret string) []string {
+	values := []string{}
+
+	forms := []string{
+		jsonEscape(secret, false),
+		jsonEscape(secret, true),
+		goJSONEscape(secret, false),
+		goJSONEscape(secret, true),
+	}
+
+	for _, form := range forms {
+		if form != secret && form != "" {
+			values = append(values, form)
+		}
+	}
+
+	return values
+}
+
+// goJSONEscape returns the secret escaped by the encoding/json package, which may escape the HTML characters
+func goJSONEscape(secret string, escapeHTML bool) string {
+	var buff bytes.Buffer
+
+	encoder := json.NewEncoder(&buff)
+	encoder.SetEscapeHTML(escapeHTML)
+	err := encoder.Encode(secret)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimRight(buff.String(), "\n"), `"`), `"`)
+}
+
+// jsonEscape returns the secret escaped as Python does to encode a JSON string, which is how ansible prints it. When ascii is true, the non ASCII characters are also escaped
+func jsonEscape(secret string, ascii bool) string {
+	var escaped strings.Builder
+
+	for _, char := range secret {
+		switch {
+		case char == '"':
+			escaped.WriteString(`\"`)
+		case char == '\\':
+			escaped.WriteString(`\\`)
+		case char == '\n':
+			escaped.WriteString(`\n`)
+		case char == '\r':
+			escaped.WriteString(`\r`)
+		case char == '\t':
+			escaped.WriteString(`\t`)
+		case char == '\b':
+			escaped.WriteString(`\b`)
+		case char == '\f':
+			escaped.WriteString(`\f`)
+		case char < 0x20:
+			fmt.Fprintf(&escaped, `\u%04x`, char)
+		case ascii && char > 0x7e && char <= 0xffff:
+			fmt.Fprintf(&escaped, `\u%04x`, char)
+		case ascii && char > 0xffff:
+			high, low := utf16.EncodeRune(char)
+			fmt.Fprintf(&escaped, `\u%04x\u%04x`, high, low)
+		default:
+			escaped.WriteRune(char)
+		}
+	}
+
+	return escaped.String()
+}
+
+// SensitiveValues returns the string values of the variables whose name holds a secret, such as ansible_become_password, including the ones of the nested maps
+func SensitiveValues(vars map[string]interface{}) []string {
+	values := []string{}
+
+	for name, value := range vars {
+		switch v := value.(type) {
+		case string:
+			if IsSensitiveName(name) {
+				values = append(values, v)
+			}
+		case map[string]interface{}:
+			values = append(values, SensitiveValues(v)...)
+		case map[string]string:
+			for nestedName, nestedValue := range v {
+				if IsSensitiveName(nestedName) {
+					values = append(values, nestedValue)
+				}
+			}
+		}
+	}
+
+	return values
+}
+
+// IsSensitiveName returns true when the name of the variable or environment variable holds a secret, such as ANSIBLE_BECOME_PASS, ansible_password or VAULT_TOKEN
+func IsSensitiveName(name string) bool {
+	return sensitiveNameRegexp.MatchString(name) && !askNameRegexp.MatchString(name)
+}
+
+// DefaultRedactor returns the redactor where the secrets of the whole process are registered
+func DefaultRedactor() *Redactor {
+	return defaultRedactor
+}
+
+// NewContext returns a copy of the context that carries the redactor. DefaultExecute uses it to give the redactor of each execution to the results outputers
+func NewContext(ctx context.Context, redactor *Redactor) context.Context {
+	return context.WithValue(ctx, redactorContextKey{}, redactor)
+}
+
+// FromContext returns the redactor carried by the context, or the redactor of the whole process when the context does not carry any
+func FromContext(ctx context.Context) *Redactor {
+	if ctx != nil {
+		redactor, isRedactor := ctx.Value(redactorContextKey{}).(*Redactor)
+		if isRedactor && redactor != nil {
+			return redactor
+		}
+	}
+
+	return defaultRedactor
+}
+
+// AddSecret registers the secrets to be masked in the outputs, the error messages and the commands of the whole process
+func AddSecret(secrets ...string) {
+	defaultRedactor.AddSecret(secrets...)
+}
+
+// RemoveSecret unregisters the secrets of the whole process
+func RemoveSecret(secrets ...string) {
+	defaultRedactor.RemoveSecret(secrets...)
+}
+
+// Reset unregisters all the secrets of the whole process
+func Reset() {
+	defaultRedactor.Reset()
+}
+
+// Secrets returns the secrets registered for the whole process
+func Secrets() []string {
+	return defaultRedactor.Secrets()
+}
+
+// AddVariables registers the string values of the variables whose name holds a secret to be masked in the whole process
+func AddVariables(vars map[string]interface{}) {
+	defaultRedactor.AddVariables(vars)
+}
+
+// AddEnvVars registers the values of the environment variables whose name holds a secret to be masked in the whole process
+func AddEnvVars(envVars map[string]string) {
+	defaultRedactor.AddEnvVars(envVars)
+}
+
+// Redact returns the value with the secrets registered in the whole process masked
+func Redact(value string) string {
+	return defaultRedactor.Redact(value)
+}
+
+// RedactWith returns the value with the secrets registered in the whole process and the given secrets masked. The commands use it to mask their own secrets on the String method, as the redactor of the execution does
+func RedactWith(value string, secrets ...string) string {
+	if len(secrets) == 0 {
+		return Redact(value)
+	}
+
+	return NewRedactor(append(defaultRedactor.Secrets(), secrets...)...).Redact(value)
+}
+
+// RedactEnvVar returns the value of the environment variable masked when its name holds a secret, otherwise the secrets registered in the whole process are masked on the value
+func RedactEnvVar(name, value string) string {
+	return defaultRedactor.RedactEnvVar(name, value)
+}
@@ -0,0 +1,243 @@
+package redact
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		desc     string
+		redactor *Redactor
+		value    string
+		res      string
+	}{
+		{
+			desc:     "Testing redact a value without registered secrets",
+			redactor: NewRedactor(),
+			value:    "password is secret",
+			res:      "password is secret",
+		},
+		{
+			desc:     "Testing redact every occurrence of a registered secret",
+			redactor: NewRedactor("s3cr3t"),
+			value:    "s3cr3t and s3cr3t",
+			res:      RedactedMask + " and " + RedactedMask,
+		},
+		{
+			desc:     "Testing redact the longest secret first when a secret contains another one",
+			redactor: NewRedactor("pass", "passphrase"),
+			value:    "the passphrase",
+			res:      "the " + RedactedMask,
+		},
+		{
+			desc:     "Testing redact ignoring the empty secrets",
+			redactor: NewRedactor("", "\n"),
+			value:    "nothing to hide",
+			res:      "nothing to hide",
+		},
+		{
+			desc:     "Testing redact each line of a multiline secret",
+			redactor: NewRedactor("-----BEGIN KEY-----\nc2VjcmV0\n-----END KEY-----\n"),
+			value:    "key line c2VjcmV0",
+			res:      "key line " + RedactedMask,
+		},
+		{
+			desc:     "Testing redact the JSON escaped form of a secret",
+			redactor: NewRedactor(`pa"ss\word`),
+			value:    `{"password": "pa\"ss\\word"}`,
+			res:      `{"password": "` + RedactedMask + `"}`,
+		},
+		{
+			desc:     "Testing redact the JSON escaped form of a multiline secret",
+			redactor: NewRedactor("first\nsecond"),
+			value:    `{"key": "first\nsecond"}`,
+			res:      `{"key": "` + RedactedMask + `"}`,
+		},
+		{
+			desc:     "Testing redact the JSON escaped form of a secret with HTML characters",
+			redactor: NewRedactor("a&b<c>"),
+			value:    `{"password": "a\u0026b\u003cc\u003e"}`,
+			res:      `{"password": "` + RedactedMask + `"}`,
+		},
+		{
+			desc:     "Testing redact the JSON escaped form of a secret with non ASCII characters",
+			redactor: NewRedactor("pässwörd😀"),
+			value:    `{"password": "p\u00e4ssw\u00f6rd\ud83d\ude00"}`,
+			res:      `{"password": "` + RedactedMask + `"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res := test.redactor.Redact(test.value)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestRemoveSecret(t *testing.T) {
+	t.Run("Testing remove a registered secret", func(t *testing.T) {
+		redactor := NewRedactor("s3cr3t", "t0k3n")
+		assert.Equal(t, RedactedMask+" "+RedactedMask, redactor.Redact("s3cr3t t0k3n"))
+
+		redactor.RemoveSecret("s3cr3t")
+		assert.Equal(t, "s3cr3t "+RedactedMask, redactor.Redact("s3cr3t t0k3n"))
+	})
+}
+
+func TestReset(t *testing.T) {
+	t.Run("Testing unregister all the secrets", func(t *testing.T) {
+		redactor := NewRedactor("s3cr3t", "t0k3n")
+
+		redactor.Reset()
+		assert.Empty(t, redactor.Secrets())
+		assert.Equal(t, "s3cr3t t0k3n", redactor.Redact("s3cr3t t0k3n"))
+
+		redactor.AddSecret("t0k3n")
+		assert.Equal(t, "s3cr3t "+RedactedMask, redactor.Redact("s3cr3t t0k3n"))
+	})
+}
+
+func TestSecrets(t *testing.T) {
+	t.Run("Testing return the registered secrets", func(t *testing.T) {
+		redactor := NewRedactor("t0k3n", "s3cr3t", "")
+
+		assert.Equal(t, []string{"s3cr3t", "t0k3n"}, redactor.Secrets())
+	})
+}
+
+func TestRedactWith(t *testing.T) {
+	t.Run("Testing redact the secrets of the whole process and the given secrets", func(t *testing.T) {
+		AddSecret("s3cr3t")
+		defer RemoveSecret("s3cr3t")
+
+		assert.Equal(t, RedactedMask+" "+RedactedMask+" other", RedactWith("s3cr3t t0k3n other", "t0k3n"))
+		// the given secrets are not registered for the whole process
+		assert.Equal(t, RedactedMask+" t0k3n", Redact("s3cr3t t0k3n"))
+	})
+}
+
+func TestFromContext(t *testing.T) {
+	t.Run("Testing return the redactor carried by the context", func(t *testing.T) {
+		redactor := NewRedactor("s3cr3t")
+
+		assert.Same(t, redactor, FromContext(NewContext(context.TODO(), redactor)))
+	})
+
+	t.Run("Testing return the redactor of the whole process when the context does not carry any", func(t *testing.T) {
+		assert.Same(t, DefaultRedactor(), FromContext(context.TODO()))
+	})
+}
+
+func TestSensitiveValues(t *testing.T) {
+	t.Run("Testing return the values of the variables that hold a secret", func(t *testing.T) {
+		values := SensitiveValues(map[string]interface{}{
+			"ansible_become_password": "b3c0m3",
+			"ansible_user":            "admin",
+			"db": map[string]interface{}{
+				"password": "db-p4ss",
+			},
+		})
+
+		assert.ElementsMatch(t, []string{"b3c0m3", "db-p4ss"}, values)
+	})
+}
+
+func TestAddVariables(t *testing.T) {
+	t.Run("Testing register the values of the variables that hold a secret", func(t *testing.T) {
+		redactor := NewRedactor()
+		redactor.AddVariables(map[string]interface{}{
+			"ansible_become_password": "b3c0m3",
+			"ansible_user":            "admin",
+			"ansible_ask_pass":        "yes",
+			"db": map[string]interface{}{
+				"password": "db-p4ss",
+				"port":     5432,
+			},
+			"api": map[string]string{
+				"token": "t0k3n",
+			},
+		})
+
+		assert.Equal(t, RedactedMask+" admin yes "+RedactedMask+" "+RedactedMask, redactor.Redact("b3c0m3 admin yes db-p4ss t0k3n"))
+	})
+}
+
+func TestAddEnvVars(t *testing.T) {
+	t.Run("Testing register the values of the environment variables that hold a secret", func(t *testing.T) {
+		redactor := NewRedactor()
+		redactor.AddEnvVars(map[string]string{
+			"ANSIBLE_BECOME_PASS": "b3c0m3",
+			"ANSIBLE_FORCE_COLOR": "true",
+		})
+
+		assert.Equal(t, RedactedMask+" true", redactor.Redact("b3c0m3 true"))
+	})
+}
+
+func TestRedactEnvVar(t *testing.T) {
+	tests := []struct {
+		desc  string
+		name  string
+		value string
+		res   string
+	}{
+		{
+			desc:  "Testing redact the value of an environment variable that holds a secret",
+			name:  "ANSIBLE_BECOME_PASS",
+			value: "b3c0m3",
+			res:   RedactedMask,
+		},
+		{
+			desc:  "Testing redact the registered secrets on the value of an environment variable that does not hold a secret",
+			name:  "ANSIBLE_EXTRA",
+			value: "user:s3cr3t",
+			res:   "user:" + RedactedMask,
+		},
+		{
+			desc:  "Testing keep an empty value of an environment variable that holds a secret",
+			name:  "VAULT_TOKEN",
+			value: "",
+			res:   "",
+		},
+	}
+
+	redactor := NewRedactor("s3cr3t")
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res := redactor.RedactEnvVar(test.name, test.value)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestIsSensitiveName(t *testing.T) {
+	tests := []struct {
+		name string
+		res  bool
+	}{
+		{name: "ANSIBLE_BECOME_PASS", res: true},
+		{name: "ansible_become_password", res: true},
+		{name: "ansible_ssh_pass", res: true},
+		{name: "GO_ANSIBLE_VAULT_PASSWORD_CLIENT_TOKEN", res: true},
+		{name: "client_secret", res: true},
+		{name: "password", res: true},
+		{name: "ANSIBLE_ASK_PASS", res: false},
+		{name: "ANSIBLE_VAULT_PASSWORD_FILE", res: false},
+		{name: "ansible_user", res: false},
+		{name: "passthrough", res: false},
+	}
+
+	for _, test := range tests {
+		t.Run("Testing is sensitive name "+test.name, func(t *testing.T) {
+			assert.Equal(t, test.res, IsSensitiveName(test.name))
+		})
+	}
+}
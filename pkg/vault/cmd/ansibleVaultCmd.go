@@ -0,0 +1,183 @@
+package vaultcmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/redact"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// DefaultAnsibleVaultBinary is the ansible-vault binary file default value
+	DefaultAnsibleVaultBinary = "ansible-vault"
+
+	// AnsibleVaultCreateSubCommand is the ansible-vault subcommand to create a new encrypted file
+	AnsibleVaultCreateSubCommand = "create"
+
+	// AnsibleVaultDecryptSubCommand is the ansible-vault subcommand to decrypt encrypted files
+	AnsibleVaultDecryptSubCommand = "decrypt"
+
+	// AnsibleVaultEncryptSubCommand is the ansible-vault subcommand to encrypt files
+	AnsibleVaultEncryptSubCommand = "encrypt"
+
+	// AnsibleVaultEncryptStringSubCommand is the ansible-vault subcommand to encrypt strings
+	AnsibleVaultEncryptStringSubCommand = "encrypt_string"
+
+	// AnsibleVaultRekeySubCommand is the ansible-vault subcommand to change the password of encrypted files
+	AnsibleVaultRekeySubCommand = "rekey"
+
+	// AnsibleVaultViewSubCommand is the ansible-vault subcommand to show the content of encrypted files
+	AnsibleVaultViewSubCommand = "view"
+)
+
+// AnsibleVaultOptionsFunc is a function to set executor options
+type AnsibleVaultOptionsFunc func(*AnsibleVaultCmd)
+
+// AnsibleVaultCmd object is the main object which defines the `ansible-vault` command to manage encrypted files and strings.
+type AnsibleVaultCmd struct {
+	// Binary is the ansible-vault binary file
+	Binary string
+
+	// SubCommand is the ansible-vault subcommand, such as encrypt or rekey
+	SubCommand string
+
+	// Args are the files processed by the subcommand or, for the encrypt_string subcommand, the strings to encrypt. The strings passed as arguments are visible in the process list, so set the StdinName option and write the string to the command stdin instead
+	Args []string
+
+	// VaultOptions are the ansible-vault options
+	VaultOptions *AnsibleVaultOptions
+}
+
+// NewAnsibleVaultCmd creates a new AnsibleVaultCmd instance
+func NewAnsibleVaultCmd(options ...AnsibleVaultOptionsFunc) *AnsibleVaultCmd {
+	cmd := &AnsibleVaultCmd{}
+
+	for _, option := range options {
+		option(cmd)
+	}
+
+	return cmd
+}
+
+// WithBinary set the ansible-vault binary file
+func WithBinary(binary string) AnsibleVaultOptionsFunc {
+	return func(p *AnsibleVaultCmd) {
+		p.Binary = binary
+	}
+}
+
+// WithSubCommand set the ansible-vault subcommand
+func WithSubCommand(subCommand string) AnsibleVaultOptionsFunc {
+	return func(p *AnsibleVaultCmd) {
+		p.SubCommand = subCommand
+	}
+}
+
+// WithArgs set the files processed by the subcommand or, for the encrypt_string subcommand, the strings to encrypt. The strings passed as arguments are visible in the process list, so prefer reading them from stdin by setting the StdinName option
+func WithArgs(args ...string) AnsibleVaultOptionsFunc {
+	return func(p *AnsibleVaultCmd) {
+		p.Args = append([]string{}, args...)
+	}
+}
+
+// WithVaultOptions set the ansible-vault options
+func WithVaultOptions(options *AnsibleVaultOptions) AnsibleVaultOptionsFunc {
+	return func(p *AnsibleVaultCmd) {
+		p.VaultOptions = options
+	}
+}
+
+// validate checks that the subcommand is defined and that it receives the files it requires
+func (p *AnsibleVaultCmd) validate() error {
+	errContext := "(vaultcmd::AnsibleVaultCmd::validate)"
+
+	switch p.SubCommand {
+	case "":
+		return errors.New(errContext, "Ansible vault subcommand is not defined")
+	case AnsibleVaultCreateSubCommand:
+		if len(p.Args) != 1 {
+			return errors.New(errContext, fmt.Sprintf("Ansible vault '%s' subcommand requires exactly one file", p.SubCommand))
+		}
+	case AnsibleVaultRekeySubCommand, AnsibleVaultViewSubCommand:
+		if len(p.Args) == 0 {
+			return errors.New(errContext, fmt.Sprintf("Ansible vault '%s' subcommand requires at least one file", p.SubCommand))
+		}
+	case AnsibleVaultEncryptStringSubCommand:
+		if p.VaultOptions != nil && p.VaultOptions.StdinName != "" && p.VaultOptions.Prompt {
+			return errors.New(errContext, fmt.Sprintf("Ansible vault '%s' subcommand does not support prompting the string when it is read from stdin", p.SubCommand))
+		}
+	case AnsibleVaultDecryptSubCommand, AnsibleVaultEncryptSubCommand:
+	default:
+		return errors.New(errContext, fmt.Sprintf("Ansible vault subcommand '%s' is not supported", p.SubCommand))
+	}
+
+	return nil
+}
+
+// Command generate the ansible-vault command which will be executed
+func (p *AnsibleVaultCmd) Command() ([]string, error) {
+	cmd := []string{}
+
+	errContext := "(vaultcmd::AnsibleVaultCmd::Command)"
+
+	err := p.validate()
+	if err != nil {
+		return nil, errors.New(errContext, "Error validating ansible-vault command", err)
+	}
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = DefaultAnsibleVaultBinary
+	}
+
+	cmd = append(cmd, p.Binary, p.SubCommand)
+
+	// Add the options
+	if p.VaultOptions != nil {
+		options, err := p.VaultOptions.GenerateCommandOptions()
+		if err != nil {
+			return nil, errors.New(errContext, "Error generating ansible-vault options", err)
+		}
+		cmd = append(cmd, options...)
+	}
+
+	// Add the files or strings
+	cmd = append(cmd, p.Args...)
+
+	return cmd, nil
+}
+
+// String returns the ansible-vault command as a string. The secrets registered on the redact package and the secrets of the command are masked
+func (p *AnsibleVaultCmd) String() string {
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = DefaultAnsibleVaultBinary
+	}
+
+	str := fmt.Sprintf("%s %s", p.Binary, p.SubCommand)
+
+	if p.VaultOptions != nil {
+		options := strings.TrimSpace(p.VaultOptions.String())
+		if options != "" {
+			str = fmt.Sprintf("%s %s", str, options)
+		}
+	}
+
+	// Include the files or strings
+	for _, arg := range p.Args {
+		str = fmt.Sprintf("%s %s", str, arg)
+	}
+
+	return redact.RedactWith(str, p.Secrets()...)
+}
+
+// Secrets returns the strings to encrypt passed as arguments to the encrypt_string subcommand, which DefaultExecute masks on the outputs of the execution
+func (p *AnsibleVaultCmd) Secrets() []string {
+	if p.SubCommand != AnsibleVaultEncryptStringSubCommand {
+		return []string{}
+	}
+
+	return append([]string{}, p.Args...)
+}
@@ -0,0 +1,197 @@
+package vaultcmd
+
+import (
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/redact"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleVaultCmd(t *testing.T) {
+	cmd := NewAnsibleVaultCmd(
+		WithBinary("ansible-vault-binary"),
+		WithSubCommand(AnsibleVaultEncryptSubCommand),
+		WithArgs("secrets.yml"),
+		WithVaultOptions(&AnsibleVaultOptions{
+			VaultID: []string{"prod@password-file"},
+		}),
+	)
+
+	expect := &AnsibleVaultCmd{
+		Binary:     "ansible-vault-binary",
+		SubCommand: AnsibleVaultEncryptSubCommand,
+		Args:       []string{"secrets.yml"},
+		VaultOptions: &AnsibleVaultOptions{
+			VaultID: []string{"prod@password-file"},
+		},
+	}
+
+	assert.Equal(t, expect, cmd)
+}
+
+func TestAnsibleVaultCmdCommand(t *testing.T) {
+
+	errContext := "(vaultcmd::AnsibleVaultCmd::Command)"
+	validateErrContext := "(vaultcmd::AnsibleVaultCmd::validate)"
+
+	tests := []struct {
+		desc    string
+		cmd     *AnsibleVaultCmd
+		command []string
+		err     error
+	}{
+		{
+			desc: "Testing generate an encrypt command using default binary",
+			cmd: NewAnsibleVaultCmd(
+				WithSubCommand(AnsibleVaultEncryptSubCommand),
+				WithArgs("secrets.yml"),
+				WithVaultOptions(&AnsibleVaultOptions{
+					EncryptVaultID: "prod",
+					Output:         "secrets.vault.yml",
+					VaultID:        []string{"dev@dev-password-file", "prod@password-file"},
+				}),
+			),
+			command: []string{
+				DefaultAnsibleVaultBinary,
+				AnsibleVaultEncryptSubCommand,
+				EncryptVaultIDFlag, "prod",
+				OutputFlag, "secrets.vault.yml",
+				VaultIDFlag, "dev@dev-password-file",
+				VaultIDFlag, "prod@password-file",
+				"secrets.yml",
+			},
+		},
+		{
+			desc: "Testing generate an encrypt_string command",
+			cmd: NewAnsibleVaultCmd(
+				WithBinary("ansible-vault-binary"),
+				WithSubCommand(AnsibleVaultEncryptStringSubCommand),
+				WithArgs("s3cr3t"),
+				WithVaultOptions(&AnsibleVaultOptions{
+					Name:              "db_password",
+					VaultPasswordFile: "password-file",
+				}),
+			),
+			command: []string{
+				"ansible-vault-binary",
+				AnsibleVaultEncryptStringSubCommand,
+				NameFlag, "db_password",
+				VaultPasswordFileFlag, "password-file",
+				"s3cr3t",
+			},
+		},
+		{
+			desc: "Testing generate an encrypt_string command reading the string from stdin",
+			cmd: NewAnsibleVaultCmd(
+				WithSubCommand(AnsibleVaultEncryptStringSubCommand),
+				WithVaultOptions(&AnsibleVaultOptions{
+					StdinName:         "db_password",
+					VaultPasswordFile: "password-file",
+				}),
+			),
+			command: []string{
+				DefaultAnsibleVaultBinary,
+				AnsibleVaultEncryptStringSubCommand,
+				StdinNameFlag, "db_password",
+				VaultPasswordFileFlag, "password-file",
+			},
+		},
+		{
+			desc: "Testing generate a rekey command",
+			cmd: NewAnsibleVaultCmd(
+				WithSubCommand(AnsibleVaultRekeySubCommand),
+				WithArgs("secrets.yml", "group_vars/all/vault.yml"),
+				WithVaultOptions(&AnsibleVaultOptions{
+					NewVaultPasswordFile: "new-password-file",
+					VaultPasswordFile:    "password-file",
+				}),
+			),
+			command: []string{
+				DefaultAnsibleVaultBinary,
+				AnsibleVaultRekeySubCommand,
+				NewVaultPasswordFileFlag, "new-password-file",
+				VaultPasswordFileFlag, "password-file",
+				"secrets.yml", "group_vars/all/vault.yml",
+			},
+		},
+		{
+			desc: "Testing generate a decrypt command reading from stdin",
+			cmd: NewAnsibleVaultCmd(
+				WithSubCommand(AnsibleVaultDecryptSubCommand),
+			),
+			command: []string{
+				DefaultAnsibleVaultBinary,
+				AnsibleVaultDecryptSubCommand,
+			},
+		},
+		{
+			desc: "Testing generate a command without subcommand",
+			cmd:  NewAnsibleVaultCmd(),
+			err:  errors.New(errContext, "Error validating ansible-vault command", errors.New(validateErrContext, "Ansible vault subcommand is not defined")),
+		},
+		{
+			desc: "Testing generate a command with an unsupported subcommand",
+			cmd:  NewAnsibleVaultCmd(WithSubCommand("edit"), WithArgs("secrets.yml")),
+			err:  errors.New(errContext, "Error validating ansible-vault command", errors.New(validateErrContext, "Ansible vault subcommand 'edit' is not supported")),
+		},
+		{
+			desc: "Testing generate a create command with several files",
+			cmd:  NewAnsibleVaultCmd(WithSubCommand(AnsibleVaultCreateSubCommand), WithArgs("secrets.yml", "secrets2.yml")),
+			err:  errors.New(errContext, "Error validating ansible-vault command", errors.New(validateErrContext, "Ansible vault 'create' subcommand requires exactly one file")),
+		},
+		{
+			desc: "Testing generate an encrypt_string command prompting the string read from stdin",
+			cmd: NewAnsibleVaultCmd(
+				WithSubCommand(AnsibleVaultEncryptStringSubCommand),
+				WithVaultOptions(&AnsibleVaultOptions{
+					Prompt:    true,
+					StdinName: "db_password",
+				}),
+			),
+			err: errors.New(errContext, "Error validating ansible-vault command", errors.New(validateErrContext, "Ansible vault 'encrypt_string' subcommand does not support prompting the string when it is read from stdin")),
+		},
+		{
+			desc: "Testing generate a view command without files",
+			cmd:  NewAnsibleVaultCmd(WithSubCommand(AnsibleVaultViewSubCommand)),
+			err:  errors.New(errContext, "Error validating ansible-vault command", errors.New(validateErrContext, "Ansible vault 'view' subcommand requires at least one file")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			command, err := test.cmd.Command()
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.command, command)
+			}
+		})
+	}
+}
+
+func TestAnsibleVaultCmdString(t *testing.T) {
+	cmd := NewAnsibleVaultCmd(
+		WithSubCommand(AnsibleVaultViewSubCommand),
+		WithArgs("secrets.yml"),
+		WithVaultOptions(&AnsibleVaultOptions{
+			VaultPasswordFile: "password-file",
+		}),
+	)
+
+	assert.Equal(t, "ansible-vault view --vault-password-file password-file secrets.yml", cmd.String())
+	assert.Equal(t, "ansible-vault decrypt", NewAnsibleVaultCmd(WithSubCommand(AnsibleVaultDecryptSubCommand), WithVaultOptions(&AnsibleVaultOptions{})).String())
+	assert.Equal(t, "ansible-vault encrypt_string --name password "+redact.RedactedMask, NewAnsibleVaultCmd(
+		WithSubCommand(AnsibleVaultEncryptStringSubCommand),
+		WithArgs("s3cr3t"),
+		WithVaultOptions(&AnsibleVaultOptions{Name: "password"}),
+	).String())
+}
+
+func TestAnsibleVaultCmdSecrets(t *testing.T) {
+	assert.Equal(t, []string{"s3cr3t"}, NewAnsibleVaultCmd(WithSubCommand(AnsibleVaultEncryptStringSubCommand), WithArgs("s3cr3t")).Secrets())
+	assert.Empty(t, NewAnsibleVaultCmd(WithSubCommand(AnsibleVaultEncryptSubCommand), WithArgs("secrets.yml")).Secrets())
+}
@@ -0,0 +1,13 @@
+package vaultcmd
+
+import (
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+)
+
+// AnsibleVaultErrorEnrich is the error enricher for ansible-vault errors. It is an execute.ExitCodeErrorEnrich that converts the errors into the typed errors defined in the execute package, such as execute.GeneralError, when the exit code is known
+type AnsibleVaultErrorEnrich = execute.ExitCodeErrorEnrich
+
+// NewAnsibleVaultErrorEnrich creates a new AnsibleVaultErrorEnrich instance
+func NewAnsibleVaultErrorEnrich() *AnsibleVaultErrorEnrich {
+	return execute.NewExitCodeErrorEnrich(DefaultAnsibleVaultBinary)
+}
@@ -0,0 +1,300 @@
+package vaultcmd
+
+import (
+	"fmt"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+
+	// AskVaultPasswordFlag ask for vault password
+	AskVaultPasswordFlag = "--ask-vault-password"
+
+	// EncryptVaultIDFlag the vault id used to encrypt. It is required when more than one vault id is provided
+	EncryptVaultIDFlag = "--encrypt-vault-id"
+
+	// NameFlag is the variable name of the string to encrypt, used by encrypt_string
+	NameFlag = "--name"
+
+	// NewVaultIDFlag the new vault identity to use for rekey
+	NewVaultIDFlag = "--new-vault-id"
+
+	// NewVaultPasswordFileFlag new vault password file for rekey
+	NewVaultPasswordFileFlag = "--new-vault-password-file"
+
+	// OutputFlag output file name for encrypt, decrypt or encrypt_string. Use - for stdout
+	OutputFlag = "--output"
+
+	// PromptFlag prompt for the string to encrypt, used by encrypt_string
+	PromptFlag = "--prompt"
+
+	// ShowInputFlag do not hide the input when prompted for the string to encrypt, used by encrypt_string
+	ShowInputFlag = "--show-input"
+
+	// SkipTTYCheckFlag allows the editor to be opened when no tty is attached, used by create
+	SkipTTYCheckFlag = "--skip-tty-check"
+
+	// StdinNameFlag is the variable name of the string read from stdin, used by encrypt_string
+	StdinNameFlag = "--stdin-name"
+
+	// VaultIDFlag the vault identity to use
+	VaultIDFlag = "--vault-id"
+
+	// VaultPasswordFileFlag vault password file
+	VaultPasswordFileFlag = "--vault-password-file"
+
+	// VerboseFlag verbose mode enabled
+	VerboseFlag = "-vvvv"
+
+	// VerboseVFlag verbose with -v is enabled
+	VerboseVFlag = "-v"
+
+	// VerboseVVFlag verbose with -vv is enabled
+	VerboseVVFlag = "-vv"
+
+	// VerboseVVVFlag verbose with -vvv is enabled
+	VerboseVVVFlag = "-vvv"
+
+	// VerboseVVVVFlag verbose with -vvvv is enabled
+	VerboseVVVVFlag = "-vvvv"
+
+	// VersionFlag show program's version number, config file location, configured module search path, module location, executable location and exit
+	VersionFlag = "--version"
+)
+
+// AnsibleVaultOptions represents the options that can be passed to the ansible-vault command.
+type AnsibleVaultOptions struct {
+
+	// AskVaultPassword ask for vault password
+	AskVaultPassword bool
+
+	// EncryptVaultID the vault id used to encrypt. It is required when more than one vault id is provided
+	EncryptVaultID string
+
+	// Name is the variable name of the string to encrypt, used by encrypt_string
+	Name string
+
+	// NewVaultID the new vault identity to use for rekey
+	NewVaultID string
+
+	// NewVaultPasswordFile new vault password file for rekey
+	NewVaultPasswordFile string
+
+	// Output output file name for encrypt, decrypt or encrypt_string. Use - for stdout
+	Output string
+
+	// Prompt prompt for the string to encrypt, used by encrypt_string
+	Prompt bool
+
+	// ShowInput do not hide the input when prompted for the string to encrypt, used by encrypt_string
+	ShowInput bool
+
+	// SkipTTYCheck allows the editor to be opened when no tty is attached, used by create
+	SkipTTYCheck bool
+
+	// StdinName is the variable name of the string read from stdin, used by encrypt_string
+	StdinName string
+
+	// VaultID the vault identities to use. A vault identity flag is set for each one
+	VaultID []string
+
+	// VaultPasswordFile vault password file
+	VaultPasswordFile string
+
+	// Verbose verbose mode enabled
+	Verbose bool
+
+	// Verbose verbose mode -v enabled
+	VerboseV bool
+
+	// Verbose verbose mode -vv enabled
+	VerboseVV bool
+
+	// Verbose verbose mode -vvv enabled
+	VerboseVVV bool
+
+	// Verbose verbose mode -vvvv enabled
+	VerboseVVVV bool
+
+	// Version show program's version number, config file location, configured module search path, module location, executable location and exit
+	Version bool
+}
+
+// GenerateCommandOptions generates the command line options for the ansible-vault command.
+func (o *AnsibleVaultOptions) GenerateCommandOptions() ([]string, error) {
+
+	errContext := "(vaultcmd::AnsibleVaultOptions::GenerateCommandOptions)"
+	options := []string{}
+
+	if o == nil {
+		return nil, errors.New(errContext, "AnsibleVaultOptions is nil")
+	}
+
+	if o.AskVaultPassword {
+		options = append(options, AskVaultPasswordFlag)
+	}
+
+	if o.EncryptVaultID != "" {
+		options = append(options, EncryptVaultIDFlag, o.EncryptVaultID)
+	}
+
+	if o.Name != "" {
+		options = append(options, NameFlag, o.Name)
+	}
+
+	if o.NewVaultID != "" {
+		options = append(options, NewVaultIDFlag, o.NewVaultID)
+	}
+
+	if o.NewVaultPasswordFile != "" {
+		options = append(options, NewVaultPasswordFileFlag, o.NewVaultPasswordFile)
+	}
+
+	if o.Output != "" {
+		options = append(options, OutputFlag, o.Output)
+	}
+
+	if o.Prompt {
+		options = append(options, PromptFlag)
+	}
+
+	if o.ShowInput {
+		options = append(options, ShowInputFlag)
+	}
+
+	if o.SkipTTYCheck {
+		options = append(options, SkipTTYCheckFlag)
+	}
+
+	if o.StdinName != "" {
+		options = append(options, StdinNameFlag, o.StdinName)
+	}
+
+	for _, vaultID := range o.VaultID {
+		options = append(options, VaultIDFlag, vaultID)
+	}
+
+	if o.VaultPasswordFile != "" {
+		options = append(options, VaultPasswordFileFlag, o.VaultPasswordFile)
+	}
+
+	verboseFlag, err := o.generateVerbosityFlag()
+	if err != nil {
+		return nil, errors.New(errContext, "", err)
+	}
+
+	if verboseFlag != "" {
+		options = append(options, verboseFlag)
+	}
+
+	if o.Version {
+		options = append(options, VersionFlag)
+	}
+
+	return options, nil
+}
+
+// generateVerbosityFlag return a string with the verbose flag. Higher verbosity (more v's) has precedence over lower
+func (o *AnsibleVaultOptions) generateVerbosityFlag() (string, error) {
+	if o.Verbose {
+		return VerboseFlag, nil
+	}
+
+	if o.VerboseVVVV {
+		return VerboseVVVVFlag, nil
+	}
+
+	if o.VerboseVVV {
+		return VerboseVVVFlag, nil
+	}
+
+	if o.VerboseVV {
+		return VerboseVVFlag, nil
+	}
+
+	if o.VerboseV {
+		return VerboseVFlag, nil
+	}
+
+	return "", nil
+}
+
+// String returns a string representation of the ansible-vault options.
+func (o *AnsibleVaultOptions) String() string {
+	str := ""
+
+	if o.AskVaultPassword {
+		str = fmt.Sprintf("%s %s", str, AskVaultPasswordFlag)
+	}
+
+	if o.EncryptVaultID != "" {
+		str = fmt.Sprintf("%s %s %s", str, EncryptVaultIDFlag, o.EncryptVaultID)
+	}
+
+	if o.Name != "" {
+		str = fmt.Sprintf("%s %s %s", str, NameFlag, o.Name)
+	}
+
+	if o.NewVaultID != "" {
+		str = fmt.Sprintf("%s %s %s", str, NewVaultIDFlag, o.NewVaultID)
+	}
+
+	if o.NewVaultPasswordFile != "" {
+		str = fmt.Sprintf("%s %s %s", str, NewVaultPasswordFileFlag, o.NewVaultPasswordFile)
+	}
+
+	if o.Output != "" {
+		str = fmt.Sprintf("%s %s %s", str, OutputFlag, o.Output)
+	}
+
+	if o.Prompt {
+		str = fmt.Sprintf("%s %s", str, PromptFlag)
+	}
+
+	if o.ShowInput {
+		str = fmt.Sprintf("%s %s", str, ShowInputFlag)
+	}
+
+	if o.SkipTTYCheck {
+		str = fmt.Sprintf("%s %s", str, SkipTTYCheckFlag)
+	}
+
+	if o.StdinName != "" {
+		str = fmt.Sprintf("%s %s %s", str, StdinNameFlag, o.StdinName)
+	}
+
+	for _, vaultID := range o.VaultID {
+		str = fmt.Sprintf("%s %s %s", str, VaultIDFlag, vaultID)
+	}
+
+	if o.VaultPasswordFile != "" {
+		str = fmt.Sprintf("%s %s %s", str, VaultPasswordFileFlag, o.VaultPasswordFile)
+	}
+
+	if o.Verbose {
+		str = fmt.Sprintf("%s %s", str, VerboseFlag)
+	}
+
+	if o.VerboseV {
+		str = fmt.Sprintf("%s %s", str, VerboseVFlag)
+	}
+
+	if o.VerboseVV {
+		str = fmt.Sprintf("%s %s", str, VerboseVVFlag)
+	}
+
+	if o.VerboseVVV {
+		str = fmt.Sprintf("%s %s", str, VerboseVVVFlag)
+	}
+
+	if o.VerboseVVVV {
+		str = fmt.Sprintf("%s %s", str, VerboseVVVVFlag)
+	}
+
+	if o.Version {
+		str = fmt.Sprintf("%s %s", str, VersionFlag)
+	}
+
+	return str
+}
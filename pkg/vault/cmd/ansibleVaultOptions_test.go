@@ -0,0 +1,184 @@
+package vaultcmd
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleVaultOptionsGenerateCommandOptions(t *testing.T) {
+
+	errContext := "(vaultcmd::AnsibleVaultOptions::GenerateCommandOptions)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleVaultOptions
+		err     error
+		expect  []string
+	}{
+		{
+			desc:    "Testing nil AnsibleVaultOptions definition",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleVaultOptions is nil"),
+			expect:  []string{},
+		},
+		{
+			desc:    "Testing an empty AnsibleVaultOptions definition",
+			options: &AnsibleVaultOptions{},
+			err:     nil,
+			expect:  []string{},
+		},
+		{
+			desc: "Testing AnsibleVaultOptions with all flags",
+			options: &AnsibleVaultOptions{
+				AskVaultPassword:     true,
+				EncryptVaultID:       "prod",
+				Name:                 "db_password",
+				NewVaultID:           "prod@new-password-file",
+				NewVaultPasswordFile: "new-password-file",
+				Output:               "output.yml",
+				Prompt:               true,
+				ShowInput:            true,
+				SkipTTYCheck:         true,
+				StdinName:            "api_token",
+				VaultID:              []string{"dev@dev-password-file", "prod@password-file"},
+				VaultPasswordFile:    "password-file",
+				Verbose:              true,
+				VerboseV:             true,
+				VerboseVV:            true,
+				VerboseVVV:           true,
+				VerboseVVVV:          true,
+				Version:              true,
+			},
+			err: nil,
+			expect: []string{
+				AskVaultPasswordFlag,
+				EncryptVaultIDFlag, "prod",
+				NameFlag, "db_password",
+				NewVaultIDFlag, "prod@new-password-file",
+				NewVaultPasswordFileFlag, "new-password-file",
+				OutputFlag, "output.yml",
+				PromptFlag,
+				ShowInputFlag,
+				SkipTTYCheckFlag,
+				StdinNameFlag, "api_token",
+				VaultIDFlag, "dev@dev-password-file",
+				VaultIDFlag, "prod@password-file",
+				VaultPasswordFileFlag, "password-file",
+				VerboseVVVVFlag,
+				VersionFlag,
+			},
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options, err := test.options.GenerateCommandOptions()
+
+			if err != nil && assert.Error(t, err) {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expect, options, "Unexpected options value")
+			}
+		})
+	}
+}
+
+func TestAnsibleVaultOptionsGenerateVerbosityFlag(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleVaultOptions
+		res     string
+	}{
+		{
+			desc:    "Testing generate verbosity flag",
+			options: &AnsibleVaultOptions{Verbose: true},
+			res:     "-vvvv",
+		},
+		{
+			desc:    "Testing generate verbosity flag V",
+			options: &AnsibleVaultOptions{VerboseV: true},
+			res:     "-v",
+		},
+		{
+			desc:    "Testing generate verbosity flag VV",
+			options: &AnsibleVaultOptions{VerboseVV: true},
+			res:     "-vv",
+		},
+		{
+			desc:    "Testing generate verbosity flag VVV",
+			options: &AnsibleVaultOptions{VerboseVVV: true},
+			res:     "-vvv",
+		},
+		{
+			desc:    "Testing generate verbosity flag VVVV",
+			options: &AnsibleVaultOptions{VerboseVVVV: true},
+			res:     "-vvvv",
+		},
+		{
+			desc:    "Testing generate verbosity flag VV has precedence over V",
+			options: &AnsibleVaultOptions{VerboseVV: true, VerboseV: true},
+			res:     "-vv",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.options.generateVerbosityFlag()
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestAnsibleVaultOptionsString(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleVaultOptions
+		expect  string
+	}{
+		{
+			desc:    "Testing generate string from an empty AnsibleVaultOptions",
+			options: &AnsibleVaultOptions{},
+			expect:  "",
+		},
+		{
+			desc: "Testing generate string from an AnsibleVaultOptions with all flags",
+			options: &AnsibleVaultOptions{
+				AskVaultPassword:     true,
+				EncryptVaultID:       "prod",
+				Name:                 "db_password",
+				NewVaultID:           "prod@new-password-file",
+				NewVaultPasswordFile: "new-password-file",
+				Output:               "output.yml",
+				Prompt:               true,
+				ShowInput:            true,
+				SkipTTYCheck:         true,
+				StdinName:            "api_token",
+				VaultID:              []string{"dev@dev-password-file", "prod@password-file"},
+				VaultPasswordFile:    "password-file",
+				Verbose:              true,
+				VerboseV:             true,
+				VerboseVV:            true,
+				VerboseVVV:           true,
+				VerboseVVVV:          true,
+				Version:              true,
+			},
+			expect: " --ask-vault-password --encrypt-vault-id prod --name db_password --new-vault-id prod@new-password-file --new-vault-password-file new-password-file --output output.yml --prompt --show-input --skip-tty-check --stdin-name api_token --vault-id dev@dev-password-file --vault-id prod@password-file --vault-password-file password-file -vvvv -v -vv -vvv -vvvv --version",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expect, test.options.String())
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package decrypt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault"
+	"github.com/apenella/go-ansible/v2/pkg/vault/header"
+	"github.com/pkg/errors"
+	ansiblevault "github.com/sosedoff/ansible-vault-go"
+	"github.com/spf13/afero"
+)
+
+// OptionsFunc is a function used to configure DecryptString
+type OptionsFunc func(*DecryptString)
+
+// DecryptString decrypts the vaulted values, files and inline `!vault` YAML values. It supports the 1.1 and 1.2 vault format versions
+type DecryptString struct {
+	reader  PasswordReader
+	keyring *vault.VaultKeyring
+	fs      afero.Fs
+}
+
+// NewDecryptString returns a DecryptString
+func NewDecryptString(options ...OptionsFunc) *DecryptString {
+	decrypt := &DecryptString{}
+	decrypt.Options(options...)
+
+	return decrypt
+}
+
+// WithReader sets the password reader that provides the vault password
+func WithReader(reader PasswordReader) OptionsFunc {
+	return func(d *DecryptString) {
+		d.reader = reader
+	}
+}
+
+// WithKeyring sets the keyring that provides the password of the vaulted values encrypted with a vault id label. The password reader is used when the label is not defined in the keyring
+func WithKeyring(keyring *vault.VaultKeyring) OptionsFunc {
+	return func(d *DecryptString) {
+		d.keyring = keyring
+	}
+}
+
+// WithFs sets the filesystem where the vaulted files are read from
+func WithFs(fs afero.Fs) OptionsFunc {
+	return func(d *DecryptString) {
+		d.fs = fs
+	}
+}
+
+// Options configure the DecryptString
+func (d *DecryptString) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(d)
+	}
+}
+
+// Decrypt returns the plain text of the vaulted value
+func (d *DecryptString) Decrypt(cipherText string) (string, error) {
+	return d.decryptFunc()(cipherText)
+}
+
+// DecryptFile returns the plain text of the vaulted file
+func (d *DecryptString) DecryptFile(file string) (string, error) {
+	if d == nil {
+		return "", errors.New("DecryptString must be initialized before decrypting a file.")
+	}
+
+	if d.fs == nil {
+		d.fs = afero.NewOsFs()
+	}
+
+	content, err := afero.ReadFile(d.fs, file)
+	if err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("Error reading the file '%s'.", file))
+	}
+
+	plainText, err := d.Decrypt(string(content))
+	if err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("Error decrypting the file '%s'.", file))
+	}
+
+	return plainText, nil
+}
+
+// DecryptYAML returns the YAML content with its inline `!vault` values decrypted
+func (d *DecryptString) DecryptYAML(content []byte) ([]byte, error) {
+	plainContent, err := vault.ReplaceVaultedYAMLValues(content, d.decryptFunc())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error decrypting the YAML vaulted values.")
+	}
+
+	return plainContent, nil
+}
+
+// decryptFunc returns the function that decrypts the vaulted values. The passwords are read once per vault id label, regardless of the number of values decrypted
+func (d *DecryptString) decryptFunc() func(string) (string, error) {
+	passwords := map[string]string{}
+
+	return func(cipherText string) (string, error) {
+		vaultHeader, err := header.ParseVaultHeader(cipherText)
+		if err != nil {
+			return "", errors.Wrap(err, "Error parsing the vault header")
+		}
+
+		pass, exists := passwords[vaultHeader.Label]
+		if !exists {
+			pass, err = d.password(vaultHeader.Label)
+			if err != nil {
+				return "", err
+			}
+			passwords[vaultHeader.Label] = pass
+		}
+
+		return decrypt(cipherText, pass)
+	}
+}
+
+// password returns the vault password of the vault id label, provided by the keyring or the password reader
+func (d *DecryptString) password(label string) (string, error) {
+	if d == nil {
+		return "", errors.New("DecryptString must be initialized before decrypting a value.")
+	}
+
+	reader := d.reader
+	if label != "" {
+		for _, keyringLabel := range d.keyring.Labels() {
+			if keyringLabel != label {
+				continue
+			}
+
+			keyringReader, err := d.keyring.Reader(label)
+			if err != nil {
+				return "", errors.Wrap(err, "Error getting the password reader from the keyring")
+			}
+			reader = keyringReader
+		}
+	}
+
+	if reader == nil {
+		return "", errors.New("Password reader must be provided to decrypt a value.")
+	}
+
+	pass, err := reader.Read()
+	if err != nil {
+		return "", errors.Wrap(err, "Error reading the password")
+	}
+
+	return pass, nil
+}
+
+// decrypt returns the plain text of the vaulted value. The 1.1 and 1.2 vault format versions only differ on the header, so the payload is decrypted as a 1.1 vaulted value
+func decrypt(cipherText, pass string) (string, error) {
+	lines := strings.Split(strings.TrimSpace(cipherText), "\n")
+	payload := make([]string, 0, len(lines))
+	payload = append(payload, header.NewVaultHeader("").String())
+	for _, line := range lines[1:] {
+		payload = append(payload, strings.TrimSpace(line))
+	}
+
+	plainText, err := ansiblevault.Decrypt(strings.Join(payload, "\n"), pass)
+	if err != nil {
+		return "", errors.Wrap(err, "Error decrypting the vaulted value")
+	}
+
+	return plainText, nil
+}
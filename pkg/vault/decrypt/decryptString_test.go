@@ -0,0 +1,131 @@
+package decrypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// vaultedPayload is the payload of the "ThatIsASecretMessage" value encrypted with the "secret" password
+const vaultedPayload = `32386564373134363734663531363662663034646664633031616230303061326338316536363938
+6232393062313563383932663735303863653364656231390a616134343664616430346430323837
+62653334333962313163626134643039316466323630613266633734306233316338353038336337
+6332303734663133620a343039666237366530306463396664396439373233343532626430633338
+31326335623164383930666133313436353836303662626435343563366535633732`
+
+func TestDecrypt(t *testing.T) {
+	tests := []struct {
+		desc       string
+		cipherText string
+		decrypt    *DecryptString
+		expected   string
+		err        error
+	}{
+		{
+			desc:       "Testing decrypt a 1.1 vaulted value",
+			cipherText: "$ANSIBLE_VAULT;1.1;AES256\n" + vaultedPayload,
+			decrypt:    NewDecryptString(WithReader(text.NewReadPasswordFromText(text.WithText("secret")))),
+			expected:   "ThatIsASecretMessage",
+		},
+		{
+			desc:       "Testing decrypt a 1.2 vaulted value with a vault id label",
+			cipherText: "$ANSIBLE_VAULT;1.2;AES256;dev\n" + vaultedPayload,
+			decrypt:    NewDecryptString(WithReader(text.NewReadPasswordFromText(text.WithText("secret")))),
+			expected:   "ThatIsASecretMessage",
+		},
+		{
+			desc:       "Testing decrypt an indented vaulted value",
+			cipherText: "\n      $ANSIBLE_VAULT;1.2;AES256;dev\n      " + strings.ReplaceAll(vaultedPayload, "\n", "\n      ") + "\n",
+			decrypt:    NewDecryptString(WithReader(text.NewReadPasswordFromText(text.WithText("secret")))),
+			expected:   "ThatIsASecretMessage",
+		},
+		{
+			desc:       "Testing error decrypting a vaulted value with a wrong password",
+			cipherText: "$ANSIBLE_VAULT;1.1;AES256\n" + vaultedPayload,
+			decrypt:    NewDecryptString(WithReader(text.NewReadPasswordFromText(text.WithText("wrong")))),
+			err:        errors.New("Error decrypting the vaulted value: invalid password"),
+		},
+		{
+			desc:       "Testing error decrypting a value that is not vaulted",
+			cipherText: "ThatIsASecretMessage",
+			decrypt:    NewDecryptString(WithReader(text.NewReadPasswordFromText(text.WithText("secret")))),
+			err:        errors.New("Error parsing the vault header: Content is not vaulted."),
+		},
+		{
+			desc:       "Testing error decrypting a vaulted value when the password reader is not provided",
+			cipherText: "$ANSIBLE_VAULT;1.1;AES256\n" + vaultedPayload,
+			decrypt:    NewDecryptString(),
+			err:        errors.New("Password reader must be provided to decrypt a value."),
+		},
+		{
+			desc:       "Testing error decrypting a vaulted value when DecryptString is not initialized",
+			cipherText: "$ANSIBLE_VAULT;1.1;AES256\n" + vaultedPayload,
+			decrypt:    nil,
+			err:        errors.New("DecryptString must be initialized before decrypting a value."),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			res, err := test.decrypt.Decrypt(test.cipherText)
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
+
+func TestDecryptWithKeyring(t *testing.T) {
+	decrypt := NewDecryptString(
+		WithReader(text.NewReadPasswordFromText(text.WithText("wrong"))),
+		WithKeyring(vault.NewVaultKeyring(
+			vault.WithVaultIdentity("dev", text.NewReadPasswordFromText(text.WithText("secret"))),
+			vault.WithVaultIdentitySource("prod", "prod-password-file"),
+		)),
+	)
+
+	res, err := decrypt.Decrypt("$ANSIBLE_VAULT;1.2;AES256;dev\n" + vaultedPayload)
+	assert.NoError(t, err)
+	assert.Equal(t, "ThatIsASecretMessage", res)
+
+	_, err = decrypt.Decrypt("$ANSIBLE_VAULT;1.1;AES256\n" + vaultedPayload)
+	assert.EqualError(t, err, "Error decrypting the vaulted value: invalid password")
+
+	_, err = decrypt.Decrypt("$ANSIBLE_VAULT;1.2;AES256;prod\n" + vaultedPayload)
+	assert.EqualError(t, err, "Error getting the password reader from the keyring: Vault identity 'prod' does not have a password reader.")
+}
+
+func TestDecryptFile(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(testFs, "/vault.yml", []byte("$ANSIBLE_VAULT;1.2;AES256;dev\n"+vaultedPayload+"\n"), 0600)
+
+	decrypt := NewDecryptString(
+		WithFs(testFs),
+		WithReader(text.NewReadPasswordFromText(text.WithText("secret"))),
+	)
+
+	res, err := decrypt.DecryptFile("/vault.yml")
+	assert.NoError(t, err)
+	assert.Equal(t, "ThatIsASecretMessage", res)
+
+	_, err = decrypt.DecryptFile("/unexisting.yml")
+	assert.EqualError(t, err, "Error reading the file '/unexisting.yml'.: open /unexisting.yml: file does not exist")
+}
+
+func TestDecryptYAML(t *testing.T) {
+	content := "user: ansible\nmessage: !vault |\n  $ANSIBLE_VAULT;1.1;AES256\n  " + strings.ReplaceAll(vaultedPayload, "\n", "\n  ") + "\n"
+
+	decrypt := NewDecryptString(WithReader(text.NewReadPasswordFromText(text.WithText("secret"))))
+
+	res, err := decrypt.DecryptYAML([]byte(content))
+	assert.NoError(t, err)
+	assert.Equal(t, "user: ansible\nmessage: ThatIsASecretMessage\n", string(res))
+}
@@ -0,0 +1,20 @@
+package decrypt
+
+import "github.com/stretchr/testify/mock"
+
+// MockDecryptString is a mock of the DecryptString
+type MockDecryptString struct {
+	mock.Mock
+}
+
+// NewMockDecryptString returns a MockDecryptString
+func NewMockDecryptString() *MockDecryptString {
+	return &MockDecryptString{}
+}
+
+// Decrypt mocks the decryption of a vaulted value
+func (d *MockDecryptString) Decrypt(cipherText string) (string, error) {
+	args := d.Called(cipherText)
+
+	return args.String(0), args.Error(1)
+}
@@ -1,8 +1,11 @@
 package encrypt
 
 import (
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault/header"
 	"github.com/pkg/errors"
-	vault "github.com/sosedoff/ansible-vault-go"
+	ansiblevault "github.com/sosedoff/ansible-vault-go"
 )
 
 // OptionsFunc is a function used to configure EncryptString
@@ -10,6 +13,7 @@ type OptionsFunc func(*EncryptString)
 
 type EncryptString struct {
 	reader PasswordReader
+	label  string
 }
 
 func NewEncryptString(options ...OptionsFunc) *EncryptString {
@@ -25,6 +29,13 @@ func WithReader(reader PasswordReader) OptionsFunc {
 	}
 }
 
+// WithLabel sets the vault id label. The encrypted text uses the 1.2 vault format version, which includes the label in its header
+func WithLabel(label string) OptionsFunc {
+	return func(c *EncryptString) {
+		c.label = label
+	}
+}
+
 // Options configure the ReadSecretFromEnvVar
 func (c *EncryptString) Options(opts ...OptionsFunc) {
 	for _, opt := range opts {
@@ -41,10 +52,16 @@ func (c *EncryptString) Encrypt(plainText string) (string, error) {
 		return "", errors.Wrap(err, "Error reading the password")
 	}
 
-	encryptedText, err = vault.Encrypt(plainText, pass)
+	encryptedText, err = ansiblevault.Encrypt(plainText, pass)
 	if err != nil {
 		return "", errors.Wrap(err, "Error encrypting the password")
 	}
 
+	if c.label != "" {
+		lines := strings.SplitN(encryptedText, "\n", 2)
+		lines[0] = header.NewVaultHeader(c.label).String()
+		encryptedText = strings.Join(lines, "\n")
+	}
+
 	return encryptedText, nil
 }
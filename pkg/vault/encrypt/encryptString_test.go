@@ -30,6 +30,20 @@ func TestEncrypt(t *testing.T) {
 			expectedRegexp: "\\$ANSIBLE_VAULT;1.1;AES256",
 			expectedLen:    418,
 		},
+		{
+			desc: "Testing encrypting a message with a vault id label",
+			text: "ThatIsASecretMessage",
+			encrypt: NewEncryptString(
+				WithReader(
+					text.NewReadPasswordFromText(
+						text.WithText("secret"),
+					),
+				),
+				WithLabel("dev"),
+			),
+			expectedRegexp: "^\\$ANSIBLE_VAULT;1.2;AES256;dev\n",
+			expectedLen:    422,
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,106 @@
+package header
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// VaultHeaderPrefix is the prefix of the header of the vaulted content
+	VaultHeaderPrefix = "$ANSIBLE_VAULT"
+
+	// VaultFormatVersion11 is the vault format version of the content encrypted without a vault id label
+	VaultFormatVersion11 = "1.1"
+
+	// VaultFormatVersion12 is the vault format version of the content encrypted with a vault id label
+	VaultFormatVersion12 = "1.2"
+
+	// VaultCipherAES256 is the cipher used to encrypt the vaulted content
+	VaultCipherAES256 = "AES256"
+
+	// vaultHeaderSeparator is the separator of the vault header fields
+	vaultHeaderSeparator = ";"
+)
+
+// VaultHeader is the first line of the vaulted content, such as `$ANSIBLE_VAULT;1.2;AES256;dev`
+type VaultHeader struct {
+	// Version is the vault format version
+	Version string
+
+	// Cipher is the cipher used to encrypt the content
+	Cipher string
+
+	// Label is the vault id label. It is only set on the 1.2 vault format version
+	Label string
+}
+
+// NewVaultHeader returns the VaultHeader for the label. The 1.2 vault format version is used when the label is defined, otherwise the 1.1 version is used
+func NewVaultHeader(label string) *VaultHeader {
+	header := &VaultHeader{
+		Version: VaultFormatVersion11,
+		Cipher:  VaultCipherAES256,
+	}
+
+	if label != "" {
+		header.Version = VaultFormatVersion12
+		header.Label = label
+	}
+
+	return header
+}
+
+// ParseVaultHeader returns the VaultHeader of the vaulted content. It returns an error when the content is not vaulted or its format version or cipher are not supported
+func ParseVaultHeader(content string) (*VaultHeader, error) {
+
+	line := strings.TrimSpace(content)
+	if i := strings.Index(line, "\n"); i >= 0 {
+		line = strings.TrimSpace(line[:i])
+	}
+
+	fields := strings.Split(line, vaultHeaderSeparator)
+	if len(fields) < 3 || fields[0] != VaultHeaderPrefix {
+		return nil, errors.New("Content is not vaulted.")
+	}
+
+	header := &VaultHeader{
+		Version: fields[1],
+		Cipher:  fields[2],
+	}
+
+	switch header.Version {
+	case VaultFormatVersion11:
+		if len(fields) != 3 {
+			return nil, errors.New(fmt.Sprintf("Invalid vault header '%s'.", line))
+		}
+	case VaultFormatVersion12:
+		if len(fields) != 4 || fields[3] == "" {
+			return nil, errors.New(fmt.Sprintf("Invalid vault header '%s'. The vault id label is required by the vault format version %s.", line, VaultFormatVersion12))
+		}
+		header.Label = fields[3]
+	default:
+		return nil, errors.New(fmt.Sprintf("Vault format version '%s' is not supported.", header.Version))
+	}
+
+	if header.Cipher != VaultCipherAES256 {
+		return nil, errors.New(fmt.Sprintf("Vault cipher '%s' is not supported.", header.Cipher))
+	}
+
+	return header, nil
+}
+
+// IsVaulted returns true when the content starts with the vault header prefix
+func IsVaulted(content string) bool {
+	return strings.HasPrefix(strings.TrimSpace(content), VaultHeaderPrefix+vaultHeaderSeparator)
+}
+
+// String returns the vault header line
+func (h *VaultHeader) String() string {
+	fields := []string{VaultHeaderPrefix, h.Version, h.Cipher}
+	if h.Label != "" {
+		fields = append(fields, h.Label)
+	}
+
+	return strings.Join(fields, vaultHeaderSeparator)
+}
@@ -0,0 +1,75 @@
+package header
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVaultHeader(t *testing.T) {
+	assert.Equal(t, &VaultHeader{Version: VaultFormatVersion11, Cipher: VaultCipherAES256}, NewVaultHeader(""))
+	assert.Equal(t, &VaultHeader{Version: VaultFormatVersion12, Cipher: VaultCipherAES256, Label: "dev"}, NewVaultHeader("dev"))
+}
+
+func TestParseVaultHeader(t *testing.T) {
+	tests := []struct {
+		desc    string
+		content string
+		header  *VaultHeader
+		err     error
+	}{
+		{
+			desc:    "Testing parse a 1.1 vault header",
+			content: "$ANSIBLE_VAULT;1.1;AES256\n3238",
+			header:  &VaultHeader{Version: "1.1", Cipher: "AES256"},
+		},
+		{
+			desc:    "Testing parse an indented 1.2 vault header with a vault id label",
+			content: "\n  $ANSIBLE_VAULT;1.2;AES256;dev  \n  3238",
+			header:  &VaultHeader{Version: "1.2", Cipher: "AES256", Label: "dev"},
+		},
+		{
+			desc:    "Testing error parsing content that is not vaulted",
+			content: "plain text",
+			err:     errors.New("Content is not vaulted."),
+		},
+		{
+			desc:    "Testing error parsing a 1.2 vault header without a vault id label",
+			content: "$ANSIBLE_VAULT;1.2;AES256",
+			err:     errors.New("Invalid vault header '$ANSIBLE_VAULT;1.2;AES256'. The vault id label is required by the vault format version 1.2."),
+		},
+		{
+			desc:    "Testing error parsing an unsupported vault format version",
+			content: "$ANSIBLE_VAULT;1.0;AES",
+			err:     errors.New("Vault format version '1.0' is not supported."),
+		},
+		{
+			desc:    "Testing error parsing an unsupported vault cipher",
+			content: "$ANSIBLE_VAULT;1.1;AES",
+			err:     errors.New("Vault cipher 'AES' is not supported."),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			header, err := ParseVaultHeader(test.content)
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.header, header)
+			}
+		})
+	}
+}
+
+func TestIsVaulted(t *testing.T) {
+	assert.True(t, IsVaulted("  $ANSIBLE_VAULT;1.1;AES256\n3238"))
+	assert.False(t, IsVaulted("$ANSIBLE_VAULTED"))
+}
+
+func TestVaultHeaderString(t *testing.T) {
+	assert.Equal(t, "$ANSIBLE_VAULT;1.1;AES256", NewVaultHeader("").String())
+	assert.Equal(t, "$ANSIBLE_VAULT;1.2;AES256;dev", NewVaultHeader("dev").String())
+}
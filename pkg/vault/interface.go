@@ -3,3 +3,23 @@ package vault
 type Encrypter interface {
 	Encrypt(plainText string) (string, error)
 }
+
+// Decrypter is the interface to decrypt vaulted values
+type Decrypter interface {
+	Decrypt(cipherText string) (string, error)
+}
+
+// PasswordReader defines the implementation of a password reader
+type PasswordReader interface {
+	Read() (string, error)
+}
+
+// VaultIDSourcer is implemented by the password readers that can be passed to the ansible commands as the source of a vault identity, such as a password file
+type VaultIDSourcer interface {
+	VaultIDSource() string
+}
+
+// Vaulter is the interface to vault variable values
+type Vaulter interface {
+	Vault(value string) (*VaultVariableValue, error)
+}
@@ -0,0 +1,14 @@
+package client
+
+import "github.com/apenella/go-ansible/v2/pkg/execute"
+
+// PasswordReader defines the implementation of a password reader
+type PasswordReader interface {
+	Read() (string, error)
+}
+
+// ExecutorEnvVarSetter is an executor that accepts environment variables
+type ExecutorEnvVarSetter interface {
+	execute.Executor
+	AddEnvVar(key, value string)
+}
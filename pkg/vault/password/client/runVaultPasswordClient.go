@@ -0,0 +1,61 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+)
+
+// HandleVaultPasswordClient runs the vault password client and exits when the Go binary is started with the hidden sub-command. Otherwise, it returns without doing anything. The Go binaries that use the self exec mode must call it at the beginning of their main function
+func HandleVaultPasswordClient() {
+	if len(os.Args) < 2 || os.Args[1] != VaultPasswordClientSubCommand {
+		return
+	}
+
+	os.Exit(RunVaultPasswordClient(os.Args[2:], os.Stdout, os.Stderr))
+}
+
+// RunVaultPasswordClient requests the password of the vault id, received as `--vault-id label`, and writes it to stdout. It returns the exit code expected by ansible
+func RunVaultPasswordClient(args []string, stdout, stderr io.Writer) int {
+	label := DefaultVaultIDLabel
+	if len(args) > 1 && args[0] == "--vault-id" {
+		label = args[1]
+	}
+
+	conn, err := net.Dial("unix", os.Getenv(VaultPasswordClientSocketEnv))
+	if err != nil {
+		fmt.Fprintf(stderr, "error connecting to the vault password client socket: %s\n", err)
+		return 1
+	}
+	defer conn.Close()
+
+	_, err = fmt.Fprintf(conn, "%s\n%s\n", os.Getenv(VaultPasswordClientTokenEnv), label)
+	if err == nil {
+		err = conn.(*net.UnixConn).CloseWrite()
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "error requesting the password of vault id '%s': %s\n", label, err)
+		return 1
+	}
+
+	response, err := io.ReadAll(conn)
+	if err != nil {
+		fmt.Fprintf(stderr, "error reading the password of vault id '%s': %s\n", label, err)
+		return 1
+	}
+
+	status, value, _ := strings.Cut(string(response), "\n")
+	switch status {
+	case statusOK:
+		fmt.Fprintln(stdout, value)
+		return 0
+	case statusUnknown:
+		fmt.Fprintln(stderr, value)
+		return UnknownVaultIDExitCode
+	default:
+		fmt.Fprintln(stderr, value)
+		return 1
+	}
+}
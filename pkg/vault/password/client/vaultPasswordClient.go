@@ -0,0 +1,411 @@
+package client
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// VaultPasswordClientSubCommand is the hidden sub-command that runs the Go binary as a vault password client
+	VaultPasswordClientSubCommand = "__go-ansible-vault-password-client"
+
+	// VaultPasswordClientSocketEnv is the environment variable that holds the socket where the vault password client requests the passwords
+	VaultPasswordClientSocketEnv = "GO_ANSIBLE_VAULT_PASSWORD_CLIENT_SOCKET"
+
+	// VaultPasswordClientTokenEnv is the environment variable that holds the token used by the vault password client to authenticate its requests
+	VaultPasswordClientTokenEnv = "GO_ANSIBLE_VAULT_PASSWORD_CLIENT_TOKEN"
+
+	// AnsibleVaultIdentityListEnv is the environment variable that holds the vault identities used by default by the ansible commands. It is equivalent to multiple --vault-id flags
+	AnsibleVaultIdentityListEnv = "ANSIBLE_VAULT_IDENTITY_LIST"
+
+	// DefaultVaultIDLabel is the label that ansible uses for the vault identities defined without a label
+	DefaultVaultIDLabel = "default"
+
+	// DefaultPythonInterpreter is the interpreter of the vault password client script
+	DefaultPythonInterpreter = "/usr/bin/env python3"
+
+	// UnknownVaultIDExitCode is the exit code of the vault password client when the vault id is unknown, as expected by ansible
+	UnknownVaultIDExitCode = 2
+
+	// clientScriptName is the name of the vault password client script. Ansible only passes the --vault-id flag to the scripts whose name ends with -client
+	clientScriptName = "go-ansible-vault-client"
+
+	// socketName is the name of the socket where the passwords are requested
+	socketName = "vault.sock"
+
+	// statusOK is the response status when the password is provided
+	statusOK = "ok"
+
+	// statusUnknown is the response status when the vault id is unknown
+	statusUnknown = "unknown"
+
+	// statusError is the response status when the password can not be provided
+	statusError = "error"
+)
+
+// pythonClientScript is the vault password client script, which requests the password to the socket
+const pythonClientScript = `#!%s
+import os
+import socket
+import sys
+
+label = "default"
+if len(sys.argv) > 2 and sys.argv[1] == "--vault-id":
+    label = sys.argv[2]
+
+client = socket.socket(socket.AF_UNIX, socket.SOCK_STREAM)
+client.connect(os.environ["%s"])
+client.sendall((os.environ["%s"] + "\n" + label + "\n").encode())
+client.shutdown(socket.SHUT_WR)
+
+response = b""
+while True:
+    chunk = client.recv(4096)
+    if not chunk:
+        break
+    response += chunk
+
+status, _, value = response.decode().partition("\n")
+if status != "ok":
+    sys.stderr.write(value + "\n")
+    sys.exit(%d if status == "unknown" else 1)
+
+sys.stdout.write(value + "\n")
+`
+
+// selfExecClientScript is the vault password client script that runs the Go binary with the hidden sub-command
+const selfExecClientScript = `#!/bin/sh
+exec %s %s "$@"
+`
+
+// VaultPasswordClientOptionsFunc is a function to set the VaultPasswordClient options
+type VaultPasswordClientOptionsFunc func(*VaultPasswordClient)
+
+// VaultPasswordClient exposes the password readers to the ansible commands as a vault password client script. The passwords are requested by the script through a unix socket, so they are never written to the filesystem
+type VaultPasswordClient struct {
+	// Keyring holds the password readers of the vault identities. Only the vault identities with a password reader are exposed
+	Keyring *vault.VaultKeyring
+
+	// SelfExec runs the Go binary as the vault password client, instead of the python script. The binary must call HandleVaultPasswordClient at the beginning of its main function
+	SelfExec bool
+
+	// Executable is the Go binary run as the vault password client. It is the current executable when it is not defined
+	Executable string
+
+	// PythonInterpreter is the interpreter of the vault password client python script
+	PythonInterpreter string
+
+	// VaultIdentityList are the vault identities kept on the ANSIBLE_VAULT_IDENTITY_LIST environment variable before the ones of the client, such as the ones defined on the ansible.cfg file or by the executor, which the environment variable set by the client overrides. The vault identities of the environment variable of the current process are always kept
+	VaultIdentityList []string
+
+	mutex    sync.Mutex
+	dir      string
+	token    string
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+// NewVaultPasswordClient returns a VaultPasswordClient
+func NewVaultPasswordClient(options ...VaultPasswordClientOptionsFunc) *VaultPasswordClient {
+	client := &VaultPasswordClient{
+		Keyring:           vault.NewVaultKeyring(),
+		PythonInterpreter: DefaultPythonInterpreter,
+	}
+
+	for _, option := range options {
+		option(client)
+	}
+
+	return client
+}
+
+// WithReader exposes the password reader as the vault identity with the label
+func WithReader(label string, reader PasswordReader) VaultPasswordClientOptionsFunc {
+	return func(c *VaultPasswordClient) {
+		c.Keyring.Options(vault.WithVaultIdentity(label, reader))
+	}
+}
+
+// WithKeyring exposes the vault identities of the keyring that have a password reader
+func WithKeyring(keyring *vault.VaultKeyring) VaultPasswordClientOptionsFunc {
+	return func(c *VaultPasswordClient) {
+		c.Keyring = keyring
+	}
+}
+
+// WithSelfExec runs the Go binary as the vault password client
+func WithSelfExec() VaultPasswordClientOptionsFunc {
+	return func(c *VaultPasswordClient) {
+		c.SelfExec = true
+	}
+}
+
+// WithExecutable sets the Go binary run as the vault password client
+func WithExecutable(executable string) VaultPasswordClientOptionsFunc {
+	return func(c *VaultPasswordClient) {
+		c.Executable = executable
+	}
+}
+
+// WithVaultIdentityList keeps the vault identities on the ANSIBLE_VAULT_IDENTITY_LIST environment variable before the ones of the client
+func WithVaultIdentityList(vaultIDs ...string) VaultPasswordClientOptionsFunc {
+	return func(c *VaultPasswordClient) {
+		c.VaultIdentityList = append(c.VaultIdentityList, vaultIDs...)
+	}
+}
+
+// WithPythonInterpreter sets the interpreter of the vault password client python script
+func WithPythonInterpreter(interpreter string) VaultPasswordClientOptionsFunc {
+	return func(c *VaultPasswordClient) {
+		c.PythonInterpreter = interpreter
+	}
+}
+
+// Start creates the vault password client script and starts serving the passwords. The script and the socket are created in a temporary directory only accessible by the current user
+func (c *VaultPasswordClient) Start() error {
+	var err error
+
+	errContext := "(client::VaultPasswordClient::Start)"
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listener != nil {
+		return errors.New(errContext, "Vault password client is already started")
+	}
+
+	if len(c.labels()) == 0 {
+		return errors.New(errContext, "Vault password client requires at least one vault identity with a password reader")
+	}
+
+	token := make([]byte, 32)
+	_, err = rand.Read(token)
+	if err != nil {
+		return errors.New(errContext, "Error generating the vault password client token", err)
+	}
+	c.token = hex.EncodeToString(token)
+
+	c.dir, err = os.MkdirTemp("", "go-ansible-vault-")
+	if err != nil {
+		return errors.New(errContext, "Error creating the vault password client directory", err)
+	}
+
+	script, err := c.script()
+	if err == nil {
+		err = os.WriteFile(c.scriptPath(), []byte(script), 0700)
+	}
+	if err != nil {
+		_ = os.RemoveAll(c.dir)
+		return errors.New(errContext, "Error creating the vault password client script", err)
+	}
+
+	c.listener, err = net.Listen("unix", filepath.Join(c.dir, socketName))
+	if err != nil {
+		_ = os.RemoveAll(c.dir)
+		return errors.New(errContext, "Error listening on the vault password client socket", err)
+	}
+
+	c.wg.Add(1)
+	go c.serve(c.listener)
+
+	return nil
+}
+
+// Stop stops serving the passwords and removes the vault password client script and socket
+func (c *VaultPasswordClient) Stop() error {
+	errContext := "(client::VaultPasswordClient::Stop)"
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listener == nil {
+		return nil
+	}
+
+	_ = c.listener.Close()
+	c.wg.Wait()
+	c.listener = nil
+
+	err := os.RemoveAll(c.dir)
+	if err != nil {
+		return errors.New(errContext, "Error removing the vault password client directory", err)
+	}
+
+	return nil
+}
+
+// VaultIDs returns the vault identities as `label@script`, to be passed to the ansible commands by the `--vault-id` flag. They are only available once the client is started
+func (c *VaultPasswordClient) VaultIDs() []string {
+	vaultIDs := []string{}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.listener == nil {
+		return vaultIDs
+	}
+
+	for _, label := range c.labels() {
+		vaultIDs = append(vaultIDs, fmt.Sprintf("%s@%s", label, c.scriptPath()))
+	}
+
+	return vaultIDs
+}
+
+// EnvVars returns the environment variables required by the vault password client, including the ANSIBLE_VAULT_IDENTITY_LIST that wires the vault identities into the ansible commands. The vault identities already defined on the ANSIBLE_VAULT_IDENTITY_LIST of the current process and the ones of the VaultIdentityList are kept before the ones of the client. They are only available once the client is started
+func (c *VaultPasswordClient) EnvVars() map[string]string {
+	vaultIDs := c.VaultIDs()
+	if len(vaultIDs) == 0 {
+		return map[string]string{}
+	}
+
+	identityList := []string{}
+	for _, vaultID := range strings.Split(os.Getenv(AnsibleVaultIdentityListEnv), ",") {
+		identityList = appendVaultID(identityList, vaultID)
+	}
+	for _, vaultID := range c.VaultIdentityList {
+		identityList = appendVaultID(identityList, vaultID)
+	}
+	for _, vaultID := range vaultIDs {
+		identityList = appendVaultID(identityList, vaultID)
+	}
+
+	return map[string]string{
+		VaultPasswordClientSocketEnv: filepath.Join(c.dir, socketName),
+		VaultPasswordClientTokenEnv:  c.token,
+		AnsibleVaultIdentityListEnv:  strings.Join(identityList, ","),
+	}
+}
+
+// appendVaultID appends the vault identity to the list when it is not empty nor already in the list
+func appendVaultID(vaultIDs []string, vaultID string) []string {
+	vaultID = strings.TrimSpace(vaultID)
+	if vaultID == "" {
+		return vaultIDs
+	}
+
+	for _, v := range vaultIDs {
+		if v == vaultID {
+			return vaultIDs
+		}
+	}
+
+	return append(vaultIDs, vaultID)
+}
+
+// labels returns the labels of the vault identities with a password reader
+func (c *VaultPasswordClient) labels() []string {
+	labels := []string{}
+
+	if c.Keyring == nil {
+		return labels
+	}
+
+	for _, identity := range c.Keyring.Identities {
+		if identity.Reader != nil {
+			labels = append(labels, identity.Label)
+		}
+	}
+
+	return labels
+}
+
+// scriptPath returns the path of the vault password client script
+func (c *VaultPasswordClient) scriptPath() string {
+	return filepath.Join(c.dir, clientScriptName)
+}
+
+// script returns the content of the vault password client script
+func (c *VaultPasswordClient) script() (string, error) {
+	if !c.SelfExec {
+		return fmt.Sprintf(pythonClientScript, c.PythonInterpreter, VaultPasswordClientSocketEnv, VaultPasswordClientTokenEnv, UnknownVaultIDExitCode), nil
+	}
+
+	executable := c.Executable
+	if executable == "" {
+		var err error
+		executable, err = os.Executable()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return fmt.Sprintf(selfExecClientScript, shellQuote(executable), VaultPasswordClientSubCommand), nil
+}
+
+// serve answers the password requests until the listener is closed
+func (c *VaultPasswordClient) serve(listener net.Listener) {
+	defer c.wg.Done()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			defer conn.Close()
+			c.answer(conn)
+		}()
+	}
+}
+
+// answer reads the token and the label of a password request, and writes the response status followed by the password or the error message
+func (c *VaultPasswordClient) answer(conn io.ReadWriter) {
+	reader := bufio.NewReader(conn)
+
+	token, _ := reader.ReadString('\n')
+	label, _ := reader.ReadString('\n')
+	token = strings.TrimSuffix(token, "\n")
+	label = strings.TrimSuffix(label, "\n")
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(c.token)) != 1 {
+		fmt.Fprintf(conn, "%s\ninvalid vault password client token", statusError)
+		return
+	}
+
+	known := false
+	for _, l := range c.labels() {
+		if l == label {
+			known = true
+			break
+		}
+	}
+	if !known {
+		fmt.Fprintf(conn, "%s\nvault id '%s' is not known by the vault password client", statusUnknown, label)
+		return
+	}
+
+	passwordReader, err := c.Keyring.Reader(label)
+	if err != nil {
+		fmt.Fprintf(conn, "%s\n%s", statusError, err.Error())
+		return
+	}
+
+	password, err := passwordReader.Read()
+	if err != nil {
+		fmt.Fprintf(conn, "%s\nerror reading the password of vault id '%s': %s", statusError, label, err.Error())
+		return
+	}
+
+	fmt.Fprintf(conn, "%s\n%s", statusOK, password)
+}
+
+// shellQuote quotes the value to be used as a shell word
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// VaultPasswordClientExecute is a middleware that exposes the password readers to the executed ansible command through a VaultPasswordClient. The client is started before the execution and stopped after it
+type VaultPasswordClientExecute struct {
+	executor ExecutorEnvVarSetter
+	client   *VaultPasswordClient
+}
+
+// NewVaultPasswordClientExecute returns a VaultPasswordClientExecute
+func NewVaultPasswordClientExecute(executor ExecutorEnvVarSetter, options ...VaultPasswordClientOptionsFunc) *VaultPasswordClientExecute {
+	return &VaultPasswordClientExecute{
+		executor: executor,
+		client:   NewVaultPasswordClient(options...),
+	}
+}
+
+// WithExecutor sets the executor
+func (e *VaultPasswordClientExecute) WithExecutor(executor ExecutorEnvVarSetter) *VaultPasswordClientExecute {
+	e.executor = executor
+	return e
+}
+
+// Execute starts the vault password client, sets its environment variables to the executor and runs it
+func (e *VaultPasswordClientExecute) Execute(ctx context.Context) (err error) {
+	errContext := "(client::VaultPasswordClientExecute::Execute)"
+
+	if e.executor == nil {
+		return errors.New(errContext, "Executor must be provided on VaultPasswordClientExecute")
+	}
+
+	err = e.client.Start()
+	if err != nil {
+		return errors.New(errContext, "Error starting the vault password client", err)
+	}
+	defer func() {
+		stopErr := e.client.Stop()
+		if err == nil && stopErr != nil {
+			err = errors.New(errContext, "Error stopping the vault password client", stopErr)
+		}
+	}()
+
+	for key, value := range e.client.EnvVars() {
+		e.executor.AddEnvVar(key, value)
+	}
+
+	// the error is returned as it is to keep it reachable by errors.Is and errors.As
+	return e.executor.Execute(ctx)
+}
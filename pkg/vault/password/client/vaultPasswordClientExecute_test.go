@@ -0,0 +1,44 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVaultPasswordClientExecute(t *testing.T) {
+	envVars := map[string]string{}
+	stdout := &bytes.Buffer{}
+
+	executor := execute.NewMockExecute()
+	executor.On("AddEnvVar", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		envVars[args.String(0)] = args.String(1)
+	})
+	executor.On("Execute", mock.Anything).Run(func(args mock.Arguments) {
+		setClientEnv(t, envVars)
+		RunVaultPasswordClient([]string{"--vault-id", "dev"}, stdout, stdout)
+	}).Return(errors.New("execution failed"))
+
+	exec := NewVaultPasswordClientExecute(executor,
+		WithReader("dev", text.NewReadPasswordFromText(text.WithText("dev-secret"))),
+	)
+
+	err := exec.Execute(context.TODO())
+	assert.EqualError(t, err, "execution failed")
+	assert.Equal(t, "dev-secret\n", stdout.String())
+	assert.Contains(t, envVars, VaultPasswordClientSocketEnv)
+	assert.Contains(t, envVars, VaultPasswordClientTokenEnv)
+	assert.Regexp(t, "^dev@.*/go-ansible-vault-client$", envVars[AnsibleVaultIdentityListEnv])
+	assert.Empty(t, exec.client.EnvVars())
+}
+
+func TestVaultPasswordClientExecuteWithoutExecutor(t *testing.T) {
+	err := NewVaultPasswordClientExecute(nil).Execute(context.TODO())
+	assert.ErrorContains(t, err, "Executor must be provided on VaultPasswordClientExecute")
+}
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	"github.com/stretchr/testify/assert"
+)
+
+// setClientEnv sets the environment variables of the vault password client to the current process
+func setClientEnv(t *testing.T, envVars map[string]string) {
+	t.Helper()
+
+	for key, value := range envVars {
+		t.Setenv(key, value)
+	}
+}
+
+func TestNewVaultPasswordClient(t *testing.T) {
+	reader := text.NewReadPasswordFromText(text.WithText("secret"))
+
+	client := NewVaultPasswordClient(
+		WithReader("dev", reader),
+		WithSelfExec(),
+		WithExecutable("/usr/local/bin/deployer"),
+	)
+
+	assert.Equal(t, []string{"dev"}, client.Keyring.Labels())
+	assert.True(t, client.SelfExec)
+	assert.Equal(t, "/usr/local/bin/deployer", client.Executable)
+	assert.Equal(t, DefaultPythonInterpreter, client.PythonInterpreter)
+}
+
+func TestVaultPasswordClient(t *testing.T) {
+	client := NewVaultPasswordClient(
+		WithKeyring(vault.NewVaultKeyring(
+			vault.WithVaultIdentity("dev", text.NewReadPasswordFromText(text.WithText("dev-secret"))),
+			vault.WithVaultIdentity("prod", text.NewReadPasswordFromText()),
+			vault.WithVaultIdentitySource("test", vault.VaultIDPromptSource),
+		)),
+	)
+
+	assert.Empty(t, client.VaultIDs())
+
+	err := client.Start()
+	assert.NoError(t, err)
+
+	script := filepath.Join(client.dir, clientScriptName)
+	info, err := os.Stat(script)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+	assert.Equal(t, []string{"dev@" + script, "prod@" + script}, client.VaultIDs())
+
+	envVars := client.EnvVars()
+	assert.Equal(t, "dev@"+script+",prod@"+script, envVars[AnsibleVaultIdentityListEnv])
+	setClientEnv(t, envVars)
+
+	tests := []struct {
+		desc     string
+		args     []string
+		token    string
+		stdout   string
+		stderr   string
+		exitCode int
+	}{
+		{
+			desc:     "Testing request the password of a vault id",
+			args:     []string{"--vault-id", "dev"},
+			stdout:   "dev-secret\n",
+			exitCode: 0,
+		},
+		{
+			desc:     "Testing request the password of an unknown vault id",
+			args:     []string{"--vault-id", "test"},
+			stderr:   "vault id 'test' is not known by the vault password client\n",
+			exitCode: UnknownVaultIDExitCode,
+		},
+		{
+			desc:     "Testing request the password of a vault id whose password reader fails",
+			args:     []string{"--vault-id", "prod"},
+			stderr:   "error reading the password of vault id 'prod': Text must be specified to use the password input from text.\n",
+			exitCode: 1,
+		},
+		{
+			desc:     "Testing request a password with an invalid token",
+			args:     []string{"--vault-id", "dev"},
+			token:    "invalid",
+			stderr:   "invalid vault password client token\n",
+			exitCode: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if test.token != "" {
+				t.Setenv(VaultPasswordClientTokenEnv, test.token)
+			}
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+
+			exitCode := RunVaultPasswordClient(test.args, stdout, stderr)
+			assert.Equal(t, test.exitCode, exitCode)
+			assert.Equal(t, test.stdout, stdout.String())
+			assert.Equal(t, test.stderr, stderr.String())
+		})
+	}
+
+	err = client.Stop()
+	assert.NoError(t, err)
+	_, err = os.Stat(client.dir)
+	assert.True(t, os.IsNotExist(err))
+	assert.Empty(t, client.EnvVars())
+}
+
+func TestVaultPasswordClientEnvVarsKeepVaultIdentityList(t *testing.T) {
+	t.Setenv(AnsibleVaultIdentityListEnv, "ops@~/.vault_pass, ci@prompt")
+
+	client := NewVaultPasswordClient(
+		WithReader("dev", text.NewReadPasswordFromText(text.WithText("dev-secret"))),
+		WithVaultIdentityList("cfg@/etc/ansible/vault_pass", "ci@prompt"),
+	)
+
+	err := client.Start()
+	assert.NoError(t, err)
+	defer func() { _ = client.Stop() }()
+
+	script := filepath.Join(client.dir, clientScriptName)
+	assert.Equal(t, "ops@~/.vault_pass,ci@prompt,cfg@/etc/ansible/vault_pass,dev@"+script, client.EnvVars()[AnsibleVaultIdentityListEnv])
+}
+
+func TestVaultPasswordClientStartWithoutReaders(t *testing.T) {
+	client := NewVaultPasswordClient(
+		WithKeyring(vault.NewVaultKeyring(
+			vault.WithVaultIdentitySource("test", vault.VaultIDPromptSource),
+		)),
+	)
+
+	err := client.Start()
+	assert.ErrorContains(t, err, "Vault password client requires at least one vault identity with a password reader")
+}
+
+func TestVaultPasswordClientScript(t *testing.T) {
+	t.Run("Testing request the password using the python client script", func(t *testing.T) {
+		_, err := exec.LookPath("python3")
+		if err != nil {
+			t.Skip("python3 is not available")
+		}
+
+		client := NewVaultPasswordClient(WithReader("dev", text.NewReadPasswordFromText(text.WithText("dev-secret"))))
+		err = client.Start()
+		assert.NoError(t, err)
+		defer client.Stop()
+
+		cmd := exec.Command(filepath.Join(client.dir, clientScriptName), "--vault-id", "dev")
+		for key, value := range client.EnvVars() {
+			cmd.Env = append(cmd.Env, key+"="+value)
+		}
+		cmd.Env = append(cmd.Env, "PATH="+os.Getenv("PATH"))
+
+		output, err := cmd.Output()
+		assert.NoError(t, err)
+		assert.Equal(t, "dev-secret\n", string(output))
+	})
+
+	t.Run("Testing the self exec client script runs the executable with the hidden sub-command", func(t *testing.T) {
+		client := NewVaultPasswordClient(
+			WithReader("dev", text.NewReadPasswordFromText(text.WithText("dev-secret"))),
+			WithSelfExec(),
+			WithExecutable("/bin/echo"),
+		)
+		err := client.Start()
+		assert.NoError(t, err)
+		defer client.Stop()
+
+		output, err := exec.Command(filepath.Join(client.dir, clientScriptName), "--vault-id", "dev").Output()
+		assert.NoError(t, err)
+		assert.Equal(t, VaultPasswordClientSubCommand+" --vault-id dev", strings.TrimSpace(string(output)))
+	})
+}
@@ -0,0 +1,117 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultTimeout is the default time to wait for the command to return the password
+	DefaultTimeout = 30 * time.Second
+
+	// waitDelay is the time to wait for the command output to be closed once the command is killed
+	waitDelay = time.Second
+)
+
+// OptionsFunc is a function used to configure ReadPasswordFromCommand
+type OptionsFunc func(*ReadPasswordFromCommand)
+
+// ReadPasswordFromCommand allows you to read a password from the output of an external command, such as `pass show ansible/vault`, `gopass show -o ansible/vault` or `op read op://ci/ansible/password`
+type ReadPasswordFromCommand struct {
+	command []string
+	timeout time.Duration
+	env     []string
+}
+
+// NewReadPasswordFromCommand returns a ReadPasswordFromCommand
+func NewReadPasswordFromCommand(options ...OptionsFunc) *ReadPasswordFromCommand {
+	secret := &ReadPasswordFromCommand{
+		timeout: DefaultTimeout,
+	}
+	secret.Options(options...)
+
+	return secret
+}
+
+// WithCommand sets the command, and its arguments, that writes the password to its stdout
+func WithCommand(name string, args ...string) OptionsFunc {
+	return func(s *ReadPasswordFromCommand) {
+		s.command = append([]string{name}, args...)
+	}
+}
+
+// WithTimeout sets the time to wait for the command to return the password
+func WithTimeout(timeout time.Duration) OptionsFunc {
+	return func(s *ReadPasswordFromCommand) {
+		s.timeout = timeout
+	}
+}
+
+// WithEnv sets environment variables to the command, in the form key=value. They are added to the environment of the current process
+func WithEnv(env ...string) OptionsFunc {
+	return func(s *ReadPasswordFromCommand) {
+		s.env = append(s.env, env...)
+	}
+}
+
+// Options configure the ReadPasswordFromCommand
+func (s *ReadPasswordFromCommand) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
+
+// Read returns the first line written by the command to its stdout. It returns an error when the command fails, does not finish before the timeout or does not return a password
+func (s *ReadPasswordFromCommand) Read() (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	if s == nil {
+		return "", errors.New("Read password from a command component has not been initialized.")
+	}
+
+	if len(s.command) <= 0 {
+		return "", errors.New("Command must be specified to read the password from a command.")
+	}
+
+	ctx := context.Background()
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, s.command[0], s.command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// do not wait for the processes started by the command that keep its output open once it is killed
+	cmd.WaitDelay = waitDelay
+	if len(s.env) > 0 {
+		cmd.Env = append(cmd.Environ(), s.env...)
+	}
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", errors.New(fmt.Sprintf("Command '%s' did not return the password after %s.", s.command[0], s.timeout))
+	}
+	if err != nil {
+		message := fmt.Sprintf("Error running the command '%s'.", s.command[0])
+		if stderr.Len() > 0 {
+			message = fmt.Sprintf("%s %s", message, strings.TrimSpace(stderr.String()))
+		}
+		return "", errors.Wrap(err, message)
+	}
+
+	password, _, _ := strings.Cut(stdout.String(), "\n")
+	password = strings.TrimSuffix(password, "\r")
+	if len(password) <= 0 {
+		return "", errors.New(fmt.Sprintf("Command '%s' did not return a password.", s.command[0]))
+	}
+
+	return password, nil
+}
@@ -0,0 +1,78 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		desc     string
+		reader   *ReadPasswordFromCommand
+		expected string
+		err      error
+	}{
+		{
+			desc: "Testing reading a password from a command",
+			reader: NewReadPasswordFromCommand(
+				WithCommand("sh", "-c", "printf 'ThatIsAPassword\\nsecond line\\n'"),
+			),
+			expected: "ThatIsAPassword",
+		},
+		{
+			desc: "Testing reading a password from a command with environment variables",
+			reader: NewReadPasswordFromCommand(
+				WithCommand("sh", "-c", "echo $VAULT_PASSWORD"),
+				WithEnv("VAULT_PASSWORD=ThatIsAPassword"),
+			),
+			expected: "ThatIsAPassword",
+		},
+		{
+			desc:   "Testing error reading a password from a command when ReadPasswordFromCommand is not initialized",
+			reader: nil,
+			err:    errors.New("Read password from a command component has not been initialized."),
+		},
+		{
+			desc:   "Testing error reading a password from a command when the command is not specified",
+			reader: NewReadPasswordFromCommand(),
+			err:    errors.New("Command must be specified to read the password from a command."),
+		},
+		{
+			desc: "Testing error reading a password from a failing command",
+			reader: NewReadPasswordFromCommand(
+				WithCommand("sh", "-c", "echo 'entry not found' >&2; exit 1"),
+			),
+			err: errors.New("Error running the command 'sh'. entry not found: exit status 1"),
+		},
+		{
+			desc: "Testing error reading a password from a command that does not return a password",
+			reader: NewReadPasswordFromCommand(
+				WithCommand("true"),
+			),
+			err: errors.New("Command 'true' did not return a password."),
+		},
+		{
+			desc: "Testing error reading a password from a command that exceeds the timeout",
+			reader: NewReadPasswordFromCommand(
+				WithCommand("sleep", "5"),
+				WithTimeout(100*time.Millisecond),
+			),
+			err: errors.New("Command 'sleep' did not return the password after 100ms."),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			password, err := test.reader.Read()
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, password)
+			}
+		})
+	}
+}
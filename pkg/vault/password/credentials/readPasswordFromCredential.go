@@ -0,0 +1,99 @@
+package credentials
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	// CredentialsDirectoryEnv is the environment variable where systemd sets the directory of the service credentials
+	CredentialsDirectoryEnv = "CREDENTIALS_DIRECTORY"
+)
+
+// OptionsFunc is a function used to configure ReadPasswordFromCredential
+type OptionsFunc func(*ReadPasswordFromCredential)
+
+// ReadPasswordFromCredential allows you to read a password from a credentials directory, such as the one that systemd exposes to the services through the `$CREDENTIALS_DIRECTORY` environment variable, which is populated by `LoadCredential=` or `systemd-creds`
+type ReadPasswordFromCredential struct {
+	credential string
+	directory  string
+	fs         afero.Fs
+}
+
+// NewReadPasswordFromCredential returns a ReadPasswordFromCredential
+func NewReadPasswordFromCredential(options ...OptionsFunc) *ReadPasswordFromCredential {
+	secret := &ReadPasswordFromCredential{}
+	secret.Options(options...)
+
+	return secret
+}
+
+// WithCredential sets the name of the credential that contains the password
+func WithCredential(credential string) OptionsFunc {
+	return func(s *ReadPasswordFromCredential) {
+		s.credential = credential
+	}
+}
+
+// WithDirectory sets the credentials directory. When it is not set, the directory is taken from the `$CREDENTIALS_DIRECTORY` environment variable
+func WithDirectory(directory string) OptionsFunc {
+	return func(s *ReadPasswordFromCredential) {
+		s.directory = directory
+	}
+}
+
+// WithFs set the filesystem
+func WithFs(fs afero.Fs) OptionsFunc {
+	return func(s *ReadPasswordFromCredential) {
+		s.fs = fs
+	}
+}
+
+// Options configure the ReadPasswordFromCredential
+func (s *ReadPasswordFromCredential) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
+
+// Read returns the content of the credential, without the trailing new line. It returns an error when the credentials directory is not set, or the credential does not exist or is empty
+func (s *ReadPasswordFromCredential) Read() (string, error) {
+	if s == nil {
+		return "", errors.New("Read password from a credential component has not been initialized.")
+	}
+
+	if len(s.credential) <= 0 {
+		return "", errors.New("Credential must be specified to read the password from a credential.")
+	}
+
+	directory := s.directory
+	if len(directory) <= 0 {
+		directory = os.Getenv(CredentialsDirectoryEnv)
+	}
+
+	if len(directory) <= 0 {
+		return "", errors.New(fmt.Sprintf("Credentials directory must be specified, or set by the environment variable '%s', to read the password from a credential.", CredentialsDirectoryEnv))
+	}
+
+	if s.fs == nil {
+		s.fs = afero.NewOsFs()
+	}
+
+	file := filepath.Join(directory, s.credential)
+	content, err := afero.ReadFile(s.fs, file)
+	if err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("Error reading the credential '%s'.", s.credential))
+	}
+
+	password := strings.TrimSuffix(strings.TrimSuffix(string(content), "\n"), "\r")
+	if len(password) <= 0 {
+		return "", errors.New(fmt.Sprintf("Credential '%s' is empty.", s.credential))
+	}
+
+	return password, nil
+}
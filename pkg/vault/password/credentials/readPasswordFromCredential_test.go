@@ -0,0 +1,87 @@
+package credentials
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(testFs, "/run/credentials/deploy.service/vault-password", []byte("ThatIsAPassword\n"), 0400)
+	_ = afero.WriteFile(testFs, "/run/credentials/deploy.service/empty", []byte(""), 0400)
+
+	t.Setenv(CredentialsDirectoryEnv, "/run/credentials/deploy.service")
+
+	tests := []struct {
+		desc     string
+		reader   *ReadPasswordFromCredential
+		expected string
+		err      error
+	}{
+		{
+			desc: "Testing reading a password from a credential of the credentials directory set by the environment variable",
+			reader: NewReadPasswordFromCredential(
+				WithFs(testFs),
+				WithCredential("vault-password"),
+			),
+			expected: "ThatIsAPassword",
+		},
+		{
+			desc: "Testing reading a password from a credential of a credentials directory",
+			reader: NewReadPasswordFromCredential(
+				WithFs(testFs),
+				WithDirectory("/run/credentials/deploy.service"),
+				WithCredential("vault-password"),
+			),
+			expected: "ThatIsAPassword",
+		},
+		{
+			desc:   "Testing error reading a password from a credential when ReadPasswordFromCredential is not initialized",
+			reader: nil,
+			err:    errors.New("Read password from a credential component has not been initialized."),
+		},
+		{
+			desc:   "Testing error reading a password from a credential when the credential is not specified",
+			reader: NewReadPasswordFromCredential(WithFs(testFs)),
+			err:    errors.New("Credential must be specified to read the password from a credential."),
+		},
+		{
+			desc: "Testing error reading a password from a credential that does not exist",
+			reader: NewReadPasswordFromCredential(
+				WithFs(testFs),
+				WithCredential("unexisting"),
+			),
+			err: errors.New("Error reading the credential 'unexisting'.: open /run/credentials/deploy.service/unexisting: file does not exist"),
+		},
+		{
+			desc: "Testing error reading a password from an empty credential",
+			reader: NewReadPasswordFromCredential(
+				WithFs(testFs),
+				WithCredential("empty"),
+			),
+			err: errors.New("Credential 'empty' is empty."),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			password, err := test.reader.Read()
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, password)
+			}
+		})
+	}
+}
+
+func TestReadWithoutCredentialsDirectory(t *testing.T) {
+	t.Setenv(CredentialsDirectoryEnv, "")
+
+	_, err := NewReadPasswordFromCredential(WithCredential("vault-password")).Read()
+	assert.EqualError(t, err, "Credentials directory must be specified, or set by the environment variable 'CREDENTIALS_DIRECTORY', to read the password from a credential.")
+}
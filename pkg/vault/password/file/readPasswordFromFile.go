@@ -88,3 +88,12 @@ func (s *ReadPasswordFromFile) Read() (string, error) {
 
 	return password, nil
 }
+
+// VaultIDSource returns the password file, to be used as the source of a vault identity
+func (s *ReadPasswordFromFile) VaultIDSource() string {
+	if s == nil {
+		return ""
+	}
+
+	return s.file
+}
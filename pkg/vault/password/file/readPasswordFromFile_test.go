@@ -66,3 +66,11 @@ func TestRead(t *testing.T) {
 		})
 	}
 }
+
+func TestVaultIDSource(t *testing.T) {
+	reader := NewReadPasswordFromFile(
+		WithFile("/password"),
+	)
+
+	assert.Equal(t, "/password", reader.VaultIDSource())
+}
@@ -0,0 +1,86 @@
+package reader
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// OptionsFunc is a function used to configure ReadPasswordFromReader
+type OptionsFunc func(*ReadPasswordFromReader)
+
+// ReadPasswordFromReader allows you to read a password from an io.Reader, such as the stdin or a file descriptor inherited from the parent process. The reader is consumed on the first read, so the password is kept to be returned by the next reads
+type ReadPasswordFromReader struct {
+	reader io.Reader
+
+	mutex    sync.Mutex
+	read     bool
+	password string
+}
+
+// NewReadPasswordFromReader returns a ReadPasswordFromReader
+func NewReadPasswordFromReader(options ...OptionsFunc) *ReadPasswordFromReader {
+	secret := &ReadPasswordFromReader{}
+	secret.Options(options...)
+
+	return secret
+}
+
+// WithReader sets the reader where to read the password from
+func WithReader(reader io.Reader) OptionsFunc {
+	return func(s *ReadPasswordFromReader) {
+		s.reader = reader
+	}
+}
+
+// WithStdin reads the password from the stdin
+func WithStdin() OptionsFunc {
+	return WithReader(os.Stdin)
+}
+
+// WithFd reads the password from the file descriptor, such as one inherited from the parent process
+func WithFd(fd uintptr) OptionsFunc {
+	return WithReader(os.NewFile(fd, "password"))
+}
+
+// Options configure the ReadPasswordFromReader
+func (s *ReadPasswordFromReader) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
+
+// Read returns the first line read from the reader. It returns an error when the reader is not defined, or when the password can not be read
+func (s *ReadPasswordFromReader) Read() (string, error) {
+	if s == nil {
+		return "", errors.New("Read password from a reader component has not been initialized.")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.read {
+		return s.password, nil
+	}
+
+	if s.reader == nil {
+		return "", errors.New("Reader must be specified to read the password from a reader.")
+	}
+
+	scanner := bufio.NewScanner(s.reader)
+	if !scanner.Scan() {
+		err := scanner.Err()
+		if err == nil {
+			err = io.EOF
+		}
+		return "", errors.Wrap(err, "Error reading the password from the reader.")
+	}
+
+	s.password = scanner.Text()
+	s.read = true
+
+	return s.password, nil
+}
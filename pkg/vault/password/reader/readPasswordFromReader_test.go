@@ -0,0 +1,73 @@
+package reader
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		desc     string
+		reader   *ReadPasswordFromReader
+		expected string
+		err      error
+	}{
+		{
+			desc: "Testing reading a password from a reader",
+			reader: NewReadPasswordFromReader(
+				WithReader(strings.NewReader("ThatIsAPassword\nsecond line\n")),
+			),
+			expected: "ThatIsAPassword",
+		},
+		{
+			desc:   "Testing error reading a password from a reader when ReadPasswordFromReader is not initialized",
+			reader: nil,
+			err:    errors.New("Read password from a reader component has not been initialized."),
+		},
+		{
+			desc:   "Testing error reading a password from a reader when the reader is not specified",
+			reader: NewReadPasswordFromReader(),
+			err:    errors.New("Reader must be specified to read the password from a reader."),
+		},
+		{
+			desc: "Testing error reading a password from an empty reader",
+			reader: NewReadPasswordFromReader(
+				WithReader(strings.NewReader("")),
+			),
+			err: errors.New("Error reading the password from the reader.: EOF"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			password, err := test.reader.Read()
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, password)
+			}
+		})
+	}
+}
+
+func TestReadFromFd(t *testing.T) {
+	r, w, err := os.Pipe()
+	assert.NoError(t, err)
+
+	_, _ = w.WriteString("ThatIsAPassword\n")
+	_ = w.Close()
+
+	reader := NewReadPasswordFromReader(WithFd(r.Fd()))
+
+	// the password is kept once the file descriptor is consumed
+	for i := 0; i < 2; i++ {
+		password, err := reader.Read()
+		assert.NoError(t, err)
+		assert.Equal(t, "ThatIsAPassword", password)
+	}
+}
@@ -2,6 +2,7 @@ package resolve
 
 import (
 	"errors"
+	"fmt"
 )
 
 // OptionsFunc is a function used to configure ReadPasswordResolve
@@ -38,18 +39,22 @@ func (s *ReadPasswordResolve) Options(opts ...OptionsFunc) {
 	}
 }
 
-// Read looks for the first reader defined into the reader attribute which returns a password
+// Read looks for the first reader defined into the reader attribute which returns a password without error, even when the password is empty. When every reader fails, the error includes the reason why each reader failed, joined using errors.Join
 func (s *ReadPasswordResolve) Read() (string, error) {
 	if s == nil {
 		return "", errors.New("The component to resolve read password mechanism has not been initialized.")
 	}
 
-	for _, reader := range s.reader {
+	errs := []error{errors.New("The component to resolve read password does not found a password.")}
+
+	for i, reader := range s.reader {
 		secret, err := reader.Read()
 		if err == nil {
 			return secret, nil
 		}
+
+		errs = append(errs, fmt.Errorf("password reader #%d (%T): %w", i+1, reader, err))
 	}
 
-	return "", errors.New("The component to resolve read password does not found a password.")
+	return "", errors.Join(errs...)
 }
@@ -62,6 +62,41 @@ func TestRead(t *testing.T) {
 			reader: nil,
 			err:    errors.New("The component to resolve read password mechanism has not been initialized."),
 		},
+		{
+			desc: "Testing resolve an empty password from the first reader that does not fail",
+			reader: NewReadPasswordResolve(
+				WithReader(
+					file.NewReadPasswordFromFile(
+						file.WithFs(testFs),
+						file.WithFile("/unexisting"),
+					),
+					envvars.NewReadPasswordFromEnvVar(
+						envvars.WithEnvVar("UNEXISTING_VAULT_PASSWORD"),
+					),
+					envvars.NewReadPasswordFromEnvVar(
+						envvars.WithEnvVar("VAULT_PASSWORD"),
+					),
+				),
+			),
+			expected: "",
+			err:      nil,
+		},
+		{
+			desc: "Testing error resolving the password including the reason why each reader failed",
+			reader: NewReadPasswordResolve(
+				WithReader(
+					file.NewReadPasswordFromFile(
+						file.WithFs(testFs),
+						file.WithFile("/unexisting"),
+					),
+					file.NewReadPasswordFromFile(
+						file.WithFs(testFs),
+						file.WithFile("/other"),
+					),
+				),
+			),
+			err: errors.New("The component to resolve read password does not found a password.\npassword reader #1 (*file.ReadPasswordFromFile): Error describing the file '/unexisting'.: open /unexisting: file does not exist\npassword reader #2 (*file.ReadPasswordFromFile): Error describing the file '/other'.: open /other: file does not exist"),
+		},
 		{
 			desc:   "Testing error resolve the password reader when no reader is found",
 			reader: NewReadPasswordResolve(),
@@ -73,9 +108,10 @@ func TestRead(t *testing.T) {
 
 		t.Run(test.desc, func(t *testing.T) {
 			password, err := test.reader.Read()
-			if err != nil {
+			if test.err != nil {
 				assert.EqualError(t, err, test.err.Error())
 			} else {
+				assert.NoError(t, err)
 				assert.Equal(t, test.expected, password)
 			}
 		})
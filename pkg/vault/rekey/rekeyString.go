@@ -0,0 +1,172 @@
+package rekey
+
+import (
+	"fmt"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault"
+	"github.com/apenella/go-ansible/v2/pkg/vault/decrypt"
+	"github.com/apenella/go-ansible/v2/pkg/vault/encrypt"
+	"github.com/apenella/go-ansible/v2/pkg/vault/header"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// OptionsFunc is a function used to configure RekeyString
+type OptionsFunc func(*RekeyString)
+
+// RekeyString encrypts the vaulted values, files and inline `!vault` YAML values with a new password. The vault id label of the vaulted values is kept
+type RekeyString struct {
+	reader    PasswordReader
+	newReader PasswordReader
+	fs        afero.Fs
+}
+
+// NewRekeyString returns a RekeyString
+func NewRekeyString(options ...OptionsFunc) *RekeyString {
+	rekey := &RekeyString{}
+	rekey.Options(options...)
+
+	return rekey
+}
+
+// WithReader sets the password reader that provides the current vault password
+func WithReader(reader PasswordReader) OptionsFunc {
+	return func(r *RekeyString) {
+		r.reader = reader
+	}
+}
+
+// WithNewReader sets the password reader that provides the new vault password
+func WithNewReader(reader PasswordReader) OptionsFunc {
+	return func(r *RekeyString) {
+		r.newReader = reader
+	}
+}
+
+// WithFs sets the filesystem where the vaulted files are read from and written to
+func WithFs(fs afero.Fs) OptionsFunc {
+	return func(r *RekeyString) {
+		r.fs = fs
+	}
+}
+
+// Options configure the RekeyString
+func (r *RekeyString) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(r)
+	}
+}
+
+// Rekey returns the vaulted value encrypted with the new password
+func (r *RekeyString) Rekey(cipherText string) (string, error) {
+	rekeyFunc, err := r.rekeyFunc()
+	if err != nil {
+		return "", err
+	}
+
+	return rekeyFunc(cipherText)
+}
+
+// RekeyFile encrypts the vaulted file with the new password. The file is overwritten keeping its permissions
+func (r *RekeyString) RekeyFile(file string) error {
+	if r == nil {
+		return errors.New("RekeyString must be initialized before rekeying a file.")
+	}
+
+	if r.fs == nil {
+		r.fs = afero.NewOsFs()
+	}
+
+	info, err := r.fs.Stat(file)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Error describing the file '%s'.", file))
+	}
+
+	content, err := afero.ReadFile(r.fs, file)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Error reading the file '%s'.", file))
+	}
+
+	cipherText, err := r.Rekey(string(content))
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Error rekeying the file '%s'.", file))
+	}
+
+	err = afero.WriteFile(r.fs, file, []byte(cipherText+"\n"), info.Mode().Perm())
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Error writing the file '%s'.", file))
+	}
+
+	return nil
+}
+
+// RekeyYAML returns the YAML content with its inline `!vault` values encrypted with the new password
+func (r *RekeyString) RekeyYAML(content []byte) ([]byte, error) {
+	rekeyFunc, err := r.rekeyFunc()
+	if err != nil {
+		return nil, err
+	}
+
+	cipherContent, err := vault.RewriteVaultedYAMLValues(content, func(cipherText string) (string, error) {
+		value, err := rekeyFunc(cipherText)
+		if err != nil {
+			return "", err
+		}
+
+		return value + "\n", nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Error rekeying the YAML vaulted values.")
+	}
+
+	return cipherContent, nil
+}
+
+// rekeyFunc reads the current and new passwords, and returns the function that rekeys a vaulted value using them. The passwords are read once, regardless of the number of values rekeyed
+func (r *RekeyString) rekeyFunc() (func(string) (string, error), error) {
+	if r == nil {
+		return nil, errors.New("RekeyString must be initialized before rekeying a value.")
+	}
+
+	if r.reader == nil {
+		return nil, errors.New("Password reader must be provided to rekey a value.")
+	}
+
+	if r.newReader == nil {
+		return nil, errors.New("New password reader must be provided to rekey a value.")
+	}
+
+	pass, err := r.reader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading the password")
+	}
+
+	newPass, err := r.newReader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading the new password")
+	}
+
+	decrypter := decrypt.NewDecryptString(
+		decrypt.WithReader(text.NewReadPasswordFromText(text.WithText(pass))),
+	)
+	newReader := text.NewReadPasswordFromText(text.WithText(newPass))
+
+	return func(cipherText string) (string, error) {
+		vaultHeader, err := header.ParseVaultHeader(cipherText)
+		if err != nil {
+			return "", errors.Wrap(err, "Error parsing the vault header")
+		}
+
+		plainText, err := decrypter.Decrypt(cipherText)
+		if err != nil {
+			return "", err
+		}
+
+		// the vault id label is kept on the new vaulted value
+		return encrypt.NewEncryptString(
+			encrypt.WithReader(newReader),
+			encrypt.WithLabel(vaultHeader.Label),
+		).Encrypt(plainText)
+	}, nil
+}
@@ -0,0 +1,113 @@
+package rekey
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault/decrypt"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// vaultedPayload is the payload of the "ThatIsASecretMessage" value encrypted with the "secret" password
+const vaultedPayload = `32386564373134363734663531363662663034646664633031616230303061326338316536363938
+6232393062313563383932663735303863653364656231390a616134343664616430346430323837
+62653334333962313163626134643039316466323630613266633734306233316338353038336337
+6332303734663133620a343039666237366530306463396664396439373233343532626430633338
+31326335623164383930666133313436353836303662626435343563366535633732`
+
+func newTestRekeyString(options ...OptionsFunc) *RekeyString {
+	return NewRekeyString(append([]OptionsFunc{
+		WithReader(text.NewReadPasswordFromText(text.WithText("secret"))),
+		WithNewReader(text.NewReadPasswordFromText(text.WithText("newsecret"))),
+	}, options...)...)
+}
+
+func decryptWithNewPassword(t *testing.T, cipherText string) string {
+	t.Helper()
+
+	plainText, err := decrypt.NewDecryptString(
+		decrypt.WithReader(text.NewReadPasswordFromText(text.WithText("newsecret"))),
+	).Decrypt(cipherText)
+	assert.NoError(t, err)
+
+	return plainText
+}
+
+func TestRekey(t *testing.T) {
+	tests := []struct {
+		desc       string
+		cipherText string
+		rekey      *RekeyString
+		header     string
+		err        error
+	}{
+		{
+			desc:       "Testing rekey a 1.1 vaulted value",
+			cipherText: "$ANSIBLE_VAULT;1.1;AES256\n" + vaultedPayload,
+			rekey:      newTestRekeyString(),
+			header:     "$ANSIBLE_VAULT;1.1;AES256\n",
+		},
+		{
+			desc:       "Testing rekey a 1.2 vaulted value keeping its vault id label",
+			cipherText: "$ANSIBLE_VAULT;1.2;AES256;dev\n" + vaultedPayload,
+			rekey:      newTestRekeyString(),
+			header:     "$ANSIBLE_VAULT;1.2;AES256;dev\n",
+		},
+		{
+			desc:       "Testing error rekeying a vaulted value with a wrong password",
+			cipherText: "$ANSIBLE_VAULT;1.1;AES256\n" + vaultedPayload,
+			rekey:      newTestRekeyString(WithReader(text.NewReadPasswordFromText(text.WithText("wrong")))),
+			err:        errors.New("Error decrypting the vaulted value: invalid password"),
+		},
+		{
+			desc:       "Testing error rekeying a vaulted value when the new password reader is not provided",
+			cipherText: "$ANSIBLE_VAULT;1.1;AES256\n" + vaultedPayload,
+			rekey:      NewRekeyString(WithReader(text.NewReadPasswordFromText(text.WithText("secret")))),
+			err:        errors.New("New password reader must be provided to rekey a value."),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			res, err := test.rekey.Rekey(test.cipherText)
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, strings.HasPrefix(res, test.header))
+				assert.Equal(t, "ThatIsASecretMessage", decryptWithNewPassword(t, res))
+			}
+		})
+	}
+}
+
+func TestRekeyFile(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(testFs, "/vault.yml", []byte("$ANSIBLE_VAULT;1.2;AES256;dev\n"+vaultedPayload+"\n"), 0600)
+
+	err := newTestRekeyString(WithFs(testFs)).RekeyFile("/vault.yml")
+	assert.NoError(t, err)
+
+	info, _ := testFs.Stat("/vault.yml")
+	content, _ := afero.ReadFile(testFs, "/vault.yml")
+	assert.Equal(t, "-rw-------", info.Mode().Perm().String())
+	assert.True(t, strings.HasPrefix(string(content), "$ANSIBLE_VAULT;1.2;AES256;dev\n"))
+	assert.Equal(t, "ThatIsASecretMessage", decryptWithNewPassword(t, string(content)))
+}
+
+func TestRekeyYAML(t *testing.T) {
+	content := "user: ansible\nmessage: !vault |\n  $ANSIBLE_VAULT;1.2;AES256;dev\n  " + strings.ReplaceAll(vaultedPayload, "\n", "\n  ") + "\n"
+
+	res, err := newTestRekeyString().RekeyYAML([]byte(content))
+	assert.NoError(t, err)
+
+	plainContent, err := decrypt.NewDecryptString(
+		decrypt.WithReader(text.NewReadPasswordFromText(text.WithText("newsecret"))),
+	).DecryptYAML(res)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(res), "user: ansible\nmessage: !vault |\n  $ANSIBLE_VAULT;1.2;AES256;dev\n"))
+	assert.Equal(t, "user: ansible\nmessage: ThatIsASecretMessage\n", string(plainContent))
+}
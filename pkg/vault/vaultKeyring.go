@@ -0,0 +1,150 @@
+package vault
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// VaultIDPromptSource is the vault identity source that prompts for the password
+	VaultIDPromptSource = "prompt"
+
+	// vaultIDSeparator is the separator between the label and the source of a vault identity
+	vaultIDSeparator = "@"
+)
+
+// VaultKeyringOptionsFunc is a function used to configure VaultKeyring
+type VaultKeyringOptionsFunc func(*VaultKeyring)
+
+// VaultIdentity is a vault id label with the source of its password
+type VaultIdentity struct {
+	// Label is the vault id label
+	Label string
+
+	// Source is the vault identity source passed to the ansible commands, such as a password file, a client script or `prompt`. When it is not defined, the source is provided by the password reader
+	Source string
+
+	// Reader is the password reader that provides the password of the vault identity
+	Reader PasswordReader
+}
+
+// VaultKeyring maps the vault id labels to the source of their passwords. It is expanded to a `--vault-id label@source` flag per vault identity
+type VaultKeyring struct {
+	// Identities are the vault identities, in the order they are passed to the ansible commands
+	Identities []*VaultIdentity
+}
+
+// NewVaultKeyring returns a VaultKeyring
+func NewVaultKeyring(options ...VaultKeyringOptionsFunc) *VaultKeyring {
+	keyring := &VaultKeyring{}
+	keyring.Options(options...)
+
+	return keyring
+}
+
+// WithVaultIdentity adds a vault identity whose password is provided by the password reader
+func WithVaultIdentity(label string, reader PasswordReader) VaultKeyringOptionsFunc {
+	return func(k *VaultKeyring) {
+		k.add(&VaultIdentity{
+			Label:  label,
+			Reader: reader,
+		})
+	}
+}
+
+// WithVaultIdentitySource adds a vault identity whose password is provided by the source, such as a password file, a client script or `prompt`
+func WithVaultIdentitySource(label, source string) VaultKeyringOptionsFunc {
+	return func(k *VaultKeyring) {
+		k.add(&VaultIdentity{
+			Label:  label,
+			Source: source,
+		})
+	}
+}
+
+// Options configure the VaultKeyring
+func (k *VaultKeyring) Options(opts ...VaultKeyringOptionsFunc) {
+	for _, opt := range opts {
+		opt(k)
+	}
+}
+
+// add adds the vault identity, replacing the one with the same label
+func (k *VaultKeyring) add(identity *VaultIdentity) {
+	for i, current := range k.Identities {
+		if current.Label == identity.Label {
+			k.Identities[i] = identity
+			return
+		}
+	}
+
+	k.Identities = append(k.Identities, identity)
+}
+
+// Labels returns the vault id labels
+func (k *VaultKeyring) Labels() []string {
+	labels := []string{}
+
+	if k == nil {
+		return labels
+	}
+
+	for _, identity := range k.Identities {
+		labels = append(labels, identity.Label)
+	}
+
+	return labels
+}
+
+// Reader returns the password reader of the vault id label
+func (k *VaultKeyring) Reader(label string) (PasswordReader, error) {
+	if k == nil {
+		return nil, errors.New("VaultKeyring must be initialized before getting a password reader.")
+	}
+
+	for _, identity := range k.Identities {
+		if identity.Label != label {
+			continue
+		}
+
+		if identity.Reader == nil {
+			return nil, errors.New(fmt.Sprintf("Vault identity '%s' does not have a password reader.", label))
+		}
+
+		return identity.Reader, nil
+	}
+
+	return nil, errors.New(fmt.Sprintf("Vault identity '%s' is not defined.", label))
+}
+
+// VaultIDs returns the vault identities as `label@source`, to be passed to the ansible commands by the `--vault-id` flag
+func (k *VaultKeyring) VaultIDs() ([]string, error) {
+	vaultIDs := []string{}
+
+	if k == nil {
+		return vaultIDs, nil
+	}
+
+	for _, identity := range k.Identities {
+		if identity.Label == "" {
+			return nil, errors.New("Vault identity label must be defined.")
+		}
+
+		source := identity.Source
+		if source == "" {
+			sourcer, ok := identity.Reader.(VaultIDSourcer)
+			if ok {
+				source = sourcer.VaultIDSource()
+			}
+		}
+
+		if source == "" {
+			return nil, errors.New(fmt.Sprintf("Vault identity '%s' does not have a source to be passed to the ansible commands.", identity.Label))
+		}
+
+		vaultIDs = append(vaultIDs, identity.Label+vaultIDSeparator+source)
+	}
+
+	return vaultIDs, nil
+}
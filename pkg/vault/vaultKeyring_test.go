@@ -0,0 +1,112 @@
+package vault
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sourcePasswordReader is a password reader that can be passed to the ansible commands as a vault identity source
+type sourcePasswordReader struct {
+	source string
+}
+
+func (r *sourcePasswordReader) Read() (string, error) {
+	return "secret", nil
+}
+
+func (r *sourcePasswordReader) VaultIDSource() string {
+	return r.source
+}
+
+// textPasswordReader is a password reader that can not be passed to the ansible commands
+type textPasswordReader struct{}
+
+func (r *textPasswordReader) Read() (string, error) {
+	return "secret", nil
+}
+
+func TestNewVaultKeyring(t *testing.T) {
+	reader := &textPasswordReader{}
+
+	keyring := NewVaultKeyring(
+		WithVaultIdentitySource("dev", "dev-password-file"),
+		WithVaultIdentity("prod", reader),
+		WithVaultIdentitySource("dev", VaultIDPromptSource),
+	)
+
+	assert.Equal(t, []*VaultIdentity{
+		{Label: "dev", Source: VaultIDPromptSource},
+		{Label: "prod", Reader: reader},
+	}, keyring.Identities)
+	assert.Equal(t, []string{"dev", "prod"}, keyring.Labels())
+}
+
+func TestVaultKeyringReader(t *testing.T) {
+	reader := &textPasswordReader{}
+	keyring := NewVaultKeyring(
+		WithVaultIdentity("prod", reader),
+		WithVaultIdentitySource("dev", "dev-password-file"),
+	)
+
+	res, err := keyring.Reader("prod")
+	assert.NoError(t, err)
+	assert.Equal(t, reader, res)
+
+	_, err = keyring.Reader("dev")
+	assert.EqualError(t, err, "Vault identity 'dev' does not have a password reader.")
+
+	_, err = keyring.Reader("test")
+	assert.EqualError(t, err, "Vault identity 'test' is not defined.")
+}
+
+func TestVaultKeyringVaultIDs(t *testing.T) {
+	tests := []struct {
+		desc     string
+		keyring  *VaultKeyring
+		vaultIDs []string
+		err      error
+	}{
+		{
+			desc: "Testing generate the vault ids",
+			keyring: NewVaultKeyring(
+				WithVaultIdentitySource("dev", "dev-password-file"),
+				WithVaultIdentity("prod", &sourcePasswordReader{source: "prod-password-file"}),
+				WithVaultIdentitySource("test", VaultIDPromptSource),
+			),
+			vaultIDs: []string{"dev@dev-password-file", "prod@prod-password-file", "test@prompt"},
+		},
+		{
+			desc:     "Testing generate the vault ids of a nil keyring",
+			keyring:  nil,
+			vaultIDs: []string{},
+		},
+		{
+			desc: "Testing error generating the vault ids when a password reader can not be used as source",
+			keyring: NewVaultKeyring(
+				WithVaultIdentity("prod", &textPasswordReader{}),
+			),
+			err: errors.New("Vault identity 'prod' does not have a source to be passed to the ansible commands."),
+		},
+		{
+			desc: "Testing error generating the vault ids when the label is not defined",
+			keyring: NewVaultKeyring(
+				WithVaultIdentitySource("", "password-file"),
+			),
+			err: errors.New("Vault identity label must be defined."),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			vaultIDs, err := test.keyring.VaultIDs()
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.vaultIDs, vaultIDs)
+			}
+		})
+	}
+}
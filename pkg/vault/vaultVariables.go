@@ -0,0 +1,175 @@
+package vault
+
+import (
+	"fmt"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault/header"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// VaultVariables returns a copy of the variables where every string value is vaulted as a VaultVariableValue, including the ones of the nested maps and lists. The rest of values are kept as they are.
+func VaultVariables(vaulter Vaulter, vars map[string]interface{}) (map[string]interface{}, error) {
+	if vaulter == nil {
+		return nil, errors.New("Vaulter must be provided to vault the variables.")
+	}
+
+	vaultedVars := make(map[string]interface{}, len(vars))
+	for name, value := range vars {
+		vaultedValue, err := vaultValue(vaulter, name, value)
+		if err != nil {
+			return nil, err
+		}
+		vaultedVars[name] = vaultedValue
+	}
+
+	return vaultedVars, nil
+}
+
+// VariablesStringValues returns the string values of the variables, including the ones of the nested maps and lists, which are the values vaulted by VaultVariables
+func VariablesStringValues(vars map[string]interface{}) []string {
+	values := []string{}
+	for _, value := range vars {
+		values = append(values, stringValues(value)...)
+	}
+
+	return values
+}
+
+// stringValues returns the string values found in the value
+func stringValues(value interface{}) []string {
+	values := []string{}
+
+	switch v := value.(type) {
+	case string:
+		values = append(values, v)
+	case map[string]interface{}:
+		for _, item := range v {
+			values = append(values, stringValues(item)...)
+		}
+	case map[string]string:
+		for _, item := range v {
+			values = append(values, item)
+		}
+	case []interface{}:
+		for _, item := range v {
+			values = append(values, stringValues(item)...)
+		}
+	case []string:
+		values = append(values, v...)
+	}
+
+	return values
+}
+
+// vaultValue vaults the string values found in the value. The path identifies the value on the error messages
+func vaultValue(vaulter Vaulter, path string, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		vaultedValue, err := vaulter.Vault(v)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("Error vaulting the variable '%s'.", path))
+		}
+		return vaultedValue, nil
+	case map[string]interface{}:
+		vaultedMap := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			vaultedItem, err := vaultValue(vaulter, fmt.Sprintf("%s.%s", path, key), item)
+			if err != nil {
+				return nil, err
+			}
+			vaultedMap[key] = vaultedItem
+		}
+		return vaultedMap, nil
+	case map[string]string:
+		vaultedMap := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			vaultedItem, err := vaultValue(vaulter, fmt.Sprintf("%s.%s", path, key), item)
+			if err != nil {
+				return nil, err
+			}
+			vaultedMap[key] = vaultedItem
+		}
+		return vaultedMap, nil
+	case []interface{}:
+		vaultedList := make([]interface{}, 0, len(v))
+		for i, item := range v {
+			vaultedItem, err := vaultValue(vaulter, fmt.Sprintf("%s[%d]", path, i), item)
+			if err != nil {
+				return nil, err
+			}
+			vaultedList = append(vaultedList, vaultedItem)
+		}
+		return vaultedList, nil
+	case []string:
+		vaultedList := make([]interface{}, 0, len(v))
+		for i, item := range v {
+			vaultedItem, err := vaultValue(vaulter, fmt.Sprintf("%s[%d]", path, i), item)
+			if err != nil {
+				return nil, err
+			}
+			vaultedList = append(vaultedList, vaultedItem)
+		}
+		return vaultedList, nil
+	default:
+		return value, nil
+	}
+}
+
+// EncryptVariablesFile encrypts the whole content of a YAML or JSON variables file, and writes it to the vaulted file. Both files are accessed through the filesystem, which is the OS filesystem when it is nil. The vaulted file can be passed to the ansible commands as an extra-vars file
+func EncryptVariablesFile(fs afero.Fs, encrypter Encrypter, file, vaultedFile string) error {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+
+	content, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Error reading the variables file '%s'.", file))
+	}
+
+	if header.IsVaulted(string(content)) {
+		return errors.New(fmt.Sprintf("Variables file '%s' is already vaulted.", file))
+	}
+
+	vars := map[string]interface{}{}
+	err = yaml.Unmarshal(content, &vars)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Error parsing the variables file '%s'.", file))
+	}
+
+	return encryptToFile(fs, encrypter, string(content), vaultedFile)
+}
+
+// EncryptVariablesToFile encrypts the variables, in YAML format, and writes them to the vaulted file on the filesystem, which is the OS filesystem when it is nil. The vaulted file can be passed to the ansible commands as an extra-vars file
+func EncryptVariablesToFile(fs afero.Fs, encrypter Encrypter, vars map[string]interface{}, vaultedFile string) error {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+
+	content, err := yaml.Marshal(vars)
+	if err != nil {
+		return errors.Wrap(err, "Error converting the variables to YAML.")
+	}
+
+	return encryptToFile(fs, encrypter, string(content), vaultedFile)
+}
+
+// encryptToFile encrypts the content and writes it to the vaulted file, which is only accessible by the current user
+func encryptToFile(fs afero.Fs, encrypter Encrypter, content, vaultedFile string) error {
+	if encrypter == nil {
+		return errors.New("Encrypter must be provided to encrypt the variables.")
+	}
+
+	encryptedContent, err := encrypter.Encrypt(content)
+	if err != nil {
+		return errors.Wrap(err, "Error encrypting the variables.")
+	}
+
+	err = afero.WriteFile(fs, vaultedFile, []byte(encryptedContent+"\n"), 0600)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Error writing the vaulted file '%s'.", vaultedFile))
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package vault
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// prefixEncrypter is an Encrypter that prefixes the plain text with a fake vault header
+type prefixEncrypter struct{}
+
+func (e *prefixEncrypter) Encrypt(plainText string) (string, error) {
+	return "$ANSIBLE_VAULT;1.1;AES256\n" + strings.TrimSpace(plainText), nil
+}
+
+func TestVaultVariables(t *testing.T) {
+	vaulter := NewMockVariableVaulter()
+	for _, value := range []string{"admin", "secret", "db1", "tk", "k1"} {
+		vaulter.On("Vault", value).Return(NewVaultVariableValue("vaulted_"+value), nil)
+	}
+
+	vars := map[string]interface{}{
+		"user":    "admin",
+		"port":    5432,
+		"enabled": true,
+		"db": map[string]interface{}{
+			"password": "secret",
+			"hosts":    []interface{}{"db1", map[string]string{"token": "tk"}},
+		},
+		"keys": []string{"k1"},
+	}
+
+	res, err := VaultVariables(vaulter, vars)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"user":    NewVaultVariableValue("vaulted_admin"),
+		"port":    5432,
+		"enabled": true,
+		"db": map[string]interface{}{
+			"password": NewVaultVariableValue("vaulted_secret"),
+			"hosts": []interface{}{
+				NewVaultVariableValue("vaulted_db1"),
+				map[string]interface{}{"token": NewVaultVariableValue("vaulted_tk")},
+			},
+		},
+		"keys": []interface{}{NewVaultVariableValue("vaulted_k1")},
+	}, res)
+	assert.Equal(t, "secret", vars["db"].(map[string]interface{})["password"])
+}
+
+func TestVariablesStringValues(t *testing.T) {
+	vars := map[string]interface{}{
+		"user":    "admin",
+		"port":    5432,
+		"enabled": true,
+		"db": map[string]interface{}{
+			"password": "secret",
+			"hosts":    []interface{}{"db1", map[string]string{"token": "tk"}},
+		},
+		"keys": []string{"k1"},
+	}
+
+	assert.ElementsMatch(t, []string{"admin", "secret", "db1", "tk", "k1"}, VariablesStringValues(vars))
+}
+
+func TestVaultVariablesError(t *testing.T) {
+	vaulter := NewMockVariableVaulter()
+	vaulter.On("Vault", "secret").Return((*VaultVariableValue)(nil), errors.New("encryption failed"))
+
+	_, err := VaultVariables(vaulter, map[string]interface{}{
+		"db": map[string]interface{}{
+			"passwords": []interface{}{"secret"},
+		},
+	})
+	assert.EqualError(t, err, "Error vaulting the variable 'db.passwords[0]'.: encryption failed")
+
+	_, err = VaultVariables(nil, map[string]interface{}{})
+	assert.EqualError(t, err, "Vaulter must be provided to vault the variables.")
+}
+
+func TestEncryptVariablesFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_ = afero.WriteFile(fs, "vars.yml", []byte("user: admin\npassword: secret\n"), 0644)
+	_ = afero.WriteFile(fs, "list.yml", []byte("- user\n"), 0644)
+
+	err := EncryptVariablesFile(fs, &prefixEncrypter{}, "vars.yml", "vars.vault.yml")
+	assert.NoError(t, err)
+
+	content, _ := afero.ReadFile(fs, "vars.vault.yml")
+	info, _ := fs.Stat("vars.vault.yml")
+	assert.Equal(t, "$ANSIBLE_VAULT;1.1;AES256\nuser: admin\npassword: secret\n", string(content))
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	err = EncryptVariablesFile(fs, &prefixEncrypter{}, "vars.vault.yml", "other.yml")
+	assert.EqualError(t, err, "Variables file 'vars.vault.yml' is already vaulted.")
+
+	err = EncryptVariablesFile(fs, &prefixEncrypter{}, "list.yml", "vars.vault.yml")
+	assert.ErrorContains(t, err, "Error parsing the variables file 'list.yml'.")
+
+	err = EncryptVariablesFile(fs, &prefixEncrypter{}, "missing.yml", "vars.vault.yml")
+	assert.ErrorContains(t, err, "Error reading the variables file 'missing.yml'.")
+}
+
+func TestEncryptVariablesFileOsFs(t *testing.T) {
+	dir := t.TempDir()
+
+	varsFile := filepath.Join(dir, "vars.yml")
+	vaultedFile := filepath.Join(dir, "vars.vault.yml")
+	_ = os.WriteFile(varsFile, []byte("user: admin\n"), 0644)
+
+	err := EncryptVariablesFile(nil, &prefixEncrypter{}, varsFile, vaultedFile)
+	assert.NoError(t, err)
+
+	content, _ := os.ReadFile(vaultedFile)
+	assert.Equal(t, "$ANSIBLE_VAULT;1.1;AES256\nuser: admin\n", string(content))
+}
+
+func TestEncryptVariablesToFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	err := EncryptVariablesToFile(fs, &prefixEncrypter{}, map[string]interface{}{
+		"db": map[string]interface{}{"password": "secret"},
+	}, "vars.vault.yml")
+	assert.NoError(t, err)
+
+	content, _ := afero.ReadFile(fs, "vars.vault.yml")
+	assert.Equal(t, "$ANSIBLE_VAULT;1.1;AES256\ndb:\n    password: secret\n", string(content))
+}
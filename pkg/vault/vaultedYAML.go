@@ -0,0 +1,121 @@
+package vault
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// VaultYAMLTag is the YAML tag of the inline vaulted values
+	VaultYAMLTag = "!vault"
+
+	// yamlStringTag is the YAML tag of the string values
+	yamlStringTag = "!!str"
+
+	// yamlIndent is the indentation used to write the YAML documents
+	yamlIndent = 2
+)
+
+// VaultedValueFunc transforms the vaulted value of an inline `!vault` YAML value
+type VaultedValueFunc func(cipherText string) (string, error)
+
+// ReplaceVaultedYAMLValues replaces the inline `!vault` values of the YAML content by the string returned by the function. The returned values are not tagged as vaulted, so it is used to decrypt them
+func ReplaceVaultedYAMLValues(content []byte, fn VaultedValueFunc) ([]byte, error) {
+	return transformVaultedYAMLValues(content, func(node *yaml.Node) error {
+		value, err := fn(node.Value)
+		if err != nil {
+			return err
+		}
+
+		node.Tag = yamlStringTag
+		node.Value = value
+		node.Style = 0
+		if strings.Contains(value, "\n") {
+			node.Style = yaml.LiteralStyle
+		}
+
+		return nil
+	})
+}
+
+// RewriteVaultedYAMLValues rewrites the inline `!vault` values of the YAML content with the vaulted value returned by the function. The values are kept tagged as vaulted, so it is used to rekey them
+func RewriteVaultedYAMLValues(content []byte, fn VaultedValueFunc) ([]byte, error) {
+	return transformVaultedYAMLValues(content, func(node *yaml.Node) error {
+		value, err := fn(node.Value)
+		if err != nil {
+			return err
+		}
+
+		node.Value = value
+		node.Style = yaml.LiteralStyle
+
+		return nil
+	})
+}
+
+// transformVaultedYAMLValues applies the transformation to each inline `!vault` value of the YAML content
+func transformVaultedYAMLValues(content []byte, transform func(*yaml.Node) error) ([]byte, error) {
+	var err error
+	var buff bytes.Buffer
+
+	documents := []*yaml.Node{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		document := &yaml.Node{}
+		err = decoder.Decode(document)
+		if err != nil {
+			break
+		}
+		documents = append(documents, document)
+	}
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "Error parsing the YAML content.")
+	}
+
+	for _, document := range documents {
+		err = walkVaultedYAMLValues(document, transform)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	encoder := yaml.NewEncoder(&buff)
+	encoder.SetIndent(yamlIndent)
+	for _, document := range documents {
+		err = encoder.Encode(document)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error writing the YAML content.")
+		}
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error writing the YAML content.")
+	}
+
+	return buff.Bytes(), nil
+}
+
+// walkVaultedYAMLValues applies the transformation to the node, when it is an inline `!vault` value, and to its children
+func walkVaultedYAMLValues(node *yaml.Node, transform func(*yaml.Node) error) error {
+	if node.Kind == yaml.ScalarNode && node.Tag == VaultYAMLTag {
+		err := transform(node)
+		if err != nil {
+			return errors.Wrap(err, "Error transforming a vaulted value.")
+		}
+		return nil
+	}
+
+	for _, child := range node.Content {
+		err := walkVaultedYAMLValues(child, transform)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package vault
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceVaultedYAMLValues(t *testing.T) {
+	tests := []struct {
+		desc     string
+		content  string
+		fn       VaultedValueFunc
+		expected string
+		err      error
+	}{
+		{
+			desc: "Testing replace the inline vaulted values",
+			content: `user: ansible
+password: !vault |
+  $ANSIBLE_VAULT;1.1;AES256
+  secret
+nested:
+  - token: !vault |
+      $ANSIBLE_VAULT;1.2;AES256;dev
+      1234
+`,
+			fn: func(cipherText string) (string, error) {
+				return strings.Split(strings.TrimSpace(cipherText), "\n")[1], nil
+			},
+			expected: `user: ansible
+password: secret
+nested:
+  - token: "1234"
+`,
+		},
+		{
+			desc:    "Testing replace the inline vaulted values with a multiline value",
+			content: "key: !vault |\n  $ANSIBLE_VAULT;1.1;AES256\n  key\n",
+			fn: func(cipherText string) (string, error) {
+				return "first line\nsecond line\n", nil
+			},
+			expected: "key: |\n  first line\n  second line\n",
+		},
+		{
+			desc:    "Testing error replacing the inline vaulted values",
+			content: "password: !vault |\n  $ANSIBLE_VAULT;1.1;AES256\n  secret\n",
+			fn: func(cipherText string) (string, error) {
+				return "", errors.New("wrong password")
+			},
+			err: errors.New("Error transforming a vaulted value.: wrong password"),
+		},
+		{
+			desc:    "Testing error replacing the inline vaulted values of an invalid YAML content",
+			content: "password: [",
+			fn: func(cipherText string) (string, error) {
+				return cipherText, nil
+			},
+			err: errors.New("Error parsing the YAML content.: yaml: line 1: did not find expected node content"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			res, err := ReplaceVaultedYAMLValues([]byte(test.content), test.fn)
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, string(res))
+			}
+		})
+	}
+}
+
+func TestRewriteVaultedYAMLValues(t *testing.T) {
+	content := "---\nuser: ansible\npassword: !vault |\n  $ANSIBLE_VAULT;1.1;AES256\n  secret\n---\ntoken: !vault |\n  $ANSIBLE_VAULT;1.2;AES256;dev\n  1234\n"
+
+	res, err := RewriteVaultedYAMLValues([]byte(content), func(cipherText string) (string, error) {
+		return strings.ToUpper(cipherText), nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user: ansible\npassword: !vault |\n  $ANSIBLE_VAULT;1.1;AES256\n  SECRET\n---\ntoken: !vault |\n  $ANSIBLE_VAULT;1.2;AES256;DEV\n  1234\n", string(res))
+}
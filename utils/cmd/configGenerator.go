@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"go/format"
 	"log"
 	"net/http"
 	"os"
@@ -13,10 +14,24 @@ import (
 )
 
 type config struct {
-	description string
-	env         string
-	name        string
-	vartype     string
+	choices      []string
+	defaultValue string
+	description  string
+	env          string
+	ini          []*configIni
+	name         string
+	vartype      string
+	versionAdded string
+}
+
+type configIni struct {
+	section string
+	key     string
+}
+
+// metadataIniAliases are the ansible.cfg entries that define a setting but are not listed by ansible for it, such as the ones of the connection plugins. They are appended to the setting entries, so they are loaded from an ansible.cfg file but never written to it
+var metadataIniAliases = map[string][]*configIni{
+	"ANSIBLE_PIPELINING": {{section: "ssh_connection", key: "pipelining"}},
 }
 
 func LoadConfigs(url string) []*config {
@@ -69,6 +84,32 @@ func LoadConfigs(url string) []*config {
 						}
 						c.env = strings.TrimSpace(envvar[0])
 					}
+
+					// Default and Version Added, but not the version added of the environment variables
+					if s.Text() == "Default:" {
+						c.defaultValue = strings.TrimSpace(s.Next().Text())
+					}
+
+					if s.Text() == "Version Added:" && s.Parent().IsSelection(fieldListSimpleItem) {
+						c.versionAdded = strings.TrimSpace(s.Next().Text())
+					}
+
+					if s.Text() == "Choices:" {
+						s.Next().Find("li").Each(func(i int, choice *goquery.Selection) {
+							value, _, _ := strings.Cut(choice.Text(), ":")
+							c.choices = append(c.choices, strings.TrimSpace(value))
+						})
+					}
+
+					// Ini.Section and Ini.Key
+					if s.Text() == "Section:" {
+						section := strings.Trim(strings.TrimSpace(s.Next().Text()), "[]")
+						c.ini = append(c.ini, &configIni{section: section})
+					}
+
+					if s.Text() == "Key:" && len(c.ini) > 0 {
+						c.ini[len(c.ini)-1].key = strings.TrimSpace(s.Next().Text())
+					}
 				})
 
 			}
@@ -87,12 +128,26 @@ func generateConst(config *config) string {
 	} else {
 
 		varname := strcase.ToCamel(config.env)
-		str = fmt.Sprintf("\t// %s (%s) %s\n", varname, config.vartype, config.description)
+		str = fmt.Sprintf("\t// %s (%s) %s%s\n", varname, config.vartype, config.description, generateConstDetails(config))
 		str = fmt.Sprintf("%s\t%s = \"%s\"\n", str, varname, config.env)
 	}
 	return str
 }
 
+func generateConstDetails(config *config) string {
+	str := ""
+	if config.defaultValue != "" {
+		str = fmt.Sprintf("%s [Default: %s]", str, config.defaultValue)
+	}
+	if len(config.choices) > 0 {
+		str = fmt.Sprintf("%s [Choices: %s]", str, strings.Join(config.choices, ", "))
+	}
+	if config.versionAdded != "" {
+		str = fmt.Sprintf("%s [Version Added: %s]", str, config.versionAdded)
+	}
+	return str
+}
+
 func generateConsts(configs []*config) string {
 	str := "const (\n"
 	for _, config := range configs {
@@ -171,18 +226,97 @@ func generateTests(configs []*config) string {
 	return str
 }
 
+func generateMetadataItem(config *config) string {
+	str := ""
+	if config.env != "" {
+
+		varname := strcase.ToCamel(config.env)
+
+		str = fmt.Sprintf("\t%s: {\n\t\tName: \"%s\",\n\t\tEnvVar: %s,\n\t\tType: %s,\n", varname, config.name, varname, metadataType(config.vartype))
+		if len(config.choices) > 0 {
+			str = fmt.Sprintf("%s\t\tChoices: []string{\"%s\"},\n", str, strings.Join(config.choices, "\", \""))
+		}
+		if len(config.ini) > 0 {
+			entries := []string{}
+			for _, ini := range config.ini {
+				entries = append(entries, fmt.Sprintf("{Section: \"%s\", Key: \"%s\"}", ini.section, ini.key))
+			}
+			for _, ini := range metadataIniAliases[config.env] {
+				entry := fmt.Sprintf("{Section: \"%s\", Key: \"%s\"}", ini.section, ini.key)
+				if !containsEntry(entries, entry) {
+					entries = append(entries, entry)
+				}
+			}
+			str = fmt.Sprintf("%s\t\tIni: []ConfigurationSettingIni{%s},\n", str, strings.Join(entries, ", "))
+		}
+		str = fmt.Sprintf("%s\t},\n", str)
+	}
+	return str
+}
+
+// containsEntry returns whether the entry is one of the entries
+func containsEntry(entries []string, entry string) bool {
+	for _, e := range entries {
+		if e == entry {
+			return true
+		}
+	}
+
+	return false
+}
+
+func metadataType(vartype string) string {
+	switch vartype {
+	case "boolean", "bool":
+		return "ConfigurationSettingTypeBool"
+	case "integer", "int":
+		return "ConfigurationSettingTypeInt"
+	case "float":
+		return "ConfigurationSettingTypeFloat"
+	case "list":
+		return "ConfigurationSettingTypeList"
+	case "path", "tmppath":
+		return "ConfigurationSettingTypePath"
+	case "pathspec":
+		return "ConfigurationSettingTypePathSpec"
+	case "pathlist":
+		return "ConfigurationSettingTypePathList"
+	default:
+		return "ConfigurationSettingTypeString"
+	}
+}
+
+// generateMetadata generates the whole ansibleConfigurationSettingsMetadata.go file of the configuration package
+func generateMetadata(configs []*config) string {
+	str := "// Code generated by utils/cmd/configGenerator.go metadata; DO NOT EDIT.\n\npackage configuration\n\n"
+	str = fmt.Sprintf("%s// configurationSettingsMetadata is the metadata of the ansible configuration settings that can be defined by environment variable, indexed by the environment variable name\n", str)
+	str = fmt.Sprintf("%svar configurationSettingsMetadata = map[string]*ConfigurationSettingMetadata{\n", str)
+	for _, config := range configs {
+		str = fmt.Sprintf("%s%s", str, generateMetadataItem(config))
+	}
+	str = fmt.Sprintf("%s}\n", str)
+
+	formatted, err := format.Source([]byte(str))
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	return string(formatted)
+}
+
 type generateFunc func([]*config) string
 
 func main() {
 
 	args := os.Args[1:]
-	invalidOptionsMsgErr := fmt.Sprintf("Invalid option.\n\n%s <options>\n\n OPTIONS:\n - const: Generate constants\n - method: generated methods\n - test: Generate tests\n\n", path.Base(os.Args[0]))
+	invalidOptionsMsgErr := fmt.Sprintf("Invalid option.\n\n%s <options> [<ansible-config list output file>]\n\n OPTIONS:\n - const: Generate constants\n - method: generated methods\n - test: Generate tests\n - metadata: Generate the ansibleConfigurationSettingsMetadata.go file\n\n The configs are scraped from the Ansible documentation, unless a file with the output of 'ansible-config list --format yaml' or 'ansible-config list --format json' is provided.\n\n", path.Base(os.Args[0]))
 
-	if len(args) != 1 {
+	if len(args) < 1 || len(args) > 2 {
 		log.Fatal(invalidOptionsMsgErr)
 	}
 
 	var f generateFunc
+	var configs []*config
 
 	// Request the HTML page.
 	url := "https://docs.ansible.com/ansible/latest/reference_appendices/config.html"
@@ -194,11 +328,17 @@ func main() {
 		f = generateConfigMethods
 	case "test":
 		f = generateTests
+	case "metadata":
+		f = generateMetadata
 	default:
 		log.Fatal(invalidOptionsMsgErr)
 	}
 
-	configs := LoadConfigs(url)
-	fmt.Println(f(configs))
+	if len(args) == 2 {
+		configs = LoadConfigsFromFile(args[1])
+	} else {
+		configs = LoadConfigs(url)
+	}
+	fmt.Print(f(configs))
 
 }
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configListEntry is a setting of the `ansible-config list --format yaml` or `ansible-config list --format json` output
+type configListEntry struct {
+	Choices     yaml.Node   `yaml:"choices"`
+	Default     interface{} `yaml:"default"`
+	Description yaml.Node   `yaml:"description"`
+	Env         []struct {
+		Name string `yaml:"name"`
+	} `yaml:"env"`
+	Ini []struct {
+		Key     string `yaml:"key"`
+		Section string `yaml:"section"`
+	} `yaml:"ini"`
+	Type         string      `yaml:"type"`
+	VersionAdded interface{} `yaml:"version_added"`
+}
+
+// LoadConfigsFromFile loads the configs from a file that holds the `ansible-config list` output, either in YAML or JSON format since JSON is a subset of YAML
+func LoadConfigsFromFile(file string) []*config {
+	var entries map[string]*configListEntry
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	err = yaml.Unmarshal(content, &entries)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	configs := []*config{}
+	for _, name := range names {
+		entry := entries[name]
+		if entry == nil {
+			continue
+		}
+
+		c := &config{
+			choices:      nodeValues(&entry.Choices),
+			defaultValue: formatValue(entry.Default),
+			description:  strings.Join(nodeValues(&entry.Description), " "),
+			name:         name,
+			vartype:      entry.Type,
+			versionAdded: formatValue(entry.VersionAdded),
+		}
+
+		// the last environment variable is used, as it happens when the configs are scraped from the documentation
+		if len(entry.Env) > 0 {
+			c.env = entry.Env[len(entry.Env)-1].Name
+		}
+
+		for _, ini := range entry.Ini {
+			c.ini = append(c.ini, &configIni{section: ini.Section, key: ini.Key})
+		}
+
+		configs = append(configs, c)
+	}
+
+	return configs
+}
+
+// nodeValues returns the values of a scalar or sequence node, or the keys of a mapping node, such as the choices described by a dictionary
+func nodeValues(node *yaml.Node) []string {
+	values := []string{}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		values = append(values, strings.TrimSpace(node.Value))
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			values = append(values, strings.TrimSpace(item.Value))
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			values = append(values, strings.TrimSpace(node.Content[i].Value))
+		}
+	}
+
+	return values
+}
+
+// formatValue returns the value as it is written in the generated comments
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}